@@ -0,0 +1,5 @@
+data "pingaccess_agents" "all" {}
+
+output "agent_certificate_hashes" {
+  value = { for a in data.pingaccess_agents.all.agents : a.name => a.certificate_hash }
+}
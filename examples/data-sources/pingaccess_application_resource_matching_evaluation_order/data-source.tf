@@ -0,0 +1,7 @@
+data "pingaccess_application_resource_matching_evaluation_order" "example" {
+  application_id = pingaccess_application.example.id
+}
+
+output "regex_patterns" {
+  value = [for e in data.pingaccess_application_resource_matching_evaluation_order.example.entries : e.pattern if e.pattern_type == "REGEX"]
+}
@@ -0,0 +1,7 @@
+data "pingaccess_backup" "pre_change" {
+  output_path = "${path.root}/backups/pingaccess-pre-change.zip"
+}
+
+output "backup_sha256" {
+  value = data.pingaccess_backup.pre_change.sha256
+}
@@ -0,0 +1,6 @@
+data "pingaccess_config_export" "snapshot" {}
+
+resource "local_sensitive_file" "snapshot" {
+  filename = "${path.module}/pingaccess-${data.pingaccess_config_export.snapshot.sha256}.json"
+  content  = data.pingaccess_config_export.snapshot.data
+}
@@ -0,0 +1,18 @@
+data "pingaccess_engines" "cluster" {}
+
+resource "pingaccess_site" "example" {
+  name    = "example"
+  targets = ["backend.example.com:443"]
+  secure  = true
+
+  lifecycle {
+    precondition {
+      condition = alltrue([
+        for e in data.pingaccess_engines.cluster.engines :
+        e.seconds_since_last_updated >= 0 && e.seconds_since_last_updated < 120
+        if e.config_replication_enabled
+      ])
+      error_message = "An engine has not polled the administrative node in the last two minutes."
+    }
+  }
+}
@@ -0,0 +1,8 @@
+data "pingaccess_plugin_descriptor" "example" {
+  type       = "site_authenticator"
+  class_name = "com.pingidentity.pa.siteauthenticators.BasicAuthTargetSiteAuthenticator"
+}
+
+output "fields" {
+  value = data.pingaccess_plugin_descriptor.example.configuration_fields[*].name
+}
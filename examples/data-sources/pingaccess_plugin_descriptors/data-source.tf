@@ -0,0 +1,7 @@
+data "pingaccess_plugin_descriptors" "example" {
+  type = "site_authenticator"
+}
+
+output "site_authenticator_classes" {
+  value = data.pingaccess_plugin_descriptors.example.descriptors[*].class_name
+}
@@ -0,0 +1,5 @@
+data "pingaccess_replica_admins" "all" {}
+
+output "replicating_admins" {
+  value = [for r in data.pingaccess_replica_admins.all.replica_admins : r.host_port if r.config_replication_enabled]
+}
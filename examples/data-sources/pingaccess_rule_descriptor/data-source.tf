@@ -0,0 +1,7 @@
+data "pingaccess_rule_descriptor" "example" {
+  class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
+}
+
+output "required_fields" {
+  value = [for f in data.pingaccess_rule_descriptor.example.configuration_fields : f.name if f.required]
+}
@@ -0,0 +1,5 @@
+data "pingaccess_rule_descriptors" "example" {}
+
+output "rule_classes" {
+  value = data.pingaccess_rule_descriptors.example.descriptors[*].class_name
+}
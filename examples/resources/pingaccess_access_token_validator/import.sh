@@ -1,2 +1,4 @@
 # Import assumes the new structured configuration style, the old json style will show a diff on next plan
 terraform import pingaccess_access_token_validator.demo_access_token_validator 123
+# or by name, e.g.
+terraform import pingaccess_access_token_validator.demo_access_token_validator "name=demo"
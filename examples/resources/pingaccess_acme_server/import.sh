@@ -1 +1,3 @@
 terraform import pingaccess_acme_server.example 123
+# or by name, e.g.
+terraform import pingaccess_acme_server.example "name=example"
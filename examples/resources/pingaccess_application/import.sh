@@ -1 +1,5 @@
 terraform import pingaccess_application.example 123
+# or by name, e.g.
+terraform import pingaccess_application.example "name=example"
+# or by context root and virtual host, e.g.
+terraform import pingaccess_application.example /example@example.com:443
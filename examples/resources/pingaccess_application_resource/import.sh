@@ -1,2 +1,4 @@
 # PingAccess application resources can be imported using the application/resource id, e.g.
 terraform import pingaccess_application_resource.example 1/5
+# or by the application name and resource name, e.g.
+terraform import pingaccess_application_resource.example "example/api"
@@ -0,0 +1,5 @@
+# PingAccess application resource orders can be imported using the application id, e.g.
+terraform import pingaccess_application_resource_order.example 1
+# or by the application name, or context root and virtual host, e.g.
+terraform import pingaccess_application_resource_order.example "name=example"
+terraform import pingaccess_application_resource_order.example /example@example.com:443
@@ -0,0 +1,14 @@
+resource "pingaccess_application_resource_order" "example" {
+  application_id = pingaccess_application.example.id
+  resource_ids = [
+    pingaccess_application_resource.specific.id,
+    pingaccess_application_resource.general.id,
+    pingaccess_application_resource.root.id,
+  ]
+
+  depends_on = [
+    pingaccess_application_resource.specific,
+    pingaccess_application_resource.general,
+    pingaccess_application_resource.root,
+  ]
+}
@@ -1 +1,3 @@
 terraform import authn_req_list.example 123
+# or by name, e.g.
+terraform import authn_req_list.example "name=example"
@@ -1 +1,3 @@
 terraform import pingaccess_availability_profile.example 123
+# or by name, e.g.
+terraform import pingaccess_availability_profile.example "name=example"
@@ -1 +1,3 @@
 terraform import pingaccess_certificate.example 123
+# or by alias, e.g.
+terraform import pingaccess_certificate.example "alias=example"
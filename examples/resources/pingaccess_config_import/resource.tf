@@ -0,0 +1,12 @@
+data "pingaccess_config_export" "primary" {
+  provider = pingaccess.primary
+}
+
+resource "pingaccess_config_import" "dr" {
+  provider = pingaccess.dr
+  data     = data.pingaccess_config_export.primary.data
+
+  triggers = {
+    checksum = data.pingaccess_config_export.primary.sha256
+  }
+}
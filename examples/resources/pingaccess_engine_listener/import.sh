@@ -1 +1,3 @@
 terraform import pingaccess_engine_listener.example 123
+# or by name, e.g.
+terraform import pingaccess_engine_listener.example "name=example"
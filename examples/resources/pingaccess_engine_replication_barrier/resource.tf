@@ -0,0 +1,12 @@
+resource "pingaccess_engine_replication_barrier" "example" {
+  quorum = 2
+
+  triggers = {
+    application = sha256(jsonencode(pingaccess_application.example))
+    policy      = sha256(jsonencode(pingaccess_ruleset.example))
+  }
+
+  timeouts {
+    create = "5m"
+  }
+}
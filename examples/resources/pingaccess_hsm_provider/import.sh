@@ -1 +1,3 @@
 terraform import pingaccess_hsm_provider.example 123
+# or by name, e.g.
+terraform import pingaccess_hsm_provider.example "name=example"
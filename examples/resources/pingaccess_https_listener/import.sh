@@ -1 +1,3 @@
 terraform import pingaccess_https_listener.admin 1
+# or by name, e.g.
+terraform import pingaccess_https_listener.admin "name=ADMIN"
@@ -1,16 +1,15 @@
 resource "pingaccess_identity_mapping" "example" {
-  class_name    = "com.pingidentity.pa.identitymappings.HeaderIdentityMapping"
-  name          = "example"
-  configuration = <<EOF
-  {
-    "attributeHeaderMappings": [
+  class_name = "com.pingidentity.pa.identitymappings.HeaderIdentityMapping"
+  name       = "example"
+
+  configuration = {
+    "attributeHeaderMappings" = [
       {
-        "subject": true,
-        "attributeName": "sub",
-        "headerName": "sub"
+        "subject"       = true
+        "attributeName" = "sub"
+        "headerName"    = "sub"
       }
-    ],
-    "headerClientCertificateMappings": []
+    ]
+    "headerClientCertificateMappings" = []
   }
-  EOF
 }
@@ -1,2 +1,4 @@
 #This is currently only supported for generated KeyPairs.
 terraform import pingaccess_keypair.example 123
+# or by alias, e.g.
+terraform import pingaccess_keypair.example "alias=example"
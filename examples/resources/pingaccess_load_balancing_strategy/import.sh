@@ -1 +1,3 @@
 terraform import pingaccess_load_balancing_strategy.example 123
+# or by name, e.g.
+terraform import pingaccess_load_balancing_strategy.example "name=example"
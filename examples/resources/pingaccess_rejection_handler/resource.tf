@@ -0,0 +1,8 @@
+resource "pingaccess_rejection_handler" "demo" {
+  class_name = "com.pingidentity.pa.rejectionhandlers.RedirectRejectionHandler"
+  name       = "demo"
+
+  configuration = {
+    "redirectUrl" = "https://localhost/error"
+  }
+}
@@ -1 +1,3 @@
 terraform import pingaccess_site.example 123
+# or by name, e.g.
+terraform import pingaccess_site.example "name=example"
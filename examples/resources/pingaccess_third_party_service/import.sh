@@ -1 +1,3 @@
 terraform import pingaccess_third_party_service.example 123
+# or by name, e.g.
+terraform import pingaccess_third_party_service.example "name=example"
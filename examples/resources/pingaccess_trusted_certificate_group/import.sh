@@ -1 +1,3 @@
 terraform import pingaccess_trusted_certificate_group.example 123
+# or by name, e.g.
+terraform import pingaccess_trusted_certificate_group.example "name=example"
@@ -1 +1,3 @@
 terraform import pingaccess_virtualhost.example 123
+# or by host and port, e.g.
+terraform import pingaccess_virtualhost.example example.com:443
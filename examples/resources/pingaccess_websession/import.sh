@@ -1 +1,3 @@
 terraform import pingaccess_websession.example 123
+# or by name, e.g.
+terraform import pingaccess_websession.example "name=example"
@@ -28,10 +28,6 @@ data "pingaccess_trusted_certificate_group" "trust_any" {
 resource "pingaccess_rule" "demo_1" {
   class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
   name       = "demo_1"
-  supported_destinations = [
-    "Site",
-    "Agent"
-  ]
   configuration = <<EOF
 		{
 			"cidrNotation": "127.0.0.1/32",
@@ -53,10 +49,6 @@ resource "pingaccess_rule" "demo_1" {
 resource "pingaccess_rule" "demo_2" {
   class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
   name       = "demo_2"
-  supported_destinations = [
-    "Site",
-    "Agent"
-  ]
   configuration = <<EOF
   {
     "cidrNotation": "127.0.0.${pingaccess_site.demo.id}/32",
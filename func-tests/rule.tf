@@ -2,11 +2,6 @@ resource "pingaccess_rule" "demo_rule" {
   class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
   name       = "demo_rule"
 
-  supported_destinations = [
-    "Site",
-    "Agent",
-  ]
-
   configuration = <<EOF
   {
     "cidrNotation": "127.0.0.1/32",
@@ -0,0 +1,97 @@
+// Package apierror describes the validation failures returned by the PingAccess API so both providers report them
+// with the same summary and details.
+package apierror
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/request"
+)
+
+// OperationError is an error returned by the PingAccess API annotated with the operation being performed, e.g.
+// `unable to create Rule`.
+type OperationError struct {
+	Operation string
+	Err       error
+}
+
+func (e *OperationError) Error() string {
+	return e.Operation + ": " + e.Err.Error()
+}
+
+func (e *OperationError) Unwrap() error {
+	return e.Err
+}
+
+// Wrap annotates err with the operation being performed.
+func Wrap(operation string, err error) error {
+	return &OperationError{Operation: operation, Err: err}
+}
+
+// Failure is a validation failure of a single field, the field is the path used by the API such as
+// `configuration.audience[0]`.
+type Failure struct {
+	Field   string
+	Message string
+}
+
+// Validation is a validation failure returned by the PingAccess API.
+type Validation struct {
+	// Summary is the operation being performed followed by the flash messages of the error.
+	Summary string
+	// Failures are the field failures sorted by field.
+	Failures []Failure
+}
+
+// Parse returns the validation failure of err, ok is false when err is not a PingAccess API error with field failures.
+// The operation is used for the summary unless err is an OperationError, whose operation is used instead.
+func Parse(err error, operation string) (v Validation, ok bool) {
+	var paErr *request.PingAccessError
+	if !errors.As(err, &paErr) || len(paErr.Form) == 0 {
+		return v, false
+	}
+	var opErr *OperationError
+	if errors.As(err, &opErr) {
+		operation = opErr.Operation
+	}
+	v.Summary = Summary(operation, paErr)
+
+	var fields []string
+	for field := range paErr.Form {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		if paErr.Form[field] == nil {
+			continue
+		}
+		for _, msg := range *paErr.Form[field] {
+			if msg != nil {
+				v.Failures = append(v.Failures, Failure{Field: field, Message: *msg})
+			}
+		}
+	}
+	return v, true
+}
+
+// Summary joins the operation and the flash messages of the error, either may be empty.
+func Summary(operation string, err *request.PingAccessError) string {
+	var parts []string
+	if operation != "" {
+		parts = append(parts, operation)
+	}
+	if err.Flash != nil {
+		var msgs []string
+		for _, msg := range *err.Flash {
+			if msg != nil {
+				msgs = append(msgs, *msg)
+			}
+		}
+		if len(msgs) > 0 {
+			parts = append(parts, strings.Join(msgs, ", "))
+		}
+	}
+	return strings.Join(parts, ": ")
+}
@@ -0,0 +1,57 @@
+package apierror
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func str(s string) *string {
+	return &s
+}
+
+func TestParse(t *testing.T) {
+	paErr := &request.PingAccessError{ApiErrorView: models.ApiErrorView{
+		Flash: &[]*string{str("Save Failed")},
+		Form: map[string]*[]*string{
+			"name":        {str("Name is required")},
+			"destination": {str("Must be Site or Agent"), str("Cannot be empty")},
+			"empty":       nil,
+		},
+	}}
+
+	v, ok := Parse(paErr, "unable to create Application")
+	assert.True(t, ok)
+	assert.Equal(t, "unable to create Application: Save Failed", v.Summary)
+	assert.Equal(t, []Failure{
+		{Field: "destination", Message: "Must be Site or Agent"},
+		{Field: "destination", Message: "Cannot be empty"},
+		{Field: "name", Message: "Name is required"},
+	}, v.Failures)
+
+	// the operation of a wrapped error is used in place of the given operation, even when it contains ": "
+	v, ok = Parse(fmt.Errorf("retrying: %w", Wrap("unable to create Rule: attempt 2", paErr)), "")
+	assert.True(t, ok)
+	assert.Equal(t, "unable to create Rule: attempt 2: Save Failed", v.Summary)
+
+	_, ok = Parse(Wrap("unable to create Rule", fmt.Errorf("boom")), "")
+	assert.False(t, ok)
+	_, ok = Parse(&request.PingAccessError{ApiErrorView: models.ApiErrorView{Flash: &[]*string{str("Not Found")}}}, "")
+	assert.False(t, ok)
+}
+
+func TestSummary(t *testing.T) {
+	assert.Equal(t, "unable to update Site", Summary("unable to update Site", &request.PingAccessError{}))
+	assert.Equal(t, "Save Failed, Try again", Summary("", &request.PingAccessError{ApiErrorView: models.ApiErrorView{
+		Flash: &[]*string{str("Save Failed"), nil, str("Try again")},
+	}}))
+}
+
+func TestOperationError(t *testing.T) {
+	err := Wrap("unable to create Rule", fmt.Errorf("boom"))
+	assert.Equal(t, "unable to create Rule: boom", err.Error())
+	assert.EqualError(t, fmt.Errorf("outer: %w", err), "outer: unable to create Rule: boom")
+}
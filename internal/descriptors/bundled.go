@@ -0,0 +1,64 @@
+package descriptors
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// The descriptors bundled with the provider for offline use, these use the same layout as the on-disk cache and are
+// captured from a running PingAccess with `make descriptors`.
+//
+//go:embed bundled
+var bundledFS embed.FS
+
+// Bundled returns the descriptors of the given kind bundled for the PingAccess version. Plugin descriptors rarely
+// change between patch releases, so when the exact version is not bundled the latest patch release of the same minor
+// version is used.
+func Bundled(version, kind string) ([]byte, bool) {
+	return readBundled(bundledFS, version, kind)
+}
+
+func readBundled(fsys fs.FS, version, kind string) ([]byte, bool) {
+	want, err := goversion.NewVersion(version)
+	if err != nil {
+		return nil, false
+	}
+	entries, err := fs.ReadDir(fsys, "bundled")
+	if err != nil {
+		return nil, false
+	}
+	var match *goversion.Version
+	var dir string
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		v, err := goversion.NewVersion(e.Name())
+		if err != nil || !sameMinor(v, want) {
+			continue
+		}
+		if v.Equal(want) {
+			match, dir = v, e.Name()
+			break
+		}
+		if match == nil || v.GreaterThan(match) {
+			match, dir = v, e.Name()
+		}
+	}
+	if match == nil {
+		return nil, false
+	}
+	b, err := fs.ReadFile(fsys, path.Join("bundled", dir, kind+".json"))
+	if err != nil {
+		return nil, false
+	}
+	return b, true
+}
+
+func sameMinor(a, b *goversion.Version) bool {
+	as, bs := a.Segments(), b.Segments()
+	return as[0] == bs[0] && as[1] == bs[1]
+}
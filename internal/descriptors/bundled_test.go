@@ -0,0 +1,65 @@
+package descriptors
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBundled(t *testing.T) {
+	fsys := fstest.MapFS{
+		"bundled/6.1.4/rules.json": {Data: []byte("6.1.4")},
+		"bundled/6.2.0/rules.json": {Data: []byte("6.2.0")},
+		"bundled/6.2.1/rules.json": {Data: []byte("6.2.1")},
+		"bundled/6.2.3/rules.json": {Data: []byte("6.2.3")},
+		"bundled/README.md":        {Data: []byte("readme")},
+	}
+	tests := []struct {
+		version string
+		expect  string
+	}{
+		{"6.2.1", "6.2.1"},
+		{"6.2.2", "6.2.3"},
+		{"6.1.0", "6.1.4"},
+		{"6.3.0", ""},
+		{"invalid", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			b, ok := readBundled(fsys, tt.version, "rules")
+			assert.Equal(t, tt.expect != "", ok)
+			assert.Equal(t, tt.expect, string(b))
+		})
+	}
+
+	_, ok := readBundled(fsys, "6.2.0", "identity_mappings")
+	assert.False(t, ok)
+}
+
+func TestOfflineCacheBundled(t *testing.T) {
+	cache, err := NewOfflineCache("6.2.3", "", "")
+	require.NoError(t, err)
+	fetch := func() (*models.RuleDescriptorsView, error) {
+		t.Fatal("offline caches should not call the API")
+		return nil, nil
+	}
+	rules, err := Load(cache, "rules", fetch)
+	require.NoError(t, err)
+	var classes []string
+	for _, d := range rules.Items {
+		classes = append(classes, *d.ClassName)
+	}
+	assert.Contains(t, classes, "com.pingidentity.pa.policy.CIDRPolicyInterceptor")
+
+	for _, kind := range []string{"access_token_validators", "availability_profiles", "hsm_providers", "identity_mappings", "load_balancing_strategies", "rejection_handlers", "site_authenticators"} {
+		desc, err := Load(cache, kind, func() (*models.DescriptorsView, error) {
+			t.Fatal("offline caches should not call the API")
+			return nil, nil
+		})
+		require.NoError(t, err, kind)
+		assert.NotEmpty(t, desc.Items, kind)
+	}
+}
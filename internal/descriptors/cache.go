@@ -0,0 +1,212 @@
+// Package descriptors provides caching of the PingAccess plugin descriptors shared by both providers.
+package descriptors
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Cache lazily loads plugin descriptors the first time they are used and holds them for the life of the process,
+// concurrent callers for the same kind of descriptor wait for a single request to PingAccess. When a directory is
+// configured the descriptors are also persisted to disk, keyed by the PingAccess version as descriptors only change
+// between releases.
+type Cache struct {
+	dir     string
+	version string
+
+	// offline caches never call PingAccess, descriptors are only loaded from the local file, the on-disk cache or
+	// the descriptors bundled with the provider.
+	offline bool
+	local   map[string]json.RawMessage
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+type entry struct {
+	mu    sync.Mutex
+	value interface{}
+}
+
+// NewCache creates a cache for the given PingAccess version, an empty dir disables the on-disk cache.
+func NewCache(version, dir string) *Cache {
+	return &Cache{
+		dir:     dir,
+		version: version,
+		entries: map[string]*entry{},
+	}
+}
+
+var (
+	sharedMu sync.Mutex
+	shared   = map[sharedKey]*Cache{}
+)
+
+type sharedKey struct {
+	baseURL string
+	version string
+	dir     string
+}
+
+// Shared returns the cache of the process for the PingAccess admin API at baseURL running the given version, creating
+// it on first use. Every provider configured against the same server, such as provider aliases and the muxed sdkv2 and
+// protocol providers, shares the cache so descriptors are only requested from PingAccess once per process.
+func Shared(baseURL, version, dir string) *Cache {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	key := sharedKey{baseURL: baseURL, version: version, dir: dir}
+	c, ok := shared[key]
+	if !ok {
+		c = NewCache(version, dir)
+		shared[key] = c
+	}
+	return c
+}
+
+// NewOfflineCache creates a cache for use without a connection to PingAccess. Descriptors are loaded from the local
+// file when given, which holds a JSON object keyed by the kind of descriptors, then the on-disk cache and finally the
+// descriptors bundled for the PingAccess version.
+func NewOfflineCache(version, dir, file string) (*Cache, error) {
+	c := NewCache(version, dir)
+	c.offline = true
+	c.local = map[string]json.RawMessage{}
+	if file != "" {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read descriptors file: %s", err)
+		}
+		if err := json.Unmarshal(b, &c.local); err != nil {
+			return nil, fmt.Errorf("unable to parse descriptors file %s: %s", file, err)
+		}
+	}
+	return c, nil
+}
+
+func (c *Cache) entry(kind string) *entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[kind]
+	if !ok {
+		e = &entry{}
+		c.entries[kind] = e
+	}
+	return e
+}
+
+// Load returns the descriptors of the given kind, calling fetch only if they are not already cached in memory or on
+// disk. Errors are not cached so a later call will retry the request.
+func Load[T any](c *Cache, kind string, fetch func() (*T, error)) (*T, error) {
+	if c == nil {
+		return fetch()
+	}
+	e := c.entry(kind)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.value != nil {
+		return e.value.(*T), nil
+	}
+	if c.offline {
+		v, err := loadOffline[T](c, kind)
+		if err != nil {
+			return nil, err
+		}
+		e.value = v
+		return v, nil
+	}
+	if v, ok := readFile[T](c.path(kind)); ok {
+		e.value = v
+		return v, nil
+	}
+	v, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	if v == nil {
+		return nil, fmt.Errorf("no %s descriptors returned", kind)
+	}
+	e.value = v
+	writeFile(c.path(kind), v)
+	return v, nil
+}
+
+func loadOffline[T any](c *Cache, kind string) (*T, error) {
+	if raw, ok := c.local[kind]; ok {
+		v := new(T)
+		if err := json.Unmarshal(raw, v); err != nil {
+			return nil, fmt.Errorf("unable to parse %s descriptors: %s", kind, err)
+		}
+		return v, nil
+	}
+	if v, ok := readFile[T](c.path(kind)); ok {
+		return v, nil
+	}
+	if b, ok := Bundled(c.version, kind); ok {
+		v := new(T)
+		if err := json.Unmarshal(b, v); err != nil {
+			return nil, fmt.Errorf("unable to parse bundled %s descriptors: %s", kind, err)
+		}
+		return v, nil
+	}
+	return nil, fmt.Errorf("no %s descriptors are available for PingAccess %s while offline, set offline_descriptors or populate the descriptor_cache_dir", kind, c.version)
+}
+
+func (c *Cache) path(kind string) string {
+	if c.dir == "" || c.version == "" {
+		return ""
+	}
+	return filepath.Join(c.dir, c.version, kind+".json")
+}
+
+func readFile[T any](path string) (*T, bool) {
+	if path == "" {
+		return nil, false
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	v := new(T)
+	if err := json.Unmarshal(b, v); err != nil {
+		log.Printf("[WARN] ignoring invalid descriptor cache file %s: %s", path, err)
+		return nil, false
+	}
+	return v, true
+}
+
+// writeFile persists the descriptors on a best effort basis, failing to write the cache is not an error.
+func writeFile(path string, v interface{}) {
+	if path == "" {
+		return
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		log.Printf("[WARN] unable to encode descriptor cache file %s: %s", path, err)
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		log.Printf("[WARN] unable to create descriptor cache directory %s: %s", filepath.Dir(path), err)
+		return
+	}
+	// write to a temporary file first so that concurrent runs never read a partially written file
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		log.Printf("[WARN] unable to write descriptor cache file %s: %s", path, err)
+		return
+	}
+	_, err = tmp.Write(b)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Printf("[WARN] unable to write descriptor cache file %s: %s", path, err)
+		_ = os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		log.Printf("[WARN] unable to write descriptor cache file %s: %s", path, err)
+	}
+}
@@ -0,0 +1,142 @@
+package descriptors
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDescriptor struct {
+	Name string `json:"name"`
+}
+
+func TestLoadCachesInMemory(t *testing.T) {
+	cache := NewCache("6.2.0", "")
+	var calls int32
+	fetch := func() (*testDescriptor, error) {
+		atomic.AddInt32(&calls, 1)
+		return &testDescriptor{Name: "rules"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := Load(cache, "rules", fetch)
+			assert.NoError(t, err)
+			assert.Equal(t, "rules", v.Name)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestSharedCache(t *testing.T) {
+	a := Shared("https://pa.example.com:9000/pa-admin-api/v3", "6.2.0", "")
+	assert.Same(t, a, Shared("https://pa.example.com:9000/pa-admin-api/v3", "6.2.0", ""))
+	assert.NotSame(t, a, Shared("https://pa.example.com:9000/pa-admin-api/v3", "6.2.1", ""))
+	assert.NotSame(t, a, Shared("https://other.example.com:9000/pa-admin-api/v3", "6.2.0", ""))
+
+	var calls int32
+	fetch := func() (*testDescriptor, error) {
+		atomic.AddInt32(&calls, 1)
+		return &testDescriptor{Name: "rules"}, nil
+	}
+	_, err := Load(a, "rules", fetch)
+	require.NoError(t, err)
+	_, err = Load(Shared("https://pa.example.com:9000/pa-admin-api/v3", "6.2.0", ""), "rules", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestLoadDoesNotCacheErrors(t *testing.T) {
+	cache := NewCache("6.2.0", "")
+	_, err := Load(cache, "rules", func() (*testDescriptor, error) {
+		return nil, errors.New("unavailable")
+	})
+	assert.EqualError(t, err, "unavailable")
+
+	v, err := Load(cache, "rules", func() (*testDescriptor, error) {
+		return &testDescriptor{Name: "rules"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "rules", v.Name)
+}
+
+func TestLoadUsesDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	v, err := Load(NewCache("6.2.0", dir), "rules", func() (*testDescriptor, error) {
+		return &testDescriptor{Name: "from api"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "from api", v.Name)
+	assert.FileExists(t, filepath.Join(dir, "6.2.0", "rules.json"))
+
+	v, err = Load(NewCache("6.2.0", dir), "rules", func() (*testDescriptor, error) {
+		t.Fatal("descriptors should be loaded from disk")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "from api", v.Name)
+
+	v, err = Load(NewCache("7.0.0", dir), "rules", func() (*testDescriptor, error) {
+		return &testDescriptor{Name: "new version"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "new version", v.Name)
+}
+
+func TestLoadIgnoresInvalidDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "6.2.0"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "6.2.0", "rules.json"), []byte("not json"), 0600))
+
+	v, err := Load(NewCache("6.2.0", dir), "rules", func() (*testDescriptor, error) {
+		return &testDescriptor{Name: "from api"}, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "from api", v.Name)
+}
+
+func TestOfflineCache(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "descriptors.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"rules": {"name": "from file"}}`), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "5.3.2"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "5.3.2", "identity_mappings.json"), []byte(`{"name": "from cache"}`), 0600))
+
+	cache, err := NewOfflineCache("5.3.2", dir, file)
+	require.NoError(t, err)
+	fetch := func() (*testDescriptor, error) {
+		t.Fatal("offline caches should not call the API")
+		return nil, nil
+	}
+
+	v, err := Load(cache, "rules", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "from file", v.Name)
+
+	v, err = Load(cache, "identity_mappings", fetch)
+	require.NoError(t, err)
+	assert.Equal(t, "from cache", v.Name)
+
+	_, err = Load(cache, "hsm_providers", fetch)
+	assert.EqualError(t, err, "no hsm_providers descriptors are available for PingAccess 5.3.2 while offline, set offline_descriptors or populate the descriptor_cache_dir")
+}
+
+func TestNewOfflineCacheInvalidFile(t *testing.T) {
+	_, err := NewOfflineCache("6.2.0", "", filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "descriptors.json")
+	require.NoError(t, os.WriteFile(file, []byte("not json"), 0600))
+	_, err = NewOfflineCache("6.2.0", "", file)
+	assert.Error(t, err)
+}
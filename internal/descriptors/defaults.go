@@ -0,0 +1,152 @@
+package descriptors
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+)
+
+// Overlay returns the original configuration with only the values which differ in config, the configuration returned
+// by PingAccess, overwritten. Fields missing from the original configuration are added when PingAccess holds a value
+// other than their default, COMPOSITE fields are overlaid recursively and fields PingAccess no longer returns are
+// removed. Scalars are compared by their string form as descriptor defaults are strings while PingAccess returns
+// numbers and booleans. The original is returned unchanged when nothing differs, so its formatting is kept, and config
+// is returned when either is not a JSON object.
+func Overlay(fields []*models.ConfigurationField, original, config string) string {
+	orig, ok := decodeObject(original)
+	if !ok {
+		return config
+	}
+	conf, ok := decodeObject(config)
+	if !ok {
+		return config
+	}
+	if !overlay(fields, orig, conf) {
+		return original
+	}
+	b, err := json.Marshal(orig)
+	if err != nil {
+		return config
+	}
+	return string(b)
+}
+
+// overlay updates orig with the values of conf which differ, reporting whether anything changed.
+func overlay(fields []*models.ConfigurationField, orig, conf map[string]interface{}) bool {
+	byName := map[string]*models.ConfigurationField{}
+	for _, f := range fields {
+		if f != nil && f.Name != nil && f.Type != nil {
+			byName[*f.Name] = f
+		}
+	}
+	changed := false
+	for k, v := range conf {
+		current, ok := orig[k]
+		f := byName[k]
+		if !ok {
+			if nested, isObject := v.(map[string]interface{}); isObject && f != nil && *f.Type == "COMPOSITE" {
+				c := map[string]interface{}{}
+				if overlay(f.Fields, c, nested) {
+					orig[k] = c
+					changed = true
+				}
+				continue
+			}
+			if f == nil || !isDefault(f, v) {
+				orig[k] = v
+				changed = true
+			}
+			continue
+		}
+		if equivalent(current, v) || (f != nil && isDefault(f, current) && isDefault(f, v)) {
+			continue
+		}
+		c, isObject := current.(map[string]interface{})
+		nested, ok := v.(map[string]interface{})
+		if isObject && ok && f != nil && *f.Type == "COMPOSITE" {
+			if overlay(f.Fields, c, nested) {
+				changed = true
+			}
+			continue
+		}
+		orig[k] = v
+		changed = true
+	}
+	for k, current := range orig {
+		if _, ok := conf[k]; ok {
+			continue
+		}
+		if f := byName[k]; current == nil || (f != nil && isDefault(f, current)) {
+			continue
+		}
+		delete(orig, k)
+		changed = true
+	}
+	return changed
+}
+
+// isDefault checks whether the value is the one PingAccess populates the field with when it is not configured.
+func isDefault(f *models.ConfigurationField, value interface{}) bool {
+	def := fieldDefault(f)
+	if def == nil && value == "" {
+		return true
+	}
+	return equivalent(def, value)
+}
+
+func decodeObject(s string) (map[string]interface{}, bool) {
+	dec := json.NewDecoder(bytes.NewBufferString(s))
+	dec.UseNumber()
+	var m map[string]interface{}
+	if err := dec.Decode(&m); err != nil || m == nil {
+		return nil, false
+	}
+	return m, true
+}
+
+// fieldDefault returns the value PingAccess sets a field to when it is not configured, an empty default is the same as
+// no default.
+func fieldDefault(f *models.ConfigurationField) interface{} {
+	switch *f.Type {
+	case "CHECKBOX":
+		return f.Default != nil && *f.Default == "true"
+	case "LIST", "TABLE":
+		return []interface{}{}
+	}
+	if f.Default != nil && *f.Default != "" {
+		return *f.Default
+	}
+	return nil
+}
+
+func equivalent(a, b interface{}) bool {
+	switch x := a.(type) {
+	case nil:
+		return b == nil
+	case map[string]interface{}:
+		y, ok := b.(map[string]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for k, v := range x {
+			w, ok := y[k]
+			if !ok || !equivalent(v, w) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		y, ok := b.([]interface{})
+		if !ok || len(x) != len(y) {
+			return false
+		}
+		for i := range x {
+			if !equivalent(x[i], y[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return isScalar(a) && isScalar(b) && scalarString(a) == scalarString(b)
+}
@@ -0,0 +1,86 @@
+package descriptors
+
+import (
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testDefaultField(name, typ, def string) *models.ConfigurationField {
+	f := testField(name, typ, false)
+	f.Default = &def
+	return f
+}
+
+func TestOverlay(t *testing.T) {
+	timeouts := testField("timeouts", "COMPOSITE", false)
+	timeouts.Fields = []*models.ConfigurationField{
+		testDefaultField("connect", "TEXT", "30"),
+		testField("read", "TEXT", false),
+	}
+	fields := []*models.ConfigurationField{
+		testDefaultField("errorResponseCode", "TEXT", "403"),
+		testField("negate", "CHECKBOX", false),
+		testField("rejectionHandler", "SELECT", false),
+		testDefaultField("templateFile", "TEXT", ""),
+		testField("headers", "LIST", false),
+		timeouts,
+	}
+	api := `{"errorResponseCode":403,"negate":false,"rejectionHandler":null,"templateFile":"","headers":[],"timeouts":{"connect":30,"read":null}}`
+
+	tests := []struct {
+		name     string
+		original string
+		config   string
+		expected string
+	}{
+		{
+			name:     "omitted defaults keep the original",
+			original: "{\n  \"negate\": false\n}",
+			config:   api,
+			expected: "{\n  \"negate\": false\n}",
+		},
+		{
+			name:     "scalars are compared by their string form",
+			original: `{"errorResponseCode":"403","negate":"false","headers":[],"timeouts":{"connect":"30"}}`,
+			config:   api,
+			expected: `{"errorResponseCode":"403","negate":"false","headers":[],"timeouts":{"connect":"30"}}`,
+		},
+		{
+			name:     "empty strings match fields without a default",
+			original: `{"rejectionHandler":null,"templateFile":null}`,
+			config:   `{"rejectionHandler":"","templateFile":""}`,
+			expected: `{"rejectionHandler":null,"templateFile":null}`,
+		},
+		{
+			name:     "only the differing values are overwritten",
+			original: `{"errorResponseCode":404,"negate":true,"headers":["a"]}`,
+			config:   `{"errorResponseCode":404,"negate":false,"rejectionHandler":null,"headers":["a"],"timeouts":{"connect":30,"read":"10"}}`,
+			expected: `{"errorResponseCode":404,"headers":["a"],"negate":false,"timeouts":{"read":"10"}}`,
+		},
+		{
+			name:     "composite fields are overlaid recursively",
+			original: `{"timeouts":{"connect":"30","read":"5"}}`,
+			config:   `{"timeouts":{"connect":30,"read":"10"}}`,
+			expected: `{"timeouts":{"connect":"30","read":"10"}}`,
+		},
+		{
+			name:     "fields no longer returned are removed",
+			original: `{"negate":true,"rejectionHandler":null}`,
+			config:   `{}`,
+			expected: `{"rejectionHandler":null}`,
+		},
+		{
+			name:     "invalid configuration is ignored",
+			original: ``,
+			config:   api,
+			expected: api,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Overlay(fields, tt.original, tt.config))
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package descriptors
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+)
+
+// Violation describes a value in a plugin configuration which does not satisfy its descriptor.
+type Violation struct {
+	// Path to the offending field within the configuration, made of field names and the indexes of table rows or
+	// list items.
+	Path    []interface{}
+	Message string
+}
+
+// String returns the path of the violation in the form `configuration.field[0].child`.
+func (v Violation) String() string {
+	var sb strings.Builder
+	sb.WriteString("configuration")
+	for _, step := range v.Path {
+		switch s := step.(type) {
+		case int:
+			fmt.Fprintf(&sb, "[%d]", s)
+		default:
+			fmt.Fprintf(&sb, ".%s", s)
+		}
+	}
+	return sb.String()
+}
+
+// ValidateConfiguration checks the json configuration of a plugin against the configuration fields of its descriptor.
+// Required fields must be set, values must match the field type, SELECT style values must be one of the available
+// options (taking into account the value of any parent field) and TABLE and COMPOSITE fields are validated against
+// their child fields.
+func ValidateConfiguration(className string, fields []*models.ConfigurationField, configuration string) ([]Violation, error) {
+	dec := json.NewDecoder(bytes.NewBufferString(configuration))
+	dec.UseNumber()
+	var conf map[string]interface{}
+	if err := dec.Decode(&conf); err != nil {
+		return nil, fmt.Errorf("unable to parse configuration: %s", err)
+	}
+	v := validator{className: className}
+	v.fields(fields, conf, nil)
+	return v.violations, nil
+}
+
+type validator struct {
+	className  string
+	violations []Violation
+}
+
+func (v *validator) add(path []interface{}, format string, a ...interface{}) {
+	v.violations = append(v.violations, Violation{Path: path, Message: fmt.Sprintf(format, a...)})
+}
+
+func (v *validator) fields(fields []*models.ConfigurationField, conf map[string]interface{}, path []interface{}) {
+	for _, f := range fields {
+		if f == nil || f.Name == nil {
+			continue
+		}
+		fieldPath := append(append([]interface{}{}, path...), *f.Name)
+		value, ok := conf[*f.Name]
+		if !ok || value == nil || value == "" {
+			if f.Required != nil && *f.Required {
+				v.add(fieldPath, "the field '%s' is required for the class_name '%s'", *f.Name, v.className)
+			}
+			continue
+		}
+		v.value(f, value, conf, fieldPath)
+	}
+}
+
+func (v *validator) value(f *models.ConfigurationField, value interface{}, siblings map[string]interface{}, path []interface{}) {
+	fieldType := ""
+	if f.Type != nil {
+		fieldType = strings.ToUpper(*f.Type)
+	}
+	switch fieldType {
+	case "CHECKBOX":
+		if !isBool(value) {
+			v.add(path, "the field '%s' must be a boolean", *f.Name)
+		}
+	case "TEXT", "TEXTAREA", "TIME", "GROOVY", "AUTOCOMPLETEOPEN":
+		if !isScalar(value) {
+			v.add(path, "the field '%s' must be a string", *f.Name)
+		}
+	case "CONCEALED":
+		if _, ok := value.(map[string]interface{}); !ok && !isScalar(value) {
+			v.add(path, "the field '%s' must be a string or an object containing the value", *f.Name)
+		}
+	case "SELECT", "RADIO_BUTTON", "AUTOCOMPLETECLOSED":
+		if !isScalar(value) {
+			v.add(path, "the field '%s' must be a string", *f.Name)
+			return
+		}
+		v.option(f, value, siblings, path)
+	case "LIST":
+		items, ok := value.([]interface{})
+		if !ok {
+			v.add(path, "the field '%s' must be a list", *f.Name)
+			return
+		}
+		for i, item := range items {
+			itemPath := append(append([]interface{}{}, path...), i)
+			if !isScalar(item) {
+				v.add(itemPath, "the items of field '%s' must be strings", *f.Name)
+				continue
+			}
+			v.option(f, item, siblings, itemPath)
+		}
+	case "TABLE":
+		rows, ok := value.([]interface{})
+		if !ok {
+			v.add(path, "the field '%s' must be a list of rows", *f.Name)
+			return
+		}
+		for i, row := range rows {
+			rowPath := append(append([]interface{}{}, path...), i)
+			r, ok := row.(map[string]interface{})
+			if !ok {
+				v.add(rowPath, "the rows of field '%s' must be objects", *f.Name)
+				continue
+			}
+			v.unknownFields(f, r, rowPath)
+			v.fields(f.Fields, r, rowPath)
+		}
+	case "COMPOSITE":
+		c, ok := value.(map[string]interface{})
+		if !ok {
+			v.add(path, "the field '%s' must be an object", *f.Name)
+			return
+		}
+		v.fields(f.Fields, c, path)
+	}
+}
+
+// unknownFields reports any columns of a table row which are not defined by the table fields.
+func (v *validator) unknownFields(f *models.ConfigurationField, row map[string]interface{}, path []interface{}) {
+	if len(f.Fields) == 0 {
+		return
+	}
+	known := map[string]bool{}
+	var names []string
+	for _, child := range f.Fields {
+		if child != nil && child.Name != nil {
+			known[*child.Name] = true
+			names = append(names, *child.Name)
+		}
+	}
+	var unknown []string
+	for k := range row {
+		if !known[k] {
+			unknown = append(unknown, k)
+		}
+	}
+	sort.Strings(unknown)
+	for _, k := range unknown {
+		v.add(append(append([]interface{}{}, path...), k), "the field '%s' is not a column of '%s', expected one of: %s", k, *f.Name, strings.Join(names, ", "))
+	}
+}
+
+// option checks the value is one of the options available for the field, when the field depends on a parent field the
+// options for the parent's current value are used. Fields without any options, such as those populated from other
+// objects in PingAccess, accept any value.
+func (v *validator) option(f *models.ConfigurationField, value interface{}, siblings map[string]interface{}, path []interface{}) {
+	options := f.Options
+	var parent string
+	if f.ParentField != nil && f.ParentField.FieldName != nil {
+		if pv, ok := siblings[*f.ParentField.FieldName]; ok && isScalar(pv) {
+			for _, dep := range f.ParentField.DependentFieldOptions {
+				if dep != nil && dep.Value != nil && *dep.Value == scalarString(pv) {
+					options = dep.Options
+					parent = fmt.Sprintf(" when '%s' is '%s'", *f.ParentField.FieldName, *dep.Value)
+				}
+			}
+		}
+	}
+	if len(options) == 0 {
+		return
+	}
+	str := scalarString(value)
+	var allowed []string
+	for _, o := range options {
+		if o == nil || o.Value == nil {
+			continue
+		}
+		if *o.Value == str {
+			return
+		}
+		if o.Label != nil && *o.Label != *o.Value {
+			allowed = append(allowed, fmt.Sprintf("%s (%s)", *o.Value, *o.Label))
+		} else {
+			allowed = append(allowed, *o.Value)
+		}
+	}
+	v.add(path, "'%s' is not a valid option for the field '%s'%s, expected one of: %s", str, *f.Name, parent, strings.Join(allowed, ", "))
+}
+
+func isBool(value interface{}) bool {
+	switch b := value.(type) {
+	case bool:
+		return true
+	case string:
+		return b == "true" || b == "false"
+	}
+	return false
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case string, bool, json.Number, float64:
+		return true
+	}
+	return false
+}
+
+func scalarString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprint(value)
+}
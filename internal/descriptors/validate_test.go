@@ -0,0 +1,155 @@
+package descriptors
+
+import (
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testField(name, typ string, required bool) *models.ConfigurationField {
+	return &models.ConfigurationField{Name: &name, Type: &typ, Required: &required}
+}
+
+func testOptions(values ...string) []*models.ConfigurationOption {
+	var options []*models.ConfigurationOption
+	for i := range values {
+		label := "Label " + values[i]
+		options = append(options, &models.ConfigurationOption{Value: &values[i], Label: &label})
+	}
+	return options
+}
+
+func testDescriptorFields() []*models.ConfigurationField {
+	method := testField("method", "SELECT", true)
+	method.Options = testOptions("GET", "POST")
+
+	mode := testField("mode", "SELECT", false)
+	mode.Options = testOptions("A", "B")
+
+	submode := testField("submode", "SELECT", false)
+	parent := "mode"
+	valueA, valueB := "A", "B"
+	submode.ParentField = &models.ConfigurationParentField{
+		FieldName: &parent,
+		DependentFieldOptions: []*models.ConfigurationDependentFieldOption{
+			{Value: &valueA, Options: testOptions("a1", "a2")},
+			{Value: &valueB, Options: testOptions("b1")},
+		},
+	}
+
+	headers := testField("headers", "TABLE", false)
+	headers.Fields = []*models.ConfigurationField{
+		testField("name", "TEXT", true),
+		testField("value", "TEXT", false),
+	}
+
+	composite := testField("settings", "COMPOSITE", false)
+	composite.Fields = []*models.ConfigurationField{
+		testField("enabled", "CHECKBOX", true),
+		testField("secret", "CONCEALED", false),
+	}
+
+	values := testField("values", "LIST", false)
+
+	return []*models.ConfigurationField{
+		testField("path", "TEXT", true),
+		testField("negate", "CHECKBOX", false),
+		method,
+		mode,
+		submode,
+		headers,
+		composite,
+		values,
+	}
+}
+
+func TestValidateConfiguration(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		expect []string
+	}{
+		{
+			name:   "valid configuration",
+			config: `{"path": "/foo", "negate": false, "method": "GET", "mode": "B", "submode": "b1", "headers": [{"name": "a", "value": "b"}], "settings": {"enabled": "true", "secret": {"value": "s"}}, "values": ["a", 1]}`,
+		},
+		{
+			name:   "required fields",
+			config: `{"path": "", "method": null}`,
+			expect: []string{
+				"configuration.path: the field 'path' is required for the class_name 'test'",
+				"configuration.method: the field 'method' is required for the class_name 'test'",
+			},
+		},
+		{
+			name:   "numbers are accepted for text fields",
+			config: `{"path": 10, "method": "GET"}`,
+		},
+		{
+			name:   "value types",
+			config: `{"path": {"a": "b"}, "negate": "yes", "method": ["GET"], "headers": {"name": "a"}, "settings": "s", "values": "a"}`,
+			expect: []string{
+				"configuration.path: the field 'path' must be a string",
+				"configuration.negate: the field 'negate' must be a boolean",
+				"configuration.method: the field 'method' must be a string",
+				"configuration.headers: the field 'headers' must be a list of rows",
+				"configuration.settings: the field 'settings' must be an object",
+				"configuration.values: the field 'values' must be a list",
+			},
+		},
+		{
+			name:   "select options",
+			config: `{"path": "/foo", "method": "PUT"}`,
+			expect: []string{
+				"configuration.method: 'PUT' is not a valid option for the field 'method', expected one of: GET (Label GET), POST (Label POST)",
+			},
+		},
+		{
+			name:   "dependent options",
+			config: `{"path": "/foo", "method": "GET", "mode": "A", "submode": "b1"}`,
+			expect: []string{
+				"configuration.submode: 'b1' is not a valid option for the field 'submode' when 'mode' is 'A', expected one of: a1 (Label a1), a2 (Label a2)",
+			},
+		},
+		{
+			name:   "table rows",
+			config: `{"path": "/foo", "method": "GET", "headers": [{"name": "a"}, "row", {"value": "b", "other": "c"}]}`,
+			expect: []string{
+				"configuration.headers[1]: the rows of field 'headers' must be objects",
+				"configuration.headers[2].other: the field 'other' is not a column of 'headers', expected one of: name, value",
+				"configuration.headers[2].name: the field 'name' is required for the class_name 'test'",
+			},
+		},
+		{
+			name:   "composite fields",
+			config: `{"path": "/foo", "method": "GET", "settings": {"secret": ["a"]}}`,
+			expect: []string{
+				"configuration.settings.enabled: the field 'enabled' is required for the class_name 'test'",
+				"configuration.settings.secret: the field 'secret' must be a string or an object containing the value",
+			},
+		},
+		{
+			name:   "list items",
+			config: `{"path": "/foo", "method": "GET", "values": ["a", {"b": "c"}]}`,
+			expect: []string{
+				"configuration.values[1]: the items of field 'values' must be strings",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := ValidateConfiguration("test", testDescriptorFields(), tt.config)
+			require.NoError(t, err)
+			var got []string
+			for _, v := range violations {
+				got = append(got, v.String()+": "+v.Message)
+			}
+			assert.Equal(t, tt.expect, got)
+		})
+	}
+
+	_, err := ValidateConfiguration("test", testDescriptorFields(), "not json")
+	assert.Error(t, err)
+}
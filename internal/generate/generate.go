@@ -0,0 +1,301 @@
+// Package generate writes the Terraform configuration and import blocks for the objects in an existing PingAccess, so
+// an estate can be adopted without writing each resource and importing it by hand.
+//
+// Objects are listed from the admin API and then imported and read through the provider itself, so the configuration
+// generated matches what the resources read back. IDs of other generated objects are replaced by references to their
+// resources and sensitive attributes are replaced by variables.
+package generate
+
+import (
+	"context"
+	"crypto/tls"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pav7"
+
+	paCfg "github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+)
+
+// Options configures the objects generated.
+type Options struct {
+	// Types are the resource types to generate, all of the supported types when empty.
+	Types []string
+}
+
+// Run is the generate subcommand of the provider binary, it returns the exit code.
+func Run(ctx context.Context, args []string, server tfprotov5.ProviderServer, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("generate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-pingaccess generate [-out file] [-types type,...]\n\n")
+		fmt.Fprintf(stderr, "Writes the Terraform configuration and import blocks for the objects in PingAccess, the connection is\n")
+		fmt.Fprintf(stderr, "configured with the PINGACCESS_BASEURL, PINGACCESS_CONTEXT, PINGACCESS_USERNAME and PINGACCESS_PASSWORD\n")
+		fmt.Fprintf(stderr, "environment variables.\n\n")
+		fs.PrintDefaults()
+	}
+	out := fs.String("out", "", "the file to write the configuration to, standard output when not set")
+	types := fs.String("types", "", "comma separated resource types to generate, one of: "+strings.Join(sourceTypes(), ", "))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	var opts Options
+	if *types != "" {
+		opts.Types = strings.Split(*types, ",")
+	}
+	b, err := Generate(ctx, server, opts)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	if *out == "" {
+		_, _ = stdout.Write(b)
+		return 0
+	}
+	if err := os.WriteFile(*out, b, 0o600); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// object is an object of PingAccess read through its resource.
+type object struct {
+	typeName string
+	name     string
+	id       string
+	importID string
+	state    tftypes.Value
+}
+
+type generator struct {
+	server  tfprotov5.ProviderServer
+	api     *pav7.Client
+	schemas map[string]*tfprotov5.Schema
+	objects []*object
+	// ids holds the generated objects by resource type and id, to replace ids with references.
+	ids   map[string]map[string]*object
+	names map[string]bool
+}
+
+// Generate returns the configuration for the objects of the PingAccess the provider server is configured for with the
+// PINGACCESS_* environment variables.
+func Generate(ctx context.Context, server tfprotov5.ProviderServer, opts Options) ([]byte, error) {
+	g := &generator{
+		server: server,
+		ids:    map[string]map[string]*object{},
+		names:  map[string]bool{},
+	}
+	if err := g.configure(ctx); err != nil {
+		return nil, err
+	}
+	types := map[string]bool{}
+	for _, t := range opts.Types {
+		if _, ok := g.schemas[t]; !ok || !isSource(t) {
+			return nil, fmt.Errorf("unsupported resource type %q, expected one of: %s", t, strings.Join(sourceTypes(), ", "))
+		}
+		types[t] = true
+	}
+	for _, s := range sources {
+		if len(types) > 0 && !types[s.typeName] {
+			continue
+		}
+		if err := g.generate(ctx, s); err != nil {
+			return nil, err
+		}
+	}
+	return g.render(), nil
+}
+
+func isSource(typeName string) bool {
+	for _, s := range sources {
+		if s.typeName == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// newAPI returns a client for listing objects, connecting to the PingAccess the provider is configured for. PingAccess
+// uses a self signed certificate by default so it is not verified, the same as the provider.
+func newAPI(config tftypes.Value) (*pav7.Client, error) {
+	var attrs map[string]tftypes.Value
+	if err := config.As(&attrs); err != nil {
+		return nil, err
+	}
+	settings := map[string]string{}
+	for _, name := range []string{"base_url", "context", "username", "password"} {
+		var v string
+		if err := attrs[name].As(&v); err != nil {
+			return nil, fmt.Errorf("unable to read the provider %s: %w", name, err)
+		}
+		settings[name] = v
+	}
+	cfg := paCfg.NewConfig().
+		WithUsername(settings["username"]).
+		WithPassword(settings["password"]).
+		WithEndpoint(settings["base_url"] + settings["context"])
+	cfg.HTTPClient = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}} //nolint:gosec
+	return pav7.New(cfg), nil
+}
+
+// configure retrieves the resource schemas and configures the provider, with every attribute of the provider
+// configuration left to its default.
+func (g *generator) configure(ctx context.Context) error {
+	schema, err := g.server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		return err
+	}
+	if err := diagnosticsError(schema.Diagnostics); err != nil {
+		return err
+	}
+	g.schemas = schema.ResourceSchemas
+
+	typ := schema.Provider.ValueType().(tftypes.Object)
+	values := map[string]tftypes.Value{}
+	for name, t := range typ.AttributeTypes {
+		values[name] = tftypes.NewValue(t, nil)
+	}
+	config, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, values))
+	if err != nil {
+		return err
+	}
+	prepared, err := g.server.PrepareProviderConfig(ctx, &tfprotov5.PrepareProviderConfigRequest{Config: &config})
+	if err != nil {
+		return err
+	}
+	if err := diagnosticsError(prepared.Diagnostics); err != nil {
+		return err
+	}
+	if prepared.PreparedConfig != nil {
+		config = *prepared.PreparedConfig
+	}
+	configured, err := config.Unmarshal(typ)
+	if err != nil {
+		return err
+	}
+	if g.api, err = newAPI(configured); err != nil {
+		return err
+	}
+	resp, err := g.server.ConfigureProvider(ctx, &tfprotov5.ConfigureProviderRequest{Config: &config})
+	if err != nil {
+		return err
+	}
+	return diagnosticsError(resp.Diagnostics)
+}
+
+// generate lists the objects of the source and reads each through its resource.
+func (g *generator) generate(ctx context.Context, s source) error {
+	items, err := g.list(s.path)
+	if err != nil {
+		return fmt.Errorf("unable to list %s: %w", s.typeName, err)
+	}
+	for _, item := range items {
+		if s.skip != nil && s.skip(item) {
+			continue
+		}
+		importID := str(item["id"])
+		if s.importID != nil {
+			importID = s.importID(item)
+		}
+		state, err := g.read(ctx, s.typeName, importID)
+		if err != nil {
+			return fmt.Errorf("unable to read %s %s: %w", s.typeName, importID, err)
+		}
+		o := &object{typeName: s.typeName, name: g.uniqueName(s.typeName, s.name(item)), importID: importID, state: state}
+		if id, ok := attribute(state, "id"); ok {
+			_ = id.As(&o.id)
+		}
+		g.objects = append(g.objects, o)
+		if g.ids[s.typeName] == nil {
+			g.ids[s.typeName] = map[string]*object{}
+		}
+		g.ids[s.typeName][o.id] = o
+	}
+	return nil
+}
+
+func (g *generator) list(path string) ([]map[string]interface{}, error) {
+	var output struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	if _, err := g.api.Get(path, &output); err != nil {
+		return nil, err
+	}
+	return output.Items, nil
+}
+
+// read imports the object and reads its state as terraform does for an import.
+func (g *generator) read(ctx context.Context, typeName, id string) (tftypes.Value, error) {
+	schema := g.schemas[typeName]
+	imported, err := g.server.ImportResourceState(ctx, &tfprotov5.ImportResourceStateRequest{TypeName: typeName, ID: id})
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	if err := diagnosticsError(imported.Diagnostics); err != nil {
+		return tftypes.Value{}, err
+	}
+	if len(imported.ImportedResources) == 0 {
+		return tftypes.Value{}, fmt.Errorf("nothing was imported")
+	}
+	resource := imported.ImportedResources[0]
+	resp, err := g.server.ReadResource(ctx, &tfprotov5.ReadResourceRequest{TypeName: typeName, CurrentState: resource.State, Private: resource.Private})
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	if err := diagnosticsError(resp.Diagnostics); err != nil {
+		return tftypes.Value{}, err
+	}
+	if resp.NewState == nil {
+		return tftypes.Value{}, fmt.Errorf("the object no longer exists")
+	}
+	state, err := resp.NewState.Unmarshal(schema.ValueType())
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+	if state.IsNull() {
+		return tftypes.Value{}, fmt.Errorf("the object no longer exists")
+	}
+	return state, nil
+}
+
+// uniqueName returns a resource name for the object which is not yet used for the resource type.
+func (g *generator) uniqueName(typeName, name string) string {
+	base := resourceName(name)
+	n := base
+	for i := 2; g.names[typeName+"."+n]; i++ {
+		n = fmt.Sprintf("%s_%d", base, i)
+	}
+	g.names[typeName+"."+n] = true
+	return n
+}
+
+func attribute(v tftypes.Value, name string) (tftypes.Value, bool) {
+	values := map[string]tftypes.Value{}
+	if err := v.As(&values); err != nil {
+		return tftypes.Value{}, false
+	}
+	a, ok := values[name]
+	return a, ok && !a.IsNull()
+}
+
+func diagnosticsError(diags []*tfprotov5.Diagnostic) error {
+	var msgs []string
+	for _, d := range diags {
+		if d != nil && d.Severity == tfprotov5.DiagnosticSeverityError {
+			msgs = append(msgs, strings.TrimSpace(d.Summary+": "+d.Detail))
+		}
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	sort.Strings(msgs)
+	return fmt.Errorf("%s", strings.Join(msgs, "\n"))
+}
@@ -0,0 +1,162 @@
+package generate
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	tfmux "github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/applications"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/rules"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/siteAuthenticators"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/sites"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/virtualhosts"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pingaccesstest"
+	protocol "github.com/iwarapter/terraform-provider-pingaccess/internal/protocolprovider"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/sdkv2provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func providerServer(t *testing.T) tfprotov5.ProviderServer {
+	t.Helper()
+	factory, err := tfmux.NewMuxServer(context.Background(), sdkv2provider.Provider().GRPCProvider, protocol.Server)
+	require.NoError(t, err)
+	return factory.ProviderServer()
+}
+
+func TestGenerate(t *testing.T) {
+	s := pingaccesstest.NewServer(pingaccesstest.DefaultVersion)
+	defer s.Close()
+	t.Setenv("PINGACCESS_BASEURL", s.URL)
+	conf := config.NewConfig().WithUsername(pingaccesstest.Username).WithPassword(pingaccesstest.Password).WithEndpoint(s.Endpoint())
+
+	vh, _, err := virtualhosts.New(conf).AddVirtualHostCommand(&virtualhosts.AddVirtualHostCommandInput{Body: models.VirtualHostView{Host: pingaccess.String("example.com"), Port: pingaccess.Int(443)}})
+	require.NoError(t, err)
+	auth, _, err := siteAuthenticators.New(conf).AddSiteAuthenticatorCommand(&siteAuthenticators.AddSiteAuthenticatorCommandInput{Body: models.SiteAuthenticatorView{
+		Name:          pingaccess.String("basic auth"),
+		ClassName:     pingaccess.String("com.pingidentity.pa.siteauthenticators.BasicAuthTargetSiteAuthenticator"),
+		Configuration: map[string]interface{}{"username": "cheese", "password": "top_secret"},
+	}})
+	require.NoError(t, err)
+	authID, _ := auth.Id.Int64()
+	site, _, err := sites.New(conf).AddSiteCommand(&sites.AddSiteCommandInput{Body: models.SiteView{
+		Name:                 pingaccess.String("backend"),
+		Targets:              &[]*string{pingaccess.String("localhost:4567")},
+		SiteAuthenticatorIds: &[]*int{pingaccess.Int(int(authID))},
+	}})
+	require.NoError(t, err)
+	rule, _, err := rules.New(conf).AddRuleCommand(&rules.AddRuleCommandInput{Body: models.RuleView{
+		Name:          pingaccess.String("cidr"),
+		ClassName:     pingaccess.String("com.pingidentity.pa.policy.CIDRPolicyInterceptor"),
+		Configuration: map[string]interface{}{"cidrNotation": "127.0.0.1/32"},
+	}})
+	require.NoError(t, err)
+	vhID, _ := vh.Id.Int64()
+	siteID, _ := site.Id.Int64()
+	_, _, err = applications.New(conf).AddApplicationCommand(&applications.AddApplicationCommandInput{Body: models.ApplicationView{
+		Name:           pingaccess.String("my app"),
+		ContextRoot:    pingaccess.String("/app"),
+		Destination:    pingaccess.String("Site"),
+		SiteId:         pingaccess.Int(int(siteID)),
+		VirtualHostIds: &[]*int{pingaccess.Int(int(vhID))},
+		Policy: map[string]*[]*models.PolicyItem{
+			"Web": &[]*models.PolicyItem{{Type: pingaccess.String("Rule"), Id: rule.Id}},
+			"API": &[]*models.PolicyItem{},
+		},
+	}})
+	require.NoError(t, err)
+
+	b, err := Generate(context.Background(), providerServer(t), Options{})
+	require.NoError(t, err)
+	hcl := string(b)
+	t.Log(hcl)
+
+	assert.Contains(t, hcl, `resource "pingaccess_virtualhost" "example_com_443" {`)
+	assert.Contains(t, hcl, `resource "pingaccess_site" "backend" {`)
+	assert.Contains(t, hcl, `site_authenticator_ids       = [pingaccess_site_authenticator.basic_auth.id]`)
+	assert.Contains(t, hcl, `resource "pingaccess_application" "my_app" {`)
+	assert.Contains(t, hcl, `site_id                 = pingaccess_site.backend.id`)
+	assert.Contains(t, hcl, `virtual_host_ids        = [pingaccess_virtualhost.example_com_443.id]`)
+	assert.Contains(t, hcl, `id   = pingaccess_rule.cidr.id`)
+	assert.Contains(t, hcl, `password = var.pingaccess_site_authenticator_basic_auth_configuration_password`)
+	assert.Contains(t, hcl, "variable \"pingaccess_site_authenticator_basic_auth_configuration_password\" {\n  type      = string\n  sensitive = true\n}")
+	assert.NotContains(t, hcl, "encryptedValue")
+	assert.Contains(t, hcl, "import {\n  to = pingaccess_application.my_app\n  id = \"")
+	assert.NotContains(t, hcl, "pingaccess_application_resource", "the root resource is not generated")
+	assert.NotContains(t, hcl, "Trust Any", "system trusted certificate groups are not generated")
+}
+
+func TestGenerateUnsupportedType(t *testing.T) {
+	s := pingaccesstest.NewServer(pingaccesstest.DefaultVersion)
+	defer s.Close()
+	t.Setenv("PINGACCESS_BASEURL", s.URL)
+
+	_, err := Generate(context.Background(), providerServer(t), Options{Types: []string{"pingaccess_keypair_csr"}})
+	assert.EqualError(t, err, `unsupported resource type "pingaccess_keypair_csr", expected one of: `+strings.Join(sourceTypes(), ", "))
+}
+
+func TestNewAPI(t *testing.T) {
+	s := pingaccesstest.NewServer(pingaccesstest.DefaultVersion)
+	defer s.Close()
+	api, err := newAPI(tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"base_url": tftypes.String,
+		"context":  tftypes.String,
+		"username": tftypes.String,
+		"password": tftypes.String,
+	}}, map[string]tftypes.Value{
+		"base_url": tftypes.NewValue(tftypes.String, s.URL),
+		"context":  tftypes.NewValue(tftypes.String, pingaccesstest.Context),
+		"username": tftypes.NewValue(tftypes.String, pingaccesstest.Username),
+		"password": tftypes.NewValue(tftypes.String, pingaccesstest.Password),
+	}))
+	require.NoError(t, err)
+	// the client must not rely on the SDK and provider disabling certificate verification for every client
+	transport := http.DefaultTransport.(*http.Transport)
+	tlsConfig := transport.TLSClientConfig
+	transport.TLSClientConfig = nil
+	defer func() { transport.TLSClientConfig = tlsConfig }()
+	var version struct {
+		Version string `json:"version"`
+	}
+	_, err = api.Get("/version", &version)
+	require.NoError(t, err)
+	assert.Equal(t, pingaccesstest.DefaultVersion, version.Version)
+}
+
+func TestRenderJSONConfiguration(t *testing.T) {
+	r := &renderer{object: &object{typeName: "pingaccess_availability_profile", name: "profile"}}
+	v := tftypes.NewValue(tftypes.String, `{"connectTimeout":10000,"secret":{"encryptedValue":"abc"},"nested":[{"key":{"encryptedValue":"def"}}]}`)
+
+	f := hclwrite.NewEmptyFile()
+	f.Body().SetAttributeRaw("configuration", r.value([]string{"configuration"}, v))
+	assert.Equal(t, `configuration = jsonencode({
+  connectTimeout = 10000
+  nested = [{
+    key = var.pingaccess_availability_profile_profile_configuration_nested_0_key
+  }]
+  secret = var.pingaccess_availability_profile_profile_configuration_secret
+})
+`, string(hclwrite.Format(f.Bytes())))
+	assert.Len(t, r.variables, 2)
+}
+
+func TestResourceName(t *testing.T) {
+	tests := map[string]string{
+		"My App":           "my_app",
+		"Generated: ADMIN": "generated_admin",
+		"localhost_3000":   "localhost_3000",
+		"3000":             "r_3000",
+		"--":               "r",
+	}
+	for in, expect := range tests {
+		assert.Equal(t, expect, resourceName(in), in)
+	}
+}
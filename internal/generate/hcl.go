@@ -0,0 +1,378 @@
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// references are the resource types referenced by id attributes, by the attribute name.
+var references = map[string]string{
+	"additional_virtual_host_ids":  "pingaccess_virtualhost",
+	"application_id":               "pingaccess_application",
+	"availability_profile_id":      "pingaccess_availability_profile",
+	"cert_ids":                     "pingaccess_certificate",
+	"hsm_provider_id":              "pingaccess_hsm_provider",
+	"key_pair_id":                  "pingaccess_keypair",
+	"keypair_id":                   "pingaccess_keypair",
+	"load_balancing_strategy_id":   "pingaccess_load_balancing_strategy",
+	"primary_virtual_host_id":      "pingaccess_virtualhost",
+	"resource_ids":                 "pingaccess_application_resource",
+	"site_authenticator_ids":       "pingaccess_site_authenticator",
+	"site_id":                      "pingaccess_site",
+	"trusted_certificate_group_id": "pingaccess_trusted_certificate_group",
+	"virtual_host_ids":             "pingaccess_virtualhost",
+	"web_session_id":               "pingaccess_websession",
+}
+
+// policyTypes are the resource types of the policy item and ruleset element types.
+var policyTypes = map[string]string{
+	"Rule":    "pingaccess_rule",
+	"Ruleset": "pingaccess_ruleset",
+	"RuleSet": "pingaccess_ruleset",
+}
+
+// variable is a sensitive attribute replaced by a variable.
+type variable struct {
+	name string
+	typ  tftypes.Type
+}
+
+// render writes the resources, then the import blocks and the variables for the sensitive attributes.
+func (g *generator) render() []byte {
+	f := hclwrite.NewEmptyFile()
+	root := f.Body()
+	var variables []variable
+	for _, o := range g.objects {
+		block := root.AppendNewBlock("resource", []string{o.typeName, o.name})
+		r := &renderer{g: g, object: o}
+		r.block(block.Body(), g.schemas[o.typeName].Block, o.state, nil)
+		variables = append(variables, r.variables...)
+		root.AppendNewline()
+	}
+	for _, o := range g.objects {
+		block := root.AppendNewBlock("import", nil)
+		block.Body().SetAttributeRaw("to", hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: o.typeName},
+			hcl.TraverseAttr{Name: o.name},
+		}))
+		block.Body().SetAttributeValue("id", cty.StringVal(o.importID))
+		root.AppendNewline()
+	}
+	for _, v := range variables {
+		block := root.AppendNewBlock("variable", []string{v.name})
+		block.Body().SetAttributeRaw("type", hclwrite.TokensForIdentifier(typeName(v.typ)))
+		block.Body().SetAttributeValue("sensitive", cty.True)
+		root.AppendNewline()
+	}
+	return append(bytes.TrimRight(hclwrite.Format(f.Bytes()), "\n"), '\n')
+}
+
+type renderer struct {
+	g         *generator
+	object    *object
+	variables []variable
+}
+
+// block writes the attributes and nested blocks of the schema block. The id, computed only and deprecated attributes
+// are not written as they cannot be configured.
+func (r *renderer) block(body *hclwrite.Body, schema *tfprotov5.SchemaBlock, v tftypes.Value, path []string) {
+	values := map[string]tftypes.Value{}
+	if err := v.As(&values); err != nil {
+		return
+	}
+	attrs := append([]*tfprotov5.SchemaAttribute(nil), schema.Attributes...)
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Name < attrs[j].Name })
+	for _, a := range attrs {
+		value := values[a.Name]
+		if value.IsNull() || !value.IsKnown() || (a.Name == "id" && len(path) == 0) || a.Deprecated || (a.Computed && !a.Optional && !a.Required) {
+			continue
+		}
+		attrPath := append(append([]string(nil), path...), a.Name)
+		if a.Sensitive {
+			body.SetAttributeRaw(a.Name, r.variable(attrPath, value.Type()))
+			continue
+		}
+		body.SetAttributeRaw(a.Name, r.tokens(attrPath, values, value))
+	}
+	blocks := append([]*tfprotov5.SchemaNestedBlock(nil), schema.BlockTypes...)
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].TypeName < blocks[j].TypeName })
+	for _, b := range blocks {
+		value := values[b.TypeName]
+		if value.IsNull() || !value.IsKnown() {
+			continue
+		}
+		blockPath := append(append([]string(nil), path...), b.TypeName)
+		if b.Nesting == tfprotov5.SchemaNestedBlockNestingModeSingle || b.Nesting == tfprotov5.SchemaNestedBlockNestingModeGroup {
+			r.block(body.AppendNewBlock(b.TypeName, nil).Body(), b.Block, value, blockPath)
+			continue
+		}
+		var elems []tftypes.Value
+		if err := value.As(&elems); err != nil {
+			continue
+		}
+		for _, elem := range elems {
+			r.block(body.AppendNewBlock(b.TypeName, nil).Body(), b.Block, elem, blockPath)
+		}
+	}
+}
+
+// tokens returns the value of the attribute, with the ids of generated objects replaced by references.
+func (r *renderer) tokens(path []string, siblings map[string]tftypes.Value, v tftypes.Value) hclwrite.Tokens {
+	target := r.referenceType(path, siblings)
+	if target == "" {
+		return r.value(path, v)
+	}
+	var elems []tftypes.Value
+	if err := v.As(&elems); err == nil {
+		var tokens []hclwrite.Tokens
+		for _, e := range elems {
+			tokens = append(tokens, r.reference(target, e))
+		}
+		return hclwrite.TokensForTuple(tokens)
+	}
+	return r.reference(target, v)
+}
+
+func (r *renderer) reference(target string, v tftypes.Value) hclwrite.Tokens {
+	if o, ok := r.g.ids[target][scalar(v)]; ok {
+		return hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: o.typeName},
+			hcl.TraverseAttr{Name: o.name},
+			hcl.TraverseAttr{Name: "id"},
+		})
+	}
+	return hclwrite.TokensForValue(toCty(v))
+}
+
+// variable declares a variable for the sensitive value at the path and returns a reference to it.
+func (r *renderer) variable(path []string, typ tftypes.Type) hclwrite.Tokens {
+	name := resourceName(strings.Join(append([]string{r.object.typeName, r.object.name}, path...), "_"))
+	r.variables = append(r.variables, variable{name: name, typ: typ})
+	return hclwrite.TokensForTraversal(hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: name}})
+}
+
+// value returns the tokens of the value, with the encrypted values PingAccess returns for CONCEALED plugin fields
+// replaced by variables. Plugin configuration is either a dynamic value, where the encrypted value is a JSON string,
+// or a JSON string which is then written with jsonencode.
+func (r *renderer) value(path []string, v tftypes.Value) hclwrite.Tokens {
+	if v.IsNull() || !v.IsKnown() {
+		return hclwrite.TokensForValue(toCty(v))
+	}
+	t := v.Type()
+	switch {
+	case t.Is(tftypes.String):
+		var s string
+		_ = v.As(&s)
+		var decoded interface{}
+		if !strings.Contains(s, `"encryptedValue"`) || decodeJSON(s, &decoded) != nil {
+			break
+		}
+		if isEncrypted(decoded) {
+			return r.variable(path, tftypes.String)
+		}
+		if _, ok := decoded.(map[string]interface{}); ok {
+			return hclwrite.TokensForFunctionCall("jsonencode", r.json(path, decoded))
+		}
+	case t.Is(tftypes.List{}), t.Is(tftypes.Set{}), t.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		_ = v.As(&elems)
+		var tokens []hclwrite.Tokens
+		for i, e := range elems {
+			tokens = append(tokens, r.value(child(path, strconv.Itoa(i)), e))
+		}
+		return hclwrite.TokensForTuple(tokens)
+	case t.Is(tftypes.Map{}), t.Is(tftypes.Object{}):
+		attrs := map[string]tftypes.Value{}
+		_ = v.As(&attrs)
+		if e, ok := attrs["encryptedValue"]; ok && len(attrs) == 1 && e.Type().Is(tftypes.String) {
+			return r.variable(path, tftypes.String)
+		}
+		var tokens []hclwrite.ObjectAttrTokens
+		for _, k := range sortedKeys(attrs) {
+			tokens = append(tokens, hclwrite.ObjectAttrTokens{Name: objectKey(k), Value: r.value(child(path, k), attrs[k])})
+		}
+		return hclwrite.TokensForObject(tokens)
+	}
+	return hclwrite.TokensForValue(toCty(v))
+}
+
+// json returns the tokens of a decoded JSON value, with the encrypted values replaced by variables.
+func (r *renderer) json(path []string, v interface{}) hclwrite.Tokens {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if isEncrypted(v) {
+			return r.variable(path, tftypes.String)
+		}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var tokens []hclwrite.ObjectAttrTokens
+		for _, k := range keys {
+			tokens = append(tokens, hclwrite.ObjectAttrTokens{Name: objectKey(k), Value: r.json(child(path, k), v[k])})
+		}
+		return hclwrite.TokensForObject(tokens)
+	case []interface{}:
+		var tokens []hclwrite.Tokens
+		for i, e := range v {
+			tokens = append(tokens, r.json(child(path, strconv.Itoa(i)), e))
+		}
+		return hclwrite.TokensForTuple(tokens)
+	case string:
+		return hclwrite.TokensForValue(cty.StringVal(v))
+	case json.Number:
+		n, _, err := big.ParseFloat(v.String(), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return hclwrite.TokensForValue(cty.StringVal(v.String()))
+		}
+		return hclwrite.TokensForValue(cty.NumberVal(n))
+	case bool:
+		return hclwrite.TokensForValue(cty.BoolVal(v))
+	}
+	return hclwrite.TokensForValue(cty.NullVal(cty.DynamicPseudoType))
+}
+
+// child returns the path of an element, without sharing the backing array of the parent path.
+func child(path []string, name string) []string {
+	return append(append([]string(nil), path...), name)
+}
+
+func decodeJSON(s string, v interface{}) error {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// isEncrypted reports whether the decoded JSON is the encrypted value of a CONCEALED field.
+func isEncrypted(v interface{}) bool {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return false
+	}
+	_, ok = m["encryptedValue"].(string)
+	return ok
+}
+
+func sortedKeys(m map[string]tftypes.Value) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// objectKey returns the key of an object attribute, quoted when it is not a valid identifier.
+func objectKey(k string) hclwrite.Tokens {
+	if hclsyntax.ValidIdentifier(k) {
+		return hclwrite.TokensForIdentifier(k)
+	}
+	return hclwrite.TokensForValue(cty.StringVal(k))
+}
+
+// referenceType returns the resource type the attribute references, if any.
+func (r *renderer) referenceType(path []string, siblings map[string]tftypes.Value) string {
+	name := path[len(path)-1]
+	parent := ""
+	if len(path) > 1 {
+		parent = path[len(path)-2]
+	}
+	switch {
+	case r.object.typeName == "pingaccess_ruleset" && name == "policy":
+		if t, ok := attribute(r.object.state, "element_type"); ok {
+			return policyTypes[scalar(t)]
+		}
+	case name == "id" && (parent == "web" || parent == "api"):
+		return policyTypes[scalar(siblings["type"])]
+	case parent == "identity_mapping_ids":
+		return "pingaccess_identity_mapping"
+	}
+	return references[name]
+}
+
+// scalar returns a string or number value as a string.
+func scalar(v tftypes.Value) string {
+	if v.IsNull() || !v.IsKnown() {
+		return ""
+	}
+	switch {
+	case v.Type().Is(tftypes.String):
+		var s string
+		_ = v.As(&s)
+		return s
+	case v.Type().Is(tftypes.Number):
+		var n big.Float
+		_ = v.As(&n)
+		return n.Text('f', -1)
+	}
+	return ""
+}
+
+func toCty(v tftypes.Value) cty.Value {
+	if v.IsNull() || !v.IsKnown() {
+		return cty.NullVal(cty.DynamicPseudoType)
+	}
+	t := v.Type()
+	switch {
+	case t.Is(tftypes.String):
+		var s string
+		_ = v.As(&s)
+		return cty.StringVal(s)
+	case t.Is(tftypes.Number):
+		var n big.Float
+		_ = v.As(&n)
+		return cty.NumberVal(&n)
+	case t.Is(tftypes.Bool):
+		var b bool
+		_ = v.As(&b)
+		return cty.BoolVal(b)
+	case t.Is(tftypes.List{}), t.Is(tftypes.Set{}), t.Is(tftypes.Tuple{}):
+		var elems []tftypes.Value
+		_ = v.As(&elems)
+		if len(elems) == 0 {
+			return cty.EmptyTupleVal
+		}
+		vals := make([]cty.Value, 0, len(elems))
+		for _, e := range elems {
+			vals = append(vals, toCty(e))
+		}
+		return cty.TupleVal(vals)
+	case t.Is(tftypes.Map{}), t.Is(tftypes.Object{}):
+		attrs := map[string]tftypes.Value{}
+		_ = v.As(&attrs)
+		if len(attrs) == 0 {
+			return cty.EmptyObjectVal
+		}
+		vals := map[string]cty.Value{}
+		for k, a := range attrs {
+			vals[k] = toCty(a)
+		}
+		return cty.ObjectVal(vals)
+	}
+	return cty.NullVal(cty.DynamicPseudoType)
+}
+
+// typeName returns the type constraint of a variable holding the value type.
+func typeName(t tftypes.Type) string {
+	switch {
+	case t.Is(tftypes.Number):
+		return "number"
+	case t.Is(tftypes.Bool):
+		return "bool"
+	case t.Is(tftypes.String):
+		return "string"
+	}
+	return "any"
+}
@@ -0,0 +1,105 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// source lists the objects of a resource type from the admin API.
+type source struct {
+	typeName string
+	path     string
+	// name returns the basis of the Terraform resource name of the item.
+	name func(item map[string]interface{}) string
+	// importID returns the import ID of the item, the item id when not set.
+	importID func(item map[string]interface{}) string
+	// skip excludes items created by PingAccess which cannot be managed.
+	skip func(item map[string]interface{}) bool
+}
+
+func field(key string) func(map[string]interface{}) string {
+	return func(item map[string]interface{}) string {
+		return str(item[key])
+	}
+}
+
+func str(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// sources are listed in dependency order, so referenced objects are generated before the objects referencing them.
+var sources = []source{
+	{typeName: "pingaccess_certificate", path: "/certificates", name: field("alias")},
+	{
+		typeName: "pingaccess_trusted_certificate_group",
+		path:     "/trustedCertificateGroups",
+		name:     field("name"),
+		skip:     func(item map[string]interface{}) bool { return item["systemGroup"] == true },
+	},
+	{typeName: "pingaccess_hsm_provider", path: "/hsmProviders", name: field("name")},
+	{typeName: "pingaccess_keypair", path: "/keyPairs", name: field("alias")},
+	{typeName: "pingaccess_https_listener", path: "/httpsListeners", name: field("name")},
+	{typeName: "pingaccess_engine_listener", path: "/engineListeners", name: field("name")},
+	{
+		typeName: "pingaccess_virtualhost",
+		path:     "/virtualhosts",
+		name:     func(item map[string]interface{}) string { return str(item["host"]) + "_" + str(item["port"]) },
+	},
+	{typeName: "pingaccess_availability_profile", path: "/highAvailability/availabilityProfiles", name: field("name")},
+	{typeName: "pingaccess_load_balancing_strategy", path: "/highAvailability/loadBalancingStrategies", name: field("name")},
+	{typeName: "pingaccess_site_authenticator", path: "/siteAuthenticators", name: field("name")},
+	{typeName: "pingaccess_site", path: "/sites", name: field("name")},
+	{typeName: "pingaccess_third_party_service", path: "/thirdPartyServices", name: field("name")},
+	{typeName: "pingaccess_identity_mapping", path: "/identityMappings", name: field("name")},
+	{typeName: "pingaccess_access_token_validator", path: "/accessTokenValidators", name: field("name")},
+	{typeName: "pingaccess_rejection_handler", path: "/rejectionHandlers", name: field("name")},
+	{typeName: "pingaccess_authn_req_list", path: "/authnReqLists", name: field("name")},
+	{typeName: "pingaccess_websession", path: "/webSessions", name: field("name")},
+	{typeName: "pingaccess_rule", path: "/rules", name: field("name")},
+	{typeName: "pingaccess_ruleset", path: "/rulesets", name: field("name")},
+	{typeName: "pingaccess_acme_server", path: "/acme/servers", name: field("name")},
+	{typeName: "pingaccess_application", path: "/applications", name: field("name")},
+	{
+		typeName: "pingaccess_application_resource",
+		path:     "/applications/resources",
+		name:     field("name"),
+		importID: func(item map[string]interface{}) string {
+			return str(item["applicationId"]) + "/" + str(item["id"])
+		},
+		// the root resource is created with the application
+		skip: func(item map[string]interface{}) bool { return item["rootResource"] == true },
+	},
+}
+
+// sourceTypes returns the resource types which can be generated.
+func sourceTypes() []string {
+	var types []string
+	for _, s := range sources {
+		types = append(types, s.typeName)
+	}
+	return types
+}
+
+// resourceName returns a valid Terraform resource name for the name of an object.
+func resourceName(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			underscore = false
+		case !underscore && b.Len() > 0:
+			b.WriteRune('_')
+			underscore = true
+		}
+	}
+	n := strings.TrimSuffix(b.String(), "_")
+	if n == "" || n[0] >= '0' && n[0] <= '9' {
+		n = "r_" + n
+	}
+	return strings.TrimSuffix(n, "_")
+}
@@ -0,0 +1,92 @@
+// Package importid resolves the natural keys the importers accept in place of the id PingAccess assigns to an object,
+// such as `name=backend` for a site or `example.com:443` for a virtual host.
+package importid
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Match is an object listed from PingAccess which may be identified by the key.
+type Match struct {
+	ID  string
+	Key string
+}
+
+// Key is a natural key of an object.
+type Key struct {
+	// Kind is the kind of object identified, e.g. site.
+	Kind string
+	// Name describes the key in errors, e.g. name.
+	Name string
+	// Parse returns the key from the import ID, or false when the import ID is not a key of this type.
+	Parse func(id string) (string, bool)
+	// List returns the objects which may match the key. The filter parameters of the list endpoints also return
+	// partial and case insensitive matches, so only the matches with the exact key are used.
+	List func(key string) ([]Match, error)
+}
+
+var numeric = regexp.MustCompile(`^[0-9]+$`)
+
+// IsID reports whether the import ID is an id assigned by PingAccess rather than a natural key.
+func IsID(id string) bool {
+	return numeric.MatchString(id)
+}
+
+// Field parses import IDs of the form `<field>=<value>`.
+func Field(field string) func(string) (string, bool) {
+	return func(id string) (string, bool) {
+		if !strings.HasPrefix(id, field+"=") || len(id) == len(field)+1 {
+			return "", false
+		}
+		return strings.TrimPrefix(id, field+"="), true
+	}
+}
+
+// Name returns the `name=<name>` key of the kind of object.
+func Name(kind string, list func(name string) ([]Match, error)) Key {
+	return Key{Kind: kind, Name: "name", Parse: Field("name"), List: list}
+}
+
+// Alias returns the `alias=<alias>` key of the kind of object.
+func Alias(kind string, list func(alias string) ([]Match, error)) Key {
+	return Key{Kind: kind, Name: "alias", Parse: Field("alias"), List: list}
+}
+
+// HostPort parses import IDs of the form `<host>:<port>`.
+func HostPort(id string) (string, bool) {
+	i := strings.LastIndex(id, ":")
+	if i <= 0 || !IsID(id[i+1:]) {
+		return "", false
+	}
+	return id, true
+}
+
+// Resolve returns the id of the object identified by the import ID, or the import ID itself when it is not a key of
+// this type. It is an error when no object or more than one object has the key.
+func (k Key) Resolve(id string) (string, error) {
+	key, ok := k.Parse(id)
+	if !ok {
+		return id, nil
+	}
+	matches, err := k.List(key)
+	if err != nil {
+		return "", fmt.Errorf("unable to find the %s with %s %q: %s", k.Kind, k.Name, key, err)
+	}
+	var ids []string
+	for _, m := range matches {
+		if m.Key == key {
+			ids = append(ids, m.ID)
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no %s found with %s %q", k.Kind, k.Name, key)
+	case 1:
+		return ids[0], nil
+	}
+	sort.Strings(ids)
+	return "", fmt.Errorf("found %d %ss with %s %q (ids %s), import by id instead", len(ids), k.Kind, k.Name, key, strings.Join(ids, ", "))
+}
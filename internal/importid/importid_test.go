@@ -0,0 +1,68 @@
+package importid
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	key := Name("site", func(name string) ([]Match, error) {
+		switch name {
+		case "broken":
+			return nil, fmt.Errorf("boom")
+		}
+		return []Match{
+			{ID: "1", Key: "backend"},
+			{ID: "2", Key: "Backend"},
+			{ID: "3", Key: "backend-2"},
+			{ID: "5", Key: "duplicate"},
+			{ID: "4", Key: "duplicate"},
+		}, nil
+	})
+
+	tests := []struct {
+		id     string
+		expect string
+		err    string
+	}{
+		{id: "42", expect: "42"},
+		{id: "name", expect: "name"},
+		{id: "name=", expect: "name="},
+		{id: "name=backend", expect: "1"},
+		{id: "name=Backend", expect: "2"},
+		{id: "name=missing", err: `no site found with name "missing"`},
+		{id: "name=duplicate", err: `found 2 sites with name "duplicate" (ids 4, 5), import by id instead`},
+		{id: "name=broken", err: `unable to find the site with name "broken": boom`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.id, func(t *testing.T) {
+			id, err := key.Resolve(tc.id)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expect, id)
+		})
+	}
+}
+
+func TestHostPort(t *testing.T) {
+	tests := map[string]bool{
+		"example.com:443": true,
+		"*:3000":          true,
+		"1":               false,
+		":443":            false,
+		"example.com":     false,
+		"example.com:abc": false,
+	}
+	for id, ok := range tests {
+		key, parsed := HostPort(id)
+		assert.Equal(t, ok, parsed, id)
+		if ok {
+			assert.Equal(t, id, key)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package pav7
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pingaccesstest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	paCfg "github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+)
+
+func str(s string) *string {
+	return &s
+}
+
+func TestPreserve(t *testing.T) {
+	current := map[string]json.RawMessage{
+		"id":          json.RawMessage(`1`),
+		"name":        json.RawMessage(`"old"`),
+		"keyPairId":   json.RawMessage(`5`),
+		"secure":      json.RawMessage(`true`),
+		"maxRequests": json.RawMessage(`100`),
+	}
+	merged, err := Preserve(current, &models.SiteView{Name: str("new"), Targets: &[]*string{str("localhost:443")}})
+	require.NoError(t, err)
+	b, err := json.Marshal(merged)
+	require.NoError(t, err)
+	// attributes the 6.2 model declares come from the body even when it omits them, the others are kept
+	assert.JSONEq(t, `{"name":"new","targets":["localhost:443"],"keyPairId":5,"maxRequests":100}`, string(b))
+}
+
+func TestFields(t *testing.T) {
+	type extended struct {
+		models.RuleView
+		Extra   *int `json:"extra,omitempty"`
+		Ignored int  `json:"-"`
+	}
+	fields := Fields(reflect.TypeOf(&extended{}))
+	for _, name := range []string{"className", "configuration", "id", "name", "supportedDestinations", "extra"} {
+		assert.Contains(t, fields, name)
+	}
+	assert.NotContains(t, fields, "Ignored")
+	assert.Empty(t, Fields(reflect.TypeOf("")))
+}
+
+func TestUpdate(t *testing.T) {
+	s := pingaccesstest.NewServer("7.0.3")
+	t.Cleanup(s.Close)
+	c := New(paCfg.NewConfig().WithUsername(pingaccesstest.Username).WithPassword(pingaccesstest.Password).WithEndpoint(s.Endpoint()))
+
+	created := &models.SiteView{}
+	_, err := c.Create("/sites", map[string]interface{}{"name": "site", "targets": []string{"localhost:443"}, "keyPairId": 5}, created)
+	require.NoError(t, err)
+	path := "/sites/" + created.Id.String()
+
+	result := &models.SiteView{}
+	_, err = c.Update(path, &models.SiteView{Name: str("renamed"), Targets: &[]*string{str("localhost:443")}}, result)
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", *result.Name)
+
+	current := map[string]json.RawMessage{}
+	_, err = c.Get(path, &current)
+	require.NoError(t, err)
+	assert.JSONEq(t, `5`, string(current["keyPairId"]), "the attribute unknown to the 6.2 model is kept")
+
+	_, err = c.Update("/sites/99", &models.SiteView{Name: str("missing")}, result)
+	assert.Error(t, err)
+}
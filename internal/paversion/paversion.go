@@ -0,0 +1,26 @@
+// Package paversion parses and compares the versions reported by the PingAccess API, it is shared by both providers so
+// they agree on which features a server supports.
+package paversion
+
+import (
+	goversion "github.com/hashicorp/go-version"
+)
+
+// Parse parses the version reported by the PingAccess API, any pre-release or build metadata is ignored so that
+// snapshot builds are treated the same as the release they precede.
+func Parse(v string) (*goversion.Version, error) {
+	parsed, err := goversion.NewVersion(v)
+	if err != nil {
+		return nil, err
+	}
+	return parsed.Core(), nil
+}
+
+// AtLeast checks whether the version is the given PingAccess version or above, a nil version is never at least any
+// version.
+func AtLeast(version *goversion.Version, v string) bool {
+	if version == nil {
+		return false
+	}
+	return version.GreaterThanOrEqual(goversion.Must(goversion.NewVersion(v)))
+}
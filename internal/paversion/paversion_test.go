@@ -0,0 +1,76 @@
+package paversion
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAtLeast(t *testing.T) {
+	tests := []struct {
+		version string
+		min     string
+		expect  bool
+	}{
+		{"5.3.2", "6.0", false},
+		{"6.0.0", "6.0", true},
+		{"6.1.3", "6.2", false},
+		{"6.2.0", "6.2", true},
+		{"6.3.1", "6.2", true},
+		{"7.0.0-SNAPSHOT", "7.0", true},
+		{"10.0", "9.1", true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s is at least %s", tt.version, tt.min), func(t *testing.T) {
+			v, err := Parse(tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, AtLeast(v, tt.min))
+		})
+	}
+	assert.False(t, AtLeast(nil, "6.0"))
+}
+
+func TestParse(t *testing.T) {
+	v, err := Parse("6.2.1-SNAPSHOT+build.5")
+	require.NoError(t, err)
+	assert.Equal(t, "6.2.1", v.String())
+
+	_, err = Parse("not-a-version")
+	assert.Error(t, err)
+}
+
+func TestRequirements(t *testing.T) {
+	for key, req := range requirements {
+		for _, v := range []string{req.Min, req.Max} {
+			if v != "" {
+				_, err := Parse(v)
+				assert.NoError(t, err, key)
+			}
+		}
+	}
+
+	tests := []struct {
+		version string
+		key     string
+		expect  string
+	}{
+		{"5.3.2", "pingaccess_acme_server", ">= 6.0"},
+		{"6.0.0", "pingaccess_acme_server", ""},
+		{"6.1.0", "pingaccess_pingfederate_oauth.client_id", "< 6.1"},
+		{"6.0.0", FeatureMaskedPasswords, ">= 6.1"},
+		{"5.3.2", "pingaccess_site", ""},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s on %s", tt.key, tt.version), func(t *testing.T) {
+			v, err := Parse(tt.version)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expect, Unsatisfied(v, tt.key))
+			assert.Equal(t, tt.expect == "", Supports(v, tt.key))
+		})
+	}
+	assert.Equal(t, "", Unsatisfied(nil, "pingaccess_acme_server"))
+	assert.True(t, Declared("pingaccess_hsm_provider"))
+	assert.Equal(t, []string{"client_credentials", "client_id", "client_secret"}, Attributes("pingaccess_pingfederate_oauth"))
+}
@@ -0,0 +1,87 @@
+package paversion
+
+import (
+	"sort"
+	"strings"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// Requirement describes the range of PingAccess versions supporting a resource, attribute or API feature. The min
+// version is inclusive and the max version is exclusive, an empty value leaves that end of the range unbounded.
+type Requirement struct {
+	Min string
+	Max string
+}
+
+// Internal API features which change behaviour between PingAccess versions rather than adding configuration.
+const (
+	// FeatureMaskedPasswords - the API returns an encryptedValue for hidden fields, allowing us to track changes.
+	FeatureMaskedPasswords = "masked_passwords"
+	// FeatureKeyPairHiddenFieldPassword - the keypair import API accepts the password as a hidden field.
+	FeatureKeyPairHiddenFieldPassword = "keypair_hidden_field_password"
+	// FeatureKeyPairHsmProvider - keypairs may be stored in an HSM, the API returns the hsmProviderId of each keypair.
+	FeatureKeyPairHsmProvider = "keypair_hsm_provider"
+)
+
+// requirements declares the PingAccess versions supporting each resource or data source (keyed by type name),
+// attribute (keyed by `type.attribute`) or internal API feature, for both providers.
+var requirements = map[string]Requirement{
+	"pingaccess_acme_default":                                            {Min: "6.0"},
+	"pingaccess_acme_server":                                             {Min: "6.0"},
+	"pingaccess_application.risk_policy_id":                              {Min: "7.0"},
+	"pingaccess_application_resource.authentication_challenge_policy_id": {Min: "6.2"},
+	"pingaccess_application_resource.query_param_config":                 {Min: "6.1"},
+	"pingaccess_hsm_provider":                                            {Min: "6.0"},
+	"pingaccess_keypair.hsm_provider_id":                                 {Min: "6.0"},
+	"pingaccess_pingfederate_oauth.client_credentials":                   {Min: "6.1"},
+	"pingaccess_pingfederate_oauth.client_id":                            {Max: "6.1"},
+	"pingaccess_pingfederate_oauth.client_secret":                        {Max: "6.1"},
+	"pingaccess_pingfederate_runtime.issuer":                             {Min: "6.0"},
+	"pingaccess_pingfederate_runtime.sts_token_exchange_endpoint":        {Min: "6.0"},
+	"pingaccess_pingfederate_runtime_metadata":                           {Min: "6.0"},
+
+	FeatureMaskedPasswords:            {Min: "6.1"},
+	FeatureKeyPairHiddenFieldPassword: {Min: "6.2"},
+	FeatureKeyPairHsmProvider:         {Min: "6.0"},
+}
+
+// Declared checks whether the key has a declared version requirement.
+func Declared(key string) bool {
+	_, ok := requirements[key]
+	return ok
+}
+
+// Unsatisfied returns a description of the version constraint for the key which the version does not satisfy, such as
+// `>= 6.1`. It is empty when the constraint is satisfied, the key has no declared requirement or the version is unknown.
+func Unsatisfied(version *goversion.Version, key string) string {
+	req, ok := requirements[key]
+	if !ok || version == nil {
+		return ""
+	}
+	if req.Min != "" && !AtLeast(version, req.Min) {
+		return ">= " + req.Min
+	}
+	if req.Max != "" && AtLeast(version, req.Max) {
+		return "< " + req.Max
+	}
+	return ""
+}
+
+// Supports checks whether the version satisfies the requirement declared for the key, keys without any declared
+// requirement are always supported.
+func Supports(version *goversion.Version, key string) bool {
+	return Unsatisfied(version, key) == ""
+}
+
+// Attributes returns the sorted names of the attributes with declared version requirements for the type.
+func Attributes(typeName string) []string {
+	var attrs []string
+	for key := range requirements {
+		if attr := strings.TrimPrefix(key, typeName+"."); attr != key {
+			attrs = append(attrs, attr)
+		}
+	}
+	sort.Strings(attrs)
+	return attrs
+}
@@ -0,0 +1,510 @@
+package pingaccesstest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/paversion"
+)
+
+// applicationDefaults are the values PingAccess sets for an application when they are not given.
+func applicationDefaults() map[string]interface{} {
+	return map[string]interface{}{
+		"accessValidatorId":                     jsonInt(0),
+		"agentCacheInvalidatedExpiration":       jsonInt(30),
+		"agentCacheInvalidatedResponseDuration": jsonInt(30),
+		"agentId":                               jsonInt(0),
+		"allowEmptyPathSegments":                false,
+		"applicationType":                       "Web",
+		"authenticationChallengePolicyId":       nil,
+		"caseSensitivePath":                     false,
+		"defaultAuthType":                       "Web",
+		"description":                           "",
+		"destination":                           "Site",
+		"enabled":                               true,
+		"identityMappingIds":                    map[string]interface{}{"Web": jsonInt(0), "API": jsonInt(0)},
+		"issuer":                                nil,
+		"manualOrderingEnabled":                 false,
+		"policy":                                map[string]interface{}{"Web": []interface{}{}, "API": []interface{}{}},
+		"realm":                                 nil,
+		"requireHTTPS":                          false,
+		"resourceOrder":                         []interface{}{},
+		"siteId":                                jsonInt(0),
+		"spaSupportEnabled":                     true,
+		"webSessionId":                          jsonInt(0),
+	}
+}
+
+// resourceDefaults are the values PingAccess sets for an application resource when they are not given.
+func resourceDefaults() map[string]interface{} {
+	return map[string]interface{}{
+		"anonymous":                       false,
+		"auditLevel":                      "ON",
+		"authenticationChallengePolicyId": nil,
+		"defaultAuthTypeOverride":         nil,
+		"enabled":                         true,
+		"pathPatterns":                    []interface{}{},
+		"policy":                          map[string]interface{}{"Web": []interface{}{}, "API": []interface{}{}},
+		"queryParamConfig":                nil,
+		"resourceType":                    "Standard",
+		"rootResource":                    false,
+		"unprotected":                     false,
+	}
+}
+
+func (s *Server) validateApplication(v validation, id string, body map[string]interface{}) {
+	for _, r := range policyReferences(body["policy"]) {
+		s.checkReference(v, "policy", r.path, r.id)
+	}
+	if root, _ := body["contextRoot"].(string); root != "" && !strings.HasPrefix(root, "/") {
+		v.add("contextRoot", "contextRoot must start with '/'")
+	}
+	if t, _ := body["applicationType"].(string); t != "" && t != "Web" && t != "API" && t != "Dynamic" {
+		v.add("applicationType", "'%s' is not a valid applicationType, expected one of: Web, API, Dynamic", t)
+	}
+	if t, _ := body["defaultAuthType"].(string); t != "" && t != "Web" && t != "API" {
+		v.add("defaultAuthType", "'%s' is not a valid defaultAuthType, expected one of: Web, API", t)
+	}
+	if _, ok := body["riskPolicyId"]; ok && !s.atLeast("7.0") {
+		v.add("riskPolicyId", "Unrecognized field 'riskPolicyId'")
+	}
+	destination, _ := body["destination"].(string)
+	site, _ := number(body["siteId"])
+	agent, _ := number(body["agentId"])
+	switch {
+	case destination == "Agent" && agent == 0:
+		v.add("agentId", "agentId is required when the destination is Agent")
+	case destination != "Agent" && site == 0:
+		v.add("siteId", "siteId is required when the destination is Site")
+	}
+
+	// the context root must be unique for each virtual host of the application
+	root := str(body["contextRoot"])
+	hosts := map[string]bool{}
+	for _, h := range referencedIDs(body["virtualHostIds"]) {
+		hosts[h] = true
+	}
+	for _, other := range s.collections["/applications"].store.list() {
+		if str(other["id"]) == id || !strings.EqualFold(str(other["contextRoot"]), root) {
+			continue
+		}
+		for _, h := range referencedIDs(other["virtualHostIds"]) {
+			if hosts[h] {
+				v.add("contextRoot", "The context root '%s' is already in use by application '%s' on the virtual host %s", root, str(other["name"]), h)
+				break
+			}
+		}
+	}
+
+	if id == "" {
+		return
+	}
+	manual, _ := body["manualOrderingEnabled"].(bool)
+	order, ok := body["resourceOrder"].([]interface{})
+	if !manual || !ok {
+		return
+	}
+	expected := map[string]bool{}
+	for _, r := range s.applicationResources(id) {
+		expected[str(r["id"])] = true
+	}
+	seen := map[string]bool{}
+	for _, raw := range order {
+		rid := str(raw)
+		if !expected[rid] {
+			v.add("resourceOrder", "Resource %s is not a resource of the application", rid)
+		} else if seen[rid] {
+			v.add("resourceOrder", "Resource %s is included more than once", rid)
+		}
+		seen[rid] = true
+	}
+	if len(seen) < len(expected) {
+		v.add("resourceOrder", "The resource order must include every resource of the application")
+	}
+}
+
+// applicationCreated creates the root resource of the application, as PingAccess does for every new application.
+// presentApplication adds the fields of the PingAccess 7.x application which are null when not set.
+func (s *Server) presentApplication(item map[string]interface{}) {
+	if _, ok := item["riskPolicyId"]; !ok && s.atLeast("7.0") {
+		item["riskPolicyId"] = nil
+	}
+}
+
+// atLeast checks whether the fake reports the given PingAccess version or above.
+func (s *Server) atLeast(v string) bool {
+	version, err := paversion.Parse(s.version)
+	return err == nil && paversion.AtLeast(version, v)
+}
+
+func (s *Server) applicationCreated(id string, item map[string]interface{}) {
+	rid := s.resources.allocate()
+	resource := merge(resourceDefaults(), map[string]interface{}{
+		"id":            jsonInt(rid),
+		"applicationId": jsonInt(mustAtoi(id)),
+		"name":          "Root Resource",
+		"methods":       []interface{}{"*"},
+		"pathPatterns":  []interface{}{map[string]interface{}{"pattern": "/*", "type": "WILDCARD"}},
+		"pathPrefixes":  []interface{}{"/*"},
+		"rootResource":  true,
+	})
+	s.resources.put(strconv.Itoa(rid), resource)
+	item["resourceOrder"] = []interface{}{jsonInt(rid)}
+}
+
+// applicationUpdated keeps the resource order when it is not given, as the order is maintained by PingAccess as
+// resources are added and removed.
+func (s *Server) applicationUpdated(previous, item map[string]interface{}) {
+	manual, _ := item["manualOrderingEnabled"].(bool)
+	if order, ok := item["resourceOrder"].([]interface{}); !manual || !ok || len(order) == 0 {
+		item["resourceOrder"] = previous["resourceOrder"]
+	}
+}
+
+func (s *Server) applicationDeleted(id string) {
+	for _, r := range s.applicationResources(id) {
+		s.resources.remove(str(r["id"]))
+	}
+}
+
+// applicationResources returns the resources of the application in the order they were created.
+func (s *Server) applicationResources(applicationID string) []map[string]interface{} {
+	var resources []map[string]interface{}
+	for _, r := range s.resources.list() {
+		if str(r["applicationId"]) == applicationID {
+			resources = append(resources, r)
+		}
+	}
+	return resources
+}
+
+// serveApplication handles the resources of an application, ok is false when the request is for the application
+// itself.
+func (s *Server) serveApplication(method, path string, r *http.Request, body map[string]interface{}) (int, interface{}, error, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/applications/"), "/")
+	if len(parts) == 1 && parts[0] == "resources" && method == http.MethodGet {
+		return http.StatusOK, map[string]interface{}{"items": filterItems(s.resources.list(), r, "name")}, nil, true
+	}
+	if len(parts) < 2 {
+		return 0, nil, nil, false
+	}
+	app, ok := s.collections["/applications"].store.get(parts[0])
+	if !ok {
+		return 0, nil, notFound(), true
+	}
+	switch {
+	case len(parts) == 2 && parts[1] == "resources":
+		switch method {
+		case http.MethodGet:
+			return http.StatusOK, map[string]interface{}{"items": filterItems(s.applicationResources(parts[0]), r, "name")}, nil, true
+		case http.MethodPost:
+			result, err := s.saveResource(app, "", body)
+			return http.StatusOK, result, err, true
+		}
+	case len(parts) == 3 && parts[1] == "resources" && parts[2] == "autoOrder" && method == http.MethodGet:
+		ids := []interface{}{}
+		for _, r := range s.autoOrder(parts[0]) {
+			ids = append(ids, r["id"])
+		}
+		return http.StatusOK, map[string]interface{}{"id": app["id"], "resourceIds": ids}, nil, true
+	case len(parts) == 3 && parts[1] == "resources":
+		resource, ok := s.resources.get(parts[2])
+		if !ok || str(resource["applicationId"]) != parts[0] {
+			return 0, nil, notFound(), true
+		}
+		switch method {
+		case http.MethodGet:
+			return http.StatusOK, copyObject(resource), nil, true
+		case http.MethodPut:
+			result, err := s.saveResource(app, parts[2], body)
+			return http.StatusOK, result, err, true
+		case http.MethodDelete:
+			if root, _ := resource["rootResource"].(bool); root {
+				return 0, nil, &apiError{status: http.StatusUnprocessableEntity, Flash: []string{"The root resource of an application cannot be deleted"}, Result: "invalid_request"}, true
+			}
+			s.resources.remove(parts[2])
+			s.removeFromOrder(app, parts[2])
+			return http.StatusOK, nil, nil, true
+		}
+	case len(parts) == 2 && parts[1] == "resourceMatchingEvaluationOrder" && method == http.MethodGet:
+		return http.StatusOK, map[string]interface{}{"entries": s.evaluationOrder(app)}, nil, true
+	default:
+		return 0, nil, notFound(), true
+	}
+	return 0, nil, methodNotAllowed(), true
+}
+
+func (s *Server) saveResource(app map[string]interface{}, id string, body map[string]interface{}) (interface{}, error) {
+	applicationID := str(app["id"])
+	v := validation{}
+	for _, f := range []string{"name", "methods"} {
+		if isEmpty(body[f]) {
+			v.add(f, "%s is required", f)
+		}
+	}
+	var existing map[string]interface{}
+	if id != "" {
+		existing, _ = s.resources.get(id)
+	}
+	root := existing != nil && existing["rootResource"] == true
+	if body["rootResource"] == true && !root {
+		v.add("rootResource", "The application already has a root resource")
+	}
+	patterns := resourcePatterns(body)
+	if len(patterns) == 0 && !root {
+		v.add("pathPatterns", "pathPatterns is required")
+	}
+	for i, p := range patterns {
+		switch {
+		case p.kind != "WILDCARD" && p.kind != "REGEX":
+			v.add(fmt.Sprintf("pathPatterns[%d].type", i), "'%s' is not a valid type, expected one of: WILDCARD, REGEX", p.kind)
+		case p.kind == "WILDCARD" && !strings.HasPrefix(p.pattern, "/"):
+			v.add(fmt.Sprintf("pathPatterns[%d].pattern", i), "The pattern '%s' must start with '/'", p.pattern)
+		}
+	}
+	methods := stringList(body["methods"])
+	for _, other := range s.applicationResources(applicationID) {
+		if str(other["id"]) == id {
+			continue
+		}
+		if strings.EqualFold(str(other["name"]), str(body["name"])) {
+			v.add("name", "The name '%s' is already in use by another resource of the application", str(body["name"]))
+		}
+		if !methodsOverlap(methods, stringList(other["methods"])) {
+			continue
+		}
+		for _, p := range resourcePatterns(other) {
+			for _, q := range patterns {
+				if p == q {
+					v.add("pathPatterns", "The path pattern '%s' is already in use by resource '%s'", q.pattern, str(other["name"]))
+				}
+			}
+		}
+	}
+	for _, r := range policyReferences(body["policy"]) {
+		s.checkReference(v, "policy", r.path, r.id)
+	}
+	if qp, ok := body["queryParamConfig"].(map[string]interface{}); ok {
+		params, _ := qp["params"].([]interface{})
+		for i, raw := range params {
+			param, _ := raw.(map[string]interface{})
+			name, _ := param["name"].(map[string]interface{})
+			if name == nil || isEmpty(name["value"]) {
+				v.add(fmt.Sprintf("queryParamConfig.params[%d].name", i), "the name of the query parameter is required")
+			}
+		}
+	}
+	if t := str(body["resourceType"]); t != "" && t != "Standard" && t != "Virtual" {
+		v.add("resourceType", "'%s' is not a valid resourceType, expected one of: Standard, Virtual", t)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	item := merge(resourceDefaults(), copyObject(body))
+	item["applicationId"] = app["id"]
+	item["rootResource"] = root
+	var prefixes []interface{}
+	for _, p := range patterns {
+		prefixes = append(prefixes, p.pattern)
+	}
+	item["pathPrefixes"] = prefixes
+	if id == "" {
+		rid := s.resources.allocate()
+		id = strconv.Itoa(rid)
+		order, _ := app["resourceOrder"].([]interface{})
+		app["resourceOrder"] = append(order, jsonInt(rid))
+	}
+	item["id"] = jsonInt(mustAtoi(id))
+	s.resources.put(id, item)
+	return copyObject(item), nil
+}
+
+func (s *Server) removeFromOrder(app map[string]interface{}, id string) {
+	order, _ := app["resourceOrder"].([]interface{})
+	kept := []interface{}{}
+	for _, raw := range order {
+		if str(raw) != id {
+			kept = append(kept, raw)
+		}
+	}
+	app["resourceOrder"] = kept
+}
+
+type pathPattern struct {
+	pattern string
+	kind    string
+}
+
+// resourcePatterns returns the path patterns of the resource, the path prefixes of older versions are treated as
+// wildcard patterns.
+func resourcePatterns(resource map[string]interface{}) []pathPattern {
+	var patterns []pathPattern
+	raw, _ := resource["pathPatterns"].([]interface{})
+	for _, item := range raw {
+		m, _ := item.(map[string]interface{})
+		patterns = append(patterns, pathPattern{pattern: str(m["pattern"]), kind: str(m["type"])})
+	}
+	if len(patterns) == 0 {
+		for _, p := range stringList(resource["pathPrefixes"]) {
+			patterns = append(patterns, pathPattern{pattern: p, kind: "WILDCARD"})
+		}
+	}
+	return patterns
+}
+
+func methodsOverlap(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == "*" || y == "*" || strings.EqualFold(x, y) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// autoOrder returns the resources of the application in the order PingAccess would suggest, the most specific wildcard
+// patterns first followed by the regex patterns and the root resource last.
+func (s *Server) autoOrder(applicationID string) []map[string]interface{} {
+	resources := s.applicationResources(applicationID)
+	rank := func(r map[string]interface{}) (int, int) {
+		if r["rootResource"] == true {
+			return 2, 0
+		}
+		best := 0
+		regex := true
+		for _, p := range resourcePatterns(r) {
+			if p.kind == "WILDCARD" {
+				regex = false
+				if l := len(strings.TrimRight(p.pattern, "*")); l > best {
+					best = l
+				}
+			}
+		}
+		if regex {
+			return 1, 0
+		}
+		return 0, -best
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		gi, si := rank(resources[i])
+		gj, sj := rank(resources[j])
+		if gi != gj {
+			return gi < gj
+		}
+		return si < sj
+	})
+	return resources
+}
+
+// evaluationOrder returns the path patterns of the application resources in the order they are matched, following
+// the resource order when manual ordering is enabled.
+func (s *Server) evaluationOrder(app map[string]interface{}) []interface{} {
+	applicationID := str(app["id"])
+	resources := s.autoOrder(applicationID)
+	if manual, _ := app["manualOrderingEnabled"].(bool); manual {
+		resources = nil
+		order, _ := app["resourceOrder"].([]interface{})
+		for _, raw := range order {
+			if r, ok := s.resources.get(str(raw)); ok {
+				resources = append(resources, r)
+			}
+		}
+	}
+	entries := []interface{}{}
+	for _, r := range resources {
+		for _, p := range resourcePatterns(r) {
+			entries = append(entries, map[string]interface{}{
+				"name":        r["name"],
+				"type":        "Resource",
+				"pattern":     p.pattern,
+				"patternType": p.kind,
+				"methods":     r["methods"],
+				"link": map[string]interface{}{
+					"id":       str(r["id"]),
+					"location": fmt.Sprintf("%s/applications/%s/resources/%s", s.Endpoint(), applicationID, str(r["id"])),
+				},
+			})
+		}
+	}
+	return entries
+}
+
+// policyReferences returns the rules and rulesets referenced by an application or resource policy.
+func policyReferences(policy interface{}) []reference {
+	var refs []reference
+	p, _ := policy.(map[string]interface{})
+	for _, k := range sortedKeys(p) {
+		items, _ := p[k].([]interface{})
+		for _, raw := range items {
+			item, _ := raw.(map[string]interface{})
+			path := "/rules"
+			if str(item["type"]) == "Ruleset" {
+				path = "/rulesets"
+			}
+			for _, id := range referencedIDs(item["id"]) {
+				refs = append(refs, reference{path: path, id: id})
+			}
+		}
+	}
+	return refs
+}
+
+// rulesetReferences returns the rules or rulesets in the policy of a ruleset.
+func rulesetReferences(ruleset map[string]interface{}) []reference {
+	path := "/rules"
+	if str(ruleset["elementType"]) == "Ruleset" {
+		path = "/rulesets"
+	}
+	var refs []reference
+	for _, id := range referencedIDs(ruleset["policy"]) {
+		refs = append(refs, reference{path: path, id: id})
+	}
+	return refs
+}
+
+func (s *Server) validateRuleset(v validation, id string, body map[string]interface{}) {
+	if t := str(body["elementType"]); t != "" && t != "Rule" && t != "Ruleset" {
+		v.add("elementType", "'%s' is not a valid elementType, expected one of: Rule, Ruleset", t)
+		return
+	}
+	if c := str(body["successCriteria"]); c != "" && c != "SuccessIfAllSucceed" && c != "SuccessIfAnyOneSucceeds" {
+		v.add("successCriteria", "'%s' is not a valid successCriteria, expected one of: SuccessIfAllSucceed, SuccessIfAnyOneSucceeds", c)
+	}
+	for _, r := range rulesetReferences(body) {
+		s.checkReference(v, "policy", r.path, r.id)
+		if r.path == "/rulesets" && id != "" && s.rulesetContains(r.id, id, map[string]bool{}) {
+			v.add("policy", "Ruleset %s cannot contain itself", id)
+		}
+	}
+}
+
+// rulesetContains reports whether the ruleset, or any ruleset within it, contains the target ruleset.
+func (s *Server) rulesetContains(id, target string, seen map[string]bool) bool {
+	if id == target {
+		return true
+	}
+	if seen[id] {
+		return false
+	}
+	seen[id] = true
+	ruleset, ok := s.collections["/rulesets"].store.get(id)
+	if !ok {
+		return false
+	}
+	for _, r := range rulesetReferences(ruleset) {
+		if r.path == "/rulesets" && s.rulesetContains(r.id, target, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustAtoi(s string) int {
+	i, _ := strconv.Atoi(s)
+	return i
+}
@@ -0,0 +1,280 @@
+package pingaccesstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Redacted replaces the values of secret fields recorded in a cassette.
+const Redacted = "REDACTED"
+
+// RecordedEndpoint replaces the address of the recorded PingAccess in a cassette.
+const RecordedEndpoint = "https://pingaccess.test"
+
+// secretFields are the fields whose values are removed from the recorded traffic, the values of hidden fields (objects
+// of value and encryptedValue) are always removed.
+var secretFields = map[string]bool{
+	"adminPassword": true,
+	"clientSecret":  true,
+	"password":      true,
+	"privateKey":    true,
+	"secret":        true,
+}
+
+// Interaction is a request to the admin API and the response returned by PingAccess.
+type Interaction struct {
+	Method       string `json:"method"`
+	Path         string `json:"path"`
+	Query        string `json:"query,omitempty"`
+	RequestBody  string `json:"requestBody,omitempty"`
+	Status       int    `json:"status"`
+	ContentType  string `json:"contentType,omitempty"`
+	ResponseBody string `json:"responseBody,omitempty"`
+}
+
+// Cassette is the admin API traffic recorded against a version of PingAccess.
+type Cassette struct {
+	Version      string         `json:"version"`
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// CassettePath returns the path of the cassette for the PingAccess version in dir.
+func CassettePath(dir, version string) string {
+	return filepath.Join(dir, version+".json")
+}
+
+// LoadCassette reads a cassette written by a Recorder.
+func LoadCassette(path string) (*Cassette, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c Cassette
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("unable to parse cassette %s: %w", path, err)
+	}
+	return &c, nil
+}
+
+// Save writes the cassette as indented json so changes between recordings can be reviewed.
+func (c *Cassette) Save(path string) error {
+	b, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o600)
+}
+
+// scrubber removes secrets and replaces addresses which change between runs, such as the PingAccess endpoint and any
+// servers started by the tests, with stable placeholders.
+type scrubber struct {
+	replacements map[string]string
+}
+
+func newScrubber(endpoint string, hosts map[string]string) *scrubber {
+	replacements := map[string]string{endpoint: RecordedEndpoint}
+	for actual, placeholder := range hosts {
+		if actual != "" {
+			replacements[actual] = placeholder
+		}
+	}
+	return &scrubber{replacements: replacements}
+}
+
+// scrub returns the body with the secret fields redacted and addresses replaced by their placeholders.
+func (s *scrubber) scrub(body string) string {
+	for _, actual := range s.actuals() {
+		body = strings.ReplaceAll(body, actual, s.replacements[actual])
+	}
+	if body == "" || (body[0] != '{' && body[0] != '[') {
+		return body
+	}
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return body
+	}
+	b, _ := json.Marshal(redact(v))
+	return string(b)
+}
+
+// restore returns the body with the placeholders replaced by the addresses of this run.
+func (s *scrubber) restore(body string) string {
+	for _, actual := range s.actuals() {
+		body = strings.ReplaceAll(body, s.replacements[actual], actual)
+	}
+	return body
+}
+
+// actuals returns the replaced addresses longest first, so an address is replaced before any address it contains.
+func (s *scrubber) actuals() []string {
+	actuals := sortedKeys(s.replacements)
+	sort.SliceStable(actuals, func(i, j int) bool { return len(actuals[i]) > len(actuals[j]) })
+	return actuals
+}
+
+func redact(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		hidden := isHiddenField(t)
+		for k, child := range t {
+			if _, ok := child.(string); ok && (hidden || secretFields[k]) {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = redact(child)
+		}
+	case []interface{}:
+		for i, child := range t {
+			t[i] = redact(child)
+		}
+	}
+	return v
+}
+
+// Recorder is a proxy to a PingAccess admin API recording the traffic into a cassette.
+type Recorder struct {
+	*httptest.Server
+	target   *url.URL
+	scrubber *scrubber
+	mu       sync.Mutex
+	cassette *Cassette
+}
+
+// NewRecorder starts a proxy to the PingAccess at target (the scheme, host and port) recording against the version.
+// The hosts are replaced by their placeholders in the cassette, these should be the addresses of any servers started by
+// the tests which are sent to PingAccess.
+func NewRecorder(target, version string, hosts map[string]string) (*Recorder, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	r := &Recorder{target: u, cassette: &Cassette{Version: version}}
+	r.Server = httptest.NewTLSServer(http.HandlerFunc(r.serveHTTP))
+	r.scrubber = newScrubber(r.URL, hosts)
+	r.scrubber.replacements[strings.TrimSuffix(target, "/")] = RecordedEndpoint
+	return r, nil
+}
+
+// Cassette returns the traffic recorded so far.
+func (r *Recorder) Cassette() *Cassette {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return &Cassette{Version: r.cassette.Version, Interactions: append([]*Interaction(nil), r.cassette.Interactions...)}
+}
+
+func (r *Recorder) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	out, err := http.NewRequestWithContext(req.Context(), req.Method, r.target.String()+req.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	out.Header = req.Header.Clone()
+	resp, err := http.DefaultTransport.RoundTrip(out)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(resp.Body)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		Query:        req.URL.RawQuery,
+		RequestBody:  r.scrubber.scrub(string(body)),
+		Status:       resp.StatusCode,
+		ContentType:  resp.Header.Get("Content-Type"),
+		ResponseBody: r.scrubber.scrub(string(respBody)),
+	})
+	r.mu.Unlock()
+
+	for k, v := range resp.Header {
+		w.Header()[k] = v
+	}
+	w.Header().Del("Content-Length")
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write([]byte(strings.ReplaceAll(string(respBody), r.target.String(), r.URL)))
+}
+
+// Replayer serves the traffic of a cassette. Requests are matched on the method, path and query, where several
+// interactions match they are returned in the order they were recorded preferring one with the same request body. Once
+// all have been returned the last is repeated, as the number of reads made by terraform can vary between runs.
+type Replayer struct {
+	*httptest.Server
+	scrubber *scrubber
+	mu       sync.Mutex
+	pending  map[string][]*Interaction
+	last     map[string]*Interaction
+}
+
+// NewReplayer starts a server replaying the cassette, the hosts are the addresses of servers started by the tests
+// replaced with their placeholders when recording.
+func NewReplayer(cassette *Cassette, hosts map[string]string) *Replayer {
+	r := &Replayer{pending: map[string][]*Interaction{}, last: map[string]*Interaction{}}
+	for _, i := range cassette.Interactions {
+		key := interactionKey(i.Method, i.Path, i.Query)
+		r.pending[key] = append(r.pending[key], i)
+	}
+	r.Server = httptest.NewTLSServer(http.HandlerFunc(r.serveHTTP))
+	r.scrubber = newScrubber(r.URL, hosts)
+	return r
+}
+
+func interactionKey(method, path, query string) string {
+	return method + " " + path + "?" + query
+}
+
+func (r *Replayer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	i := r.next(interactionKey(req.Method, req.URL.Path, req.URL.RawQuery), r.scrubber.scrub(string(body)))
+	if i == nil {
+		writeError(w, &apiError{
+			status: http.StatusNotImplemented,
+			Flash:  []string{fmt.Sprintf("no recorded interaction for %s %s", req.Method, req.URL.RequestURI())},
+			Result: "not_recorded",
+		})
+		return
+	}
+	if i.ContentType != "" {
+		w.Header().Set("Content-Type", i.ContentType)
+	}
+	w.WriteHeader(i.Status)
+	_, _ = w.Write([]byte(r.scrubber.restore(i.ResponseBody)))
+}
+
+func (r *Replayer) next(key, body string) *Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pending := r.pending[key]
+	if len(pending) == 0 {
+		return r.last[key]
+	}
+	n := 0
+	for idx, i := range pending {
+		if i.RequestBody == body {
+			n = idx
+			break
+		}
+	}
+	i := pending[n]
+	r.pending[key] = append(pending[:n:n], pending[n+1:]...)
+	r.last[key] = i
+	return i
+}
@@ -0,0 +1,71 @@
+package pingaccesstest
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/pingfederate"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/siteAuthenticators"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	s := NewServer("6.1.3")
+	defer s.Close()
+	hosts := map[string]string{"https://pf.recorded:9031": "https://pingfederate.test"}
+	rec, err := NewRecorder(s.URL, "6.1.3", hosts)
+	require.NoError(t, err)
+
+	exercise := func(endpoint, pf string) (*models.SiteAuthenticatorView, *models.PingFederateMetadataRuntimeView) {
+		conf := config.NewConfig().WithUsername(Username).WithPassword(Password).WithEndpoint(endpoint + Context)
+		v, _, err := version.New(conf).VersionCommand()
+		require.NoError(t, err)
+		assert.Equal(t, "6.1.3", *v.Version)
+
+		svc := siteAuthenticators.New(conf)
+		created, _, err := svc.AddSiteAuthenticatorCommand(&siteAuthenticators.AddSiteAuthenticatorCommandInput{Body: models.SiteAuthenticatorView{
+			Name:          pingaccess.String("basic"),
+			ClassName:     pingaccess.String("com.pingidentity.pa.siteauthenticators.BasicAuthTargetSiteAuthenticator"),
+			Configuration: map[string]interface{}{"username": "cheese", "password": "top_secret"},
+		}})
+		require.NoError(t, err)
+		read, _, err := svc.GetSiteAuthenticatorCommand(&siteAuthenticators.GetSiteAuthenticatorCommandInput{Id: created.Id.String()})
+		require.NoError(t, err)
+
+		runtime, _, err := pingfederate.New(conf).UpdatePingFederateRuntimeCommand(&pingfederate.UpdatePingFederateRuntimeCommandInput{Body: models.PingFederateMetadataRuntimeView{Issuer: pingaccess.String(pf)}})
+		require.NoError(t, err)
+		return read, runtime
+	}
+	recorded, _ := exercise(rec.URL, "https://pf.recorded:9031")
+	rec.Close()
+
+	path := CassettePath(t.TempDir(), "6.1.3")
+	require.NoError(t, rec.Cassette().Save(path))
+	assert.Equal(t, "6.1.3.json", filepath.Base(path))
+	cassette, err := LoadCassette(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 4)
+	for _, i := range cassette.Interactions {
+		assert.NotContains(t, i.RequestBody, "top_secret")
+		assert.NotContains(t, i.RequestBody, "pf.recorded")
+		assert.NotContains(t, i.ResponseBody, s.URL)
+	}
+	assert.True(t, strings.Contains(cassette.Interactions[1].RequestBody, Redacted))
+
+	// the replayed run uses a different PingFederate address, which is matched through its placeholder
+	rep := NewReplayer(cassette, map[string]string{"https://pf.replayed:9031": "https://pingfederate.test"})
+	defer rep.Close()
+	replayed, runtime := exercise(rep.URL, "https://pf.replayed:9031")
+	assert.Equal(t, recorded.Id, replayed.Id)
+	assert.Equal(t, map[string]interface{}{"encryptedValue": Redacted}, replayed.Configuration["password"])
+	assert.Equal(t, "https://pf.replayed:9031", *runtime.Issuer)
+
+	_, _, err = siteAuthenticators.New(config.NewConfig().WithUsername(Username).WithPassword(Password).WithEndpoint(rep.URL + Context)).GetSiteAuthenticatorsCommand(&siteAuthenticators.GetSiteAuthenticatorsCommandInput{})
+	assert.Error(t, err, "requests which were not recorded fail")
+}
@@ -0,0 +1,588 @@
+package pingaccesstest
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/pkcs12"
+)
+
+// keyPair is the key and certificates of a key pair held by the server.
+type keyPair struct {
+	key   crypto.Signer
+	cert  *x509.Certificate
+	chain []*x509.Certificate
+}
+
+// text is a response returned as is rather than encoded as JSON, such as a PEM encoded certificate.
+type text string
+
+// serveCertificates handles the key pair and certificate endpoints which are not plain CRUD, ok is false when the
+// request is handled by the collection.
+func (s *Server) serveCertificates(method, path string, body map[string]interface{}) (int, interface{}, error, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	switch {
+	case parts[0] == "keyPairs" && len(parts) == 1 && method == http.MethodPost:
+		return 0, nil, methodNotAllowed(), true
+	case parts[0] == "keyPairs" && len(parts) == 2 && parts[1] == "generate" && method == http.MethodPost:
+		result, err := s.generateKeyPair(body)
+		return http.StatusOK, result, err, true
+	case parts[0] == "keyPairs" && len(parts) == 2 && parts[1] == "import" && method == http.MethodPost:
+		result, err := s.importKeyPair("", body)
+		return http.StatusOK, result, err, true
+	case parts[0] == "keyPairs" && len(parts) == 2 && parts[1] == "keyAlgorithms" && method == http.MethodGet:
+		return http.StatusOK, keyAlgorithms(), nil, true
+	case parts[0] == "keyPairs" && len(parts) == 2 && method == http.MethodPut:
+		result, err := s.importKeyPair(parts[1], body)
+		return http.StatusOK, result, err, true
+	case parts[0] == "keyPairs" && len(parts) == 3 && parts[2] == "csr":
+		switch method {
+		case http.MethodGet:
+			result, err := s.keyPairCSR(parts[1])
+			return http.StatusOK, result, err, true
+		case http.MethodPost:
+			result, err := s.importCSRResponse(parts[1], body)
+			return http.StatusOK, result, err, true
+		}
+		return 0, nil, methodNotAllowed(), true
+	case parts[0] == "keyPairs" && len(parts) == 3 && parts[2] == "certificate" && method == http.MethodGet:
+		kp, ok := s.keyPairs[parts[1]]
+		if !ok {
+			return 0, nil, notFound(), true
+		}
+		return http.StatusOK, encodeCertificate(kp.cert), nil, true
+	case parts[0] == "certificates" && len(parts) == 1 && method == http.MethodPost:
+		result, err := s.importCertificate("", body)
+		return http.StatusOK, result, err, true
+	case parts[0] == "certificates" && len(parts) == 2 && method == http.MethodPut:
+		result, err := s.importCertificate(parts[1], body)
+		return http.StatusOK, result, err, true
+	case parts[0] == "certificates" && len(parts) == 3 && parts[2] == "file" && method == http.MethodGet:
+		cert, ok := s.certificates[parts[1]]
+		if !ok {
+			return 0, nil, notFound(), true
+		}
+		return http.StatusOK, encodeCertificate(cert), nil, true
+	}
+	return 0, nil, nil, false
+}
+
+func (s *Server) generateKeyPair(body map[string]interface{}) (interface{}, error) {
+	c := s.collections["/keyPairs"]
+	v := validation{}
+	s.validateObject(v, c, "", body)
+	for _, f := range []string{"commonName", "keyAlgorithm", "keySize", "validDays"} {
+		if isEmpty(body[f]) {
+			v.add(f, "%s is required", f)
+		}
+	}
+	if country, _ := body["country"].(string); country != "" && len(country) != 2 {
+		v.add("country", "country must be a two letter country code")
+	}
+	days, _ := number(body["validDays"])
+	if body["validDays"] != nil && days < 1 {
+		v.add("validDays", "validDays must be greater than 0")
+	}
+	size, _ := number(body["keySize"])
+	var key crypto.Signer
+	var err error
+	switch algorithm, _ := body["keyAlgorithm"].(string); algorithm {
+	case "RSA":
+		if size < 1024 || size > 4096 {
+			v.add("keySize", "keySize must be between 1024 and 4096 for RSA")
+			break
+		}
+		key, err = rsa.GenerateKey(rand.Reader, size)
+	case "EC":
+		var curve elliptic.Curve
+		switch size {
+		case 256:
+			curve = elliptic.P256()
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			v.add("keySize", "keySize must be one of 256, 384 or 521 for EC")
+		}
+		if curve != nil {
+			key, err = ecdsa.GenerateKey(curve, rand.Reader)
+		}
+	case "":
+	default:
+		v.add("keyAlgorithm", "keyAlgorithm must be one of RSA, EC")
+	}
+	sans, ipSANs := subjectAlternativeNames(v, body["subjectAlternativeNames"])
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	subject := pkix.Name{CommonName: str(body["commonName"])}
+	for field, target := range map[string]*[]string{
+		"organizationUnit": &subject.OrganizationalUnit,
+		"organization":     &subject.Organization,
+		"city":             &subject.Locality,
+		"state":            &subject.Province,
+		"country":          &subject.Country,
+	} {
+		if value, _ := body[field].(string); value != "" {
+			*target = []string{value}
+		}
+	}
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	now := time.Now().Truncate(time.Second)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    now,
+		NotAfter:     now.AddDate(0, 0, days),
+		DNSNames:     sans,
+		IPAddresses:  ipSANs,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	id := s.newID(c)
+	s.keyPairs[id] = &keyPair{key: key, cert: cert}
+	item := keyPairView(str(body["alias"]), cert, nil)
+	item["hsmProviderId"] = body["hsmProviderId"]
+	item["id"] = idValue(c, id)
+	c.store.put(id, item)
+	return s.present(c, item), nil
+}
+
+// importKeyPair imports a PKCS#12 file as a new key pair, or replaces the key pair with the id.
+func (s *Server) importKeyPair(id string, body map[string]interface{}) (interface{}, error) {
+	c := s.collections["/keyPairs"]
+	if _, ok := c.store.get(id); id != "" && !ok {
+		return nil, notFound()
+	}
+	v := validation{}
+	s.validateObject(v, c, id, body)
+	if isEmpty(body["fileData"]) && id == "" {
+		v.add("fileData", "fileData is required")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	if isEmpty(body["fileData"]) {
+		// only the alias of an existing key pair is being changed
+		item, _ := c.store.get(id)
+		item["alias"] = str(body["alias"])
+		return s.present(c, item), nil
+	}
+	password := ""
+	if p, ok := body["password"].(map[string]interface{}); ok {
+		password, _ = p["value"].(string)
+	}
+	data, err := base64.StdEncoding.DecodeString(str(body["fileData"]))
+	if err != nil {
+		v.add("fileData", "fileData must be a base64 encoded PKCS#12 file")
+		return nil, v.err()
+	}
+	kp, err := decodePKCS12(data, password)
+	if err != nil {
+		v.add("fileData", "Unable to read the PKCS#12 file, check the file and password: %s", err)
+		return nil, v.err()
+	}
+	for _, raw := range stringList(body["chainCertificates"]) {
+		chain, err := parseCertificates(raw)
+		if err != nil {
+			v.add("chainCertificates", "%s", err)
+			return nil, v.err()
+		}
+		kp.chain = append(kp.chain, chain...)
+	}
+
+	if id == "" {
+		id = s.newID(c)
+	}
+	s.keyPairs[id] = kp
+	item := keyPairView(str(body["alias"]), kp.cert, kp.chain)
+	item["hsmProviderId"] = body["hsmProviderId"]
+	item["id"] = idValue(c, id)
+	c.store.put(id, item)
+	return s.present(c, item), nil
+}
+
+// keyPairCSR returns a certificate signing request for the key pair, PingAccess uses the header of the Java keytool.
+func (s *Server) keyPairCSR(id string) (interface{}, error) {
+	kp, ok := s.keyPairs[id]
+	if !ok {
+		return nil, notFound()
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		RawSubject:  kp.cert.RawSubject,
+		DNSNames:    kp.cert.DNSNames,
+		IPAddresses: kp.cert.IPAddresses,
+	}, kp.key)
+	if err != nil {
+		return nil, err
+	}
+	if item, ok := s.collections["/keyPairs"].store.get(id); ok {
+		item["csrPending"] = true
+	}
+	return text(pem.EncodeToMemory(&pem.Block{Type: "NEW CERTIFICATE REQUEST", Bytes: der})), nil
+}
+
+// importCSRResponse replaces the certificate of the key pair with the signed certificate, which must be for the key of
+// the key pair.
+func (s *Server) importCSRResponse(id string, body map[string]interface{}) (interface{}, error) {
+	c := s.collections["/keyPairs"]
+	item, ok := c.store.get(id)
+	if !ok {
+		return nil, notFound()
+	}
+	kp := s.keyPairs[id]
+	v := validation{}
+	s.checkReferences(v, map[string]string{"trustedCertGroupId": "/trustedCertificateGroups", "hsmProviderId": "/hsmProviders"}, body)
+	data, _ := body["fileData"].(string)
+	if data == "" {
+		v.add("fileData", "fileData is required")
+		return nil, v.err()
+	}
+	certs, err := parseCertificates(data)
+	if err != nil {
+		v.add("fileData", "%s", err)
+		return nil, v.err()
+	}
+	if !publicKeyMatches(kp.key, certs[0]) {
+		v.add("fileData", "The certificate does not match the key pair")
+	}
+	chain := certs[1:]
+	for _, raw := range stringList(body["chainCertificates"]) {
+		more, err := parseCertificates(raw)
+		if err != nil {
+			v.add("chainCertificates", "%s", err)
+			continue
+		}
+		chain = append(chain, more...)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	kp.cert, kp.chain = certs[0], chain
+	updated := keyPairView(str(item["alias"]), kp.cert, kp.chain)
+	updated["hsmProviderId"] = item["hsmProviderId"]
+	updated["id"] = item["id"]
+	c.store.put(id, updated)
+	return s.present(c, updated), nil
+}
+
+// importCertificate imports a trusted certificate, or replaces the certificate with the id.
+func (s *Server) importCertificate(id string, body map[string]interface{}) (interface{}, error) {
+	c := s.collections["/certificates"]
+	if _, ok := c.store.get(id); id != "" && !ok {
+		return nil, notFound()
+	}
+	v := validation{}
+	s.validateObject(v, c, id, body)
+	if isEmpty(body["fileData"]) {
+		v.add("fileData", "fileData is required")
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	certs, err := parseCertificates(str(body["fileData"]))
+	if err != nil {
+		v.add("fileData", "%s", err)
+		return nil, v.err()
+	}
+	if id == "" {
+		id = s.newID(c)
+	}
+	s.certificates[id] = certs[0]
+	item := certificateView(str(body["alias"]), certs[0])
+	item["id"] = idValue(c, id)
+	c.store.put(id, item)
+	return s.present(c, item), nil
+}
+
+func decodePKCS12(data []byte, password string) (*keyPair, error) {
+	blocks, err := pkcs12.ToPEM(data, password)
+	if err != nil {
+		return nil, err
+	}
+	kp := &keyPair{}
+	var certs []*x509.Certificate
+	for _, b := range blocks {
+		switch b.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(b.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, cert)
+		case "PRIVATE KEY":
+			// pkcs12.ToPEM labels RSA keys PRIVATE KEY but encodes them as PKCS #1
+			if kp.key, err = x509.ParsePKCS1PrivateKey(b.Bytes); err != nil {
+				return nil, err
+			}
+		case "EC PRIVATE KEY":
+			if kp.key, err = x509.ParseECPrivateKey(b.Bytes); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if kp.key == nil {
+		return nil, fmt.Errorf("no private key found")
+	}
+	for _, cert := range certs {
+		if kp.cert == nil && publicKeyMatches(kp.key, cert) {
+			kp.cert = cert
+			continue
+		}
+		kp.chain = append(kp.chain, cert)
+	}
+	if kp.cert == nil {
+		return nil, fmt.Errorf("no certificate found for the private key")
+	}
+	return kp, nil
+}
+
+func publicKeyMatches(key crypto.Signer, cert *x509.Certificate) bool {
+	a, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return false
+	}
+	b, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	return err == nil && bytes.Equal(a, b)
+}
+
+// parseCertificates parses PEM encoded certificates, or a single DER encoded certificate, which may also be base64
+// encoded.
+func parseCertificates(data string) ([]*x509.Certificate, error) {
+	raw := []byte(strings.TrimSpace(data))
+	if decoded, err := base64.StdEncoding.DecodeString(string(raw)); err == nil {
+		raw = decoded
+	}
+	var certs []*x509.Certificate
+	rest := raw
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse the certificate: %s", err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to parse the certificate: %s", err)
+		}
+		certs = append(certs, cert)
+	}
+	return certs, nil
+}
+
+func encodeCertificate(cert *x509.Certificate) text {
+	return text(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}))
+}
+
+func stringList(v interface{}) []string {
+	var list []string
+	items, _ := v.([]interface{})
+	for _, item := range items {
+		if s, ok := item.(string); ok && s != "" {
+			list = append(list, s)
+		}
+	}
+	return list
+}
+
+func subjectAlternativeNames(v validation, raw interface{}) ([]string, []net.IP) {
+	var dns []string
+	var ips []net.IP
+	items, _ := raw.([]interface{})
+	for i, item := range items {
+		m, _ := item.(map[string]interface{})
+		name, _ := m["name"].(string)
+		value, _ := m["value"].(string)
+		switch name {
+		case "DNSName":
+			dns = append(dns, value)
+		case "IPAddress":
+			ip := net.ParseIP(value)
+			if ip == nil {
+				v.add(fmt.Sprintf("subjectAlternativeNames[%d].value", i), "'%s' is not a valid IP address", value)
+				continue
+			}
+			ips = append(ips, ip)
+		default:
+			v.add(fmt.Sprintf("subjectAlternativeNames[%d].name", i), "'%s' is not a supported subject alternative name type, expected one of: DNSName, IPAddress", name)
+		}
+	}
+	return dns, ips
+}
+
+func keyAlgorithms() map[string]interface{} {
+	return map[string]interface{}{"items": []interface{}{
+		map[string]interface{}{"name": "RSA", "keySizes": []int{1024, 2048, 3072, 4096}, "defaultKeySize": 2048, "signatureAlgorithms": []string{"SHA256withRSA", "SHA384withRSA", "SHA512withRSA"}, "defaultSignatureAlgorithm": "SHA256withRSA"},
+		map[string]interface{}{"name": "EC", "keySizes": []int{256, 384, 521}, "defaultKeySize": 256, "signatureAlgorithms": []string{"SHA256withECDSA", "SHA384withECDSA", "SHA512withECDSA"}, "defaultSignatureAlgorithm": "SHA256withECDSA"},
+	}}
+}
+
+func keyPairView(alias string, cert *x509.Certificate, chain []*x509.Certificate) map[string]interface{} {
+	item := certificateView(alias, cert)
+	item["csrPending"] = false
+	var chainViews []interface{}
+	for i, c := range chain {
+		view := certificateView(fmt.Sprintf("%s-chain-%d", alias, i+1), c)
+		view["id"] = json.Number(fmt.Sprint(i + 1))
+		chainViews = append(chainViews, view)
+	}
+	if len(chainViews) > 0 {
+		item["chainCertificates"] = chainViews
+	}
+	return item
+}
+
+// certificateView describes the certificate in the format used by PingAccess, which follows the Java conventions for
+// the distinguished names and signature algorithm names.
+func certificateView(alias string, cert *x509.Certificate) map[string]interface{} {
+	md5sum := md5.Sum(cert.Raw)
+	sha1sum := sha1.Sum(cert.Raw)
+	status := "Valid"
+	switch now := time.Now(); {
+	case now.After(cert.NotAfter):
+		status = "Expired"
+	case now.Before(cert.NotBefore):
+		status = "Not Yet Valid"
+	}
+	var sans []interface{}
+	for _, name := range cert.DNSNames {
+		sans = append(sans, map[string]interface{}{"name": "DNSName", "value": name})
+	}
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, map[string]interface{}{"name": "IPAddress", "value": ip.String()})
+	}
+	item := map[string]interface{}{
+		"alias":              alias,
+		"expires":            json.Number(fmt.Sprint(cert.NotAfter.UnixMilli())),
+		"validFrom":          json.Number(fmt.Sprint(cert.NotBefore.UnixMilli())),
+		"issuerDn":           distinguishedName(cert.RawIssuer),
+		"subjectDn":          distinguishedName(cert.RawSubject),
+		"subjectCn":          cert.Subject.CommonName,
+		"md5sum":             hex.EncodeToString(md5sum[:]),
+		"sha1sum":            hex.EncodeToString(sha1sum[:]),
+		"serialNumber":       serialNumber(cert.SerialNumber),
+		"signatureAlgorithm": signatureAlgorithm(cert.SignatureAlgorithm),
+		"status":             status,
+	}
+	if len(sans) > 0 {
+		item["subjectAlternativeNames"] = sans
+	}
+	return item
+}
+
+func serialNumber(n *big.Int) string {
+	b := n.Bytes()
+	parts := make([]string, len(b))
+	for i := range b {
+		parts[i] = fmt.Sprintf("%02X", b[i])
+	}
+	return strings.Join(parts, ":")
+}
+
+func signatureAlgorithm(a x509.SignatureAlgorithm) string {
+	switch a {
+	case x509.SHA1WithRSA:
+		return "SHA1withRSA"
+	case x509.SHA256WithRSA:
+		return "SHA256withRSA"
+	case x509.SHA384WithRSA:
+		return "SHA384withRSA"
+	case x509.SHA512WithRSA:
+		return "SHA512withRSA"
+	case x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS:
+		return "RSASSA-PSS"
+	case x509.ECDSAWithSHA1:
+		return "SHA1withECDSA"
+	case x509.ECDSAWithSHA256:
+		return "SHA256withECDSA"
+	case x509.ECDSAWithSHA384:
+		return "SHA384withECDSA"
+	case x509.ECDSAWithSHA512:
+		return "SHA512withECDSA"
+	case x509.PureEd25519:
+		return "Ed25519"
+	}
+	return a.String()
+}
+
+var attributeNames = map[string]string{
+	"2.5.4.3":                    "CN",
+	"2.5.4.5":                    "SERIALNUMBER",
+	"2.5.4.6":                    "C",
+	"2.5.4.7":                    "L",
+	"2.5.4.8":                    "ST",
+	"2.5.4.9":                    "STREET",
+	"2.5.4.10":                   "O",
+	"2.5.4.11":                   "OU",
+	"0.9.2342.19200300.100.1.1":  "UID",
+	"0.9.2342.19200300.100.1.25": "DC",
+	"1.2.840.113549.1.9.1":       "EMAILADDRESS",
+}
+
+// distinguishedName formats the DER encoded name as Java does, with the most specific attribute first and separated by
+// a comma and space.
+func distinguishedName(raw []byte) string {
+	var rdns pkix.RDNSequence
+	if _, err := asn1.Unmarshal(raw, &rdns); err != nil {
+		return ""
+	}
+	var parts []string
+	for i := len(rdns) - 1; i >= 0; i-- {
+		var attrs []string
+		for _, atv := range rdns[i] {
+			name, ok := attributeNames[atv.Type.String()]
+			if !ok {
+				name = "OID." + atv.Type.String()
+			}
+			attrs = append(attrs, name+"="+quoteValue(fmt.Sprint(atv.Value)))
+		}
+		parts = append(parts, strings.Join(attrs, " + "))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func quoteValue(value string) string {
+	if strings.ContainsAny(value, ",+=\"\n<>#;\\") || strings.TrimSpace(value) != value {
+		return `"` + strings.ReplaceAll(value, `"`, `\"`) + `"`
+	}
+	return value
+}
@@ -0,0 +1,496 @@
+package pingaccesstest
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// store holds the objects of a collection by id in the order they were created. IDs are allocated from a counter which
+// is never reset, so the id of a deleted object is not reused as with PingAccess.
+type store struct {
+	next  int
+	ids   []string
+	items map[string]map[string]interface{}
+}
+
+func newStore(next int) *store {
+	return &store{next: next, items: map[string]map[string]interface{}{}}
+}
+
+func (s *store) allocate() int {
+	id := s.next
+	s.next++
+	return id
+}
+
+func (s *store) get(id string) (map[string]interface{}, bool) {
+	item, ok := s.items[id]
+	return item, ok
+}
+
+func (s *store) put(id string, item map[string]interface{}) {
+	if _, ok := s.items[id]; !ok {
+		s.ids = append(s.ids, id)
+	}
+	s.items[id] = item
+}
+
+func (s *store) remove(id string) {
+	delete(s.items, id)
+	for i, v := range s.ids {
+		if v == id {
+			s.ids = append(s.ids[:i], s.ids[i+1:]...)
+			return
+		}
+	}
+}
+
+func (s *store) list() []map[string]interface{} {
+	items := make([]map[string]interface{}, 0, len(s.ids))
+	for _, id := range s.ids {
+		items = append(items, s.items[id])
+	}
+	return items
+}
+
+// collection describes an admin API endpoint holding a list of objects, such as `/sites`.
+type collection struct {
+	// label is used in error messages, e.g. "Site".
+	label string
+	path  string
+	store *store
+	// required fields must be set on create and update.
+	required []string
+	// unique fields must not be shared with another object of the collection, compared case insensitively.
+	unique []string
+	// refs maps fields holding the id, or a list or map of ids, of another object to the path of its collection. An id
+	// of 0 means no reference.
+	refs map[string]string
+	// plugin is the kind of the descriptors for plugin collections, the className and configuration of the objects are
+	// validated against the descriptors.
+	plugin string
+	// uuids allocates random string ids rather than numbers.
+	uuids bool
+	// readOnly collections are created by PingAccess and can only be updated.
+	readOnly bool
+	// defaults returns the values of the fields which are not set by a request.
+	defaults func() map[string]interface{}
+	// validate performs any further validation of a create or update, id is empty on create.
+	validate func(s *Server, v validation, id string, body map[string]interface{})
+	// present adds any fields computed by PingAccess to a copy of an object before it is returned.
+	present func(s *Server, item map[string]interface{})
+	// created and updated are called before an object is stored, to set any fields maintained by PingAccess.
+	created func(s *Server, id string, item map[string]interface{})
+	updated func(s *Server, previous, item map[string]interface{})
+	// deleted is called once an object has been deleted.
+	deleted func(s *Server, id string)
+}
+
+func (s *Server) addCollection(c *collection) *collection {
+	if c.store == nil {
+		c.store = newStore(1)
+	}
+	if c.defaults == nil {
+		c.defaults = func() map[string]interface{} { return map[string]interface{}{} }
+	}
+	s.collections[c.path] = c
+	return c
+}
+
+// insert stores the object with a newly allocated id, bypassing validation. It is used to seed the objects PingAccess
+// creates on startup.
+func (s *Server) insert(c *collection, item map[string]interface{}) string {
+	id := s.newID(c)
+	item = merge(c.defaults(), item)
+	item["id"] = idValue(c, id)
+	c.store.put(id, item)
+	return id
+}
+
+func (s *Server) newID(c *collection) string {
+	if c.uuids {
+		return uuid()
+	}
+	return strconv.Itoa(c.store.allocate())
+}
+
+func idValue(c *collection, id string) interface{} {
+	if c.uuids {
+		return id
+	}
+	return json.Number(id)
+}
+
+func (s *Server) create(c *collection, body map[string]interface{}) (interface{}, error) {
+	v := validation{}
+	s.validateObject(v, c, "", body)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	item := s.normalise(c, body)
+	s.conceal(v, c.plugin, item)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	id := s.newID(c)
+	item["id"] = idValue(c, id)
+	if c.created != nil {
+		c.created(s, id, item)
+	}
+	c.store.put(id, item)
+	return s.present(c, item), nil
+}
+
+func (s *Server) update(c *collection, id string, body map[string]interface{}) (interface{}, error) {
+	previous, ok := c.store.get(id)
+	if !ok {
+		return nil, notFound()
+	}
+	v := validation{}
+	s.validateObject(v, c, id, body)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	item := s.normalise(c, body)
+	s.conceal(v, c.plugin, item)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+	item["id"] = idValue(c, id)
+	if c.updated != nil {
+		c.updated(s, previous, item)
+	}
+	c.store.put(id, item)
+	return s.present(c, item), nil
+}
+
+func (s *Server) delete(c *collection, id string) error {
+	item, ok := c.store.get(id)
+	if !ok {
+		return notFound()
+	}
+	if item["systemGroup"] == true {
+		return &apiError{
+			status: http.StatusUnprocessableEntity,
+			Flash:  []string{fmt.Sprintf("%s with id %s is a system group and cannot be deleted", c.label, id)},
+			Result: "system_group",
+		}
+	}
+	if users := s.usedBy(c.path, id); len(users) > 0 {
+		return &apiError{
+			status: http.StatusUnprocessableEntity,
+			Flash:  []string{fmt.Sprintf("%s with id %s is in use by %s and cannot be deleted", c.label, id, strings.Join(users, ", "))},
+			Result: "in_use",
+		}
+	}
+	c.store.remove(id)
+	if c.deleted != nil {
+		c.deleted(s, id)
+	}
+	return nil
+}
+
+// normalise returns the object to store for the request body, fields not in the body are set from the collection
+// defaults and plugin configuration fields are set from the descriptor defaults.
+func (s *Server) normalise(c *collection, body map[string]interface{}) map[string]interface{} {
+	item := merge(c.defaults(), copyObject(body))
+	delete(item, "id")
+	if c.plugin != "" {
+		s.pluginDefaults(c.plugin, item)
+	}
+	return item
+}
+
+func (s *Server) validateObject(v validation, c *collection, id string, body map[string]interface{}) {
+	for _, f := range c.required {
+		if isEmpty(body[f]) {
+			v.add(f, "%s is required", f)
+		}
+	}
+	for _, f := range c.unique {
+		value, ok := body[f].(string)
+		if !ok || value == "" {
+			continue
+		}
+		for _, other := range c.store.list() {
+			if o, _ := other[f].(string); strings.EqualFold(o, value) && fmt.Sprint(other["id"]) != id {
+				v.add(f, "%s '%s' is already in use", f, value)
+				break
+			}
+		}
+	}
+	s.checkReferences(v, c.refs, body)
+	if c.plugin != "" {
+		s.validatePlugin(v, c.plugin, body)
+	}
+	if c.validate != nil {
+		c.validate(s, v, id, body)
+	}
+}
+
+// checkReferences checks the objects referenced by the body exist.
+func (s *Server) checkReferences(v validation, refs map[string]string, body map[string]interface{}) {
+	for _, field := range sortedKeys(refs) {
+		for _, id := range referencedIDs(body[field]) {
+			s.checkReference(v, field, refs[field], id)
+		}
+	}
+}
+
+func (s *Server) checkReference(v validation, field, path, id string) {
+	target, ok := s.collections[path]
+	if !ok {
+		return
+	}
+	if _, ok := target.store.get(id); !ok {
+		v.add(field, "%s with id %s does not exist", target.label, id)
+	}
+}
+
+// referencedIDs returns the ids held by a reference field, which may be a single id or a list or map of ids.
+func referencedIDs(value interface{}) []string {
+	var ids []string
+	switch t := value.(type) {
+	case []interface{}:
+		for _, item := range t {
+			ids = append(ids, referencedIDs(item)...)
+		}
+	case map[string]interface{}:
+		for _, k := range sortedKeys(t) {
+			ids = append(ids, referencedIDs(t[k])...)
+		}
+	case string:
+		if t != "" && t != "0" {
+			ids = append(ids, t)
+		}
+	default:
+		if n, ok := number(t); ok && n != 0 {
+			ids = append(ids, strconv.Itoa(n))
+		}
+	}
+	return ids
+}
+
+// usedBy describes the objects which reference the object with the id in the collection at path.
+func (s *Server) usedBy(path, id string) []string {
+	var users []string
+	for _, c := range s.sortedCollections() {
+		for _, item := range c.store.list() {
+			for _, r := range s.references(c, item) {
+				if r.path == path && r.id == id {
+					users = append(users, fmt.Sprintf("%s '%v'", c.label, describe(item)))
+					break
+				}
+			}
+		}
+	}
+	for _, name := range sortedKeys(s.singletons) {
+		sg := s.singletons[name]
+		for field, target := range sg.refs {
+			for _, ref := range referencedIDs(sg.value[field]) {
+				if target == path && ref == id {
+					users = append(users, name)
+				}
+			}
+		}
+	}
+	for _, item := range s.resources.list() {
+		for _, r := range policyReferences(item["policy"]) {
+			if r.path == path && r.id == id {
+				users = append(users, fmt.Sprintf("Resource '%v'", describe(item)))
+				break
+			}
+		}
+	}
+	return users
+}
+
+type reference struct {
+	path string
+	id   string
+}
+
+// references returns the objects referenced by an object of the collection.
+func (s *Server) references(c *collection, item map[string]interface{}) []reference {
+	var refs []reference
+	for field, path := range c.refs {
+		for _, id := range referencedIDs(item[field]) {
+			refs = append(refs, reference{path: path, id: id})
+		}
+	}
+	switch c.path {
+	case "/applications":
+		refs = append(refs, policyReferences(item["policy"])...)
+	case "/rulesets":
+		refs = append(refs, rulesetReferences(item)...)
+	}
+	return refs
+}
+
+func describe(item map[string]interface{}) interface{} {
+	for _, f := range []string{"name", "alias", "host"} {
+		if v, ok := item[f]; ok && !isEmpty(v) {
+			return v
+		}
+	}
+	return item["id"]
+}
+
+// list returns the objects of the collection matching the query parameters of the request, PingAccess supports paging,
+// sorting and filtering by name, alias and virtual host.
+func (c *collection) list(r *http.Request) map[string]interface{} {
+	return map[string]interface{}{"items": filterItems(c.store.list(), r, "name", "alias")}
+}
+
+func filterItems(all []map[string]interface{}, r *http.Request, keys ...string) []interface{} {
+	q := r.URL.Query()
+	var items []map[string]interface{}
+	for _, item := range all {
+		if !matchesQuery(item, q.Get("name"), q.Get("alias"), q.Get("filter"), keys) {
+			continue
+		}
+		if vh := q.Get("virtualHost"); vh != "" && !strings.Contains(strings.ToLower(fmt.Sprintf("%v:%v", item["host"], item["port"])), strings.ToLower(vh)) {
+			continue
+		}
+		if id := q.Get("virtualHostId"); id != "" && !containsID(item["virtualHostIds"], id) {
+			continue
+		}
+		items = append(items, item)
+	}
+	if key := q.Get("sortKey"); key != "" {
+		sort.SliceStable(items, func(i, j int) bool {
+			return fmt.Sprint(items[i][key]) < fmt.Sprint(items[j][key])
+		})
+		if strings.EqualFold(q.Get("order"), "DESC") {
+			for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+				items[i], items[j] = items[j], items[i]
+			}
+		}
+	}
+	page, _ := strconv.Atoi(q.Get("page"))
+	perPage, _ := strconv.Atoi(q.Get("numberPerPage"))
+	if page > 0 && perPage > 0 {
+		start := (page - 1) * perPage
+		if start > len(items) {
+			start = len(items)
+		}
+		end := start + perPage
+		if end > len(items) {
+			end = len(items)
+		}
+		items = items[start:end]
+	}
+	result := make([]interface{}, 0, len(items))
+	for _, item := range items {
+		result = append(result, item)
+	}
+	return result
+}
+
+func containsID(ids interface{}, id string) bool {
+	for _, v := range referencedIDs(ids) {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesQuery(item map[string]interface{}, name, alias, filter string, keys []string) bool {
+	if name != "" && !strings.EqualFold(fmt.Sprint(item["name"]), name) {
+		return false
+	}
+	if alias != "" && !strings.EqualFold(fmt.Sprint(item["alias"]), alias) {
+		return false
+	}
+	if filter == "" {
+		return true
+	}
+	for _, k := range keys {
+		if v, ok := item[k].(string); ok && strings.Contains(strings.ToLower(v), strings.ToLower(filter)) {
+			return true
+		}
+	}
+	return false
+}
+
+// present returns a copy of the object as returned by the API.
+func (s *Server) present(c *collection, item map[string]interface{}) map[string]interface{} {
+	out := copyObject(item)
+	if c.present != nil {
+		c.present(s, out)
+	}
+	return out
+}
+
+func (s *Server) presentObject(item map[string]interface{}) map[string]interface{} {
+	return copyObject(item)
+}
+
+// copyObject returns a deep copy of a decoded JSON object.
+func copyObject(in map[string]interface{}) map[string]interface{} {
+	if in == nil {
+		return map[string]interface{}{}
+	}
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = copyValue(v)
+	}
+	return out
+}
+
+func copyValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return copyObject(t)
+	case []interface{}:
+		out := make([]interface{}, len(t))
+		for i := range t {
+			out[i] = copyValue(t[i])
+		}
+		return out
+	}
+	return v
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func uuid() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+// singleton is an admin API endpoint holding a single object, such as `/pingfederate/runtime`. Deleting a singleton
+// resets it to its defaults.
+type singleton struct {
+	path     string
+	value    map[string]interface{}
+	required []string
+	refs     map[string]string
+	defaults func() map[string]interface{}
+	// fixed singletons cannot be reset.
+	fixed bool
+}
+
+func (s *Server) addSingleton(sg *singleton) {
+	if sg.defaults == nil {
+		sg.defaults = func() map[string]interface{} { return map[string]interface{}{} }
+	}
+	sg.value = sg.defaults()
+	s.singletons[sg.path] = sg
+}
@@ -0,0 +1,142 @@
+package pingaccesstest
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// crypter encrypts the values of CONCEALED fields and hidden fields with a key generated for each server. The nonce is
+// derived from the value, so the encryptedValue of a value is stable as with PingAccess 6.1 and above.
+type crypter struct {
+	aead  cipher.AEAD
+	nonce []byte
+}
+
+func newCrypter() *crypter {
+	key := make([]byte, 32)
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(err)
+	}
+	if _, err := rand.Read(nonce); err != nil {
+		panic(err)
+	}
+	block, _ := aes.NewCipher(key)
+	aead, _ := cipher.NewGCM(block)
+	return &crypter{aead: aead, nonce: nonce}
+}
+
+func (c *crypter) encrypt(value string) string {
+	mac := hmac.New(sha256.New, c.nonce)
+	mac.Write([]byte(value))
+	nonce := mac.Sum(nil)[:c.aead.NonceSize()]
+	return base64.RawURLEncoding.EncodeToString(c.aead.Seal(nonce, nonce, []byte(value), nil))
+}
+
+func (c *crypter) decrypt(encryptedValue string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(encryptedValue)
+	if err != nil || len(b) < c.aead.NonceSize() {
+		return "", fmt.Errorf("invalid encryptedValue")
+	}
+	plain, err := c.aead.Open(nil, b[:c.aead.NonceSize()], b[c.aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid encryptedValue")
+	}
+	return string(plain), nil
+}
+
+// conceal replaces the values of the CONCEALED fields in the plugin configuration and of any hidden fields in the
+// object with their encryptedValue. A hidden field is an object holding a value and/or an encryptedValue, a request can
+// send back the encryptedValue returned previously to keep the current value.
+func (s *Server) conceal(v validation, kind string, item map[string]interface{}) {
+	if kind != "" {
+		className, _ := item["className"].(string)
+		if desc, ok := s.pluginDescriptor(kind, className); ok {
+			conf, _ := item["configuration"].(map[string]interface{})
+			for _, path := range concealedFields(desc.ConfigurationFields, "") {
+				s.concealPath(v, "configuration."+path, conf, strings.Split(path, "."))
+			}
+		}
+	}
+	s.concealHidden(v, "", item)
+}
+
+func (s *Server) concealPath(v validation, field string, conf map[string]interface{}, path []string) {
+	if conf == nil {
+		return
+	}
+	if len(path) > 1 {
+		child, _ := conf[path[0]].(map[string]interface{})
+		s.concealPath(v, field, child, path[1:])
+		return
+	}
+	switch value := conf[path[0]].(type) {
+	case string:
+		if value != "" {
+			conf[path[0]] = map[string]interface{}{"encryptedValue": s.crypter.encrypt(value)}
+		}
+	case map[string]interface{}:
+		if hidden, ok := s.hiddenField(v, field, value); ok {
+			conf[path[0]] = hidden
+		}
+	}
+}
+
+func (s *Server) concealHidden(v validation, field string, value interface{}) {
+	switch t := value.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			name := k
+			if field != "" {
+				name = field + "." + k
+			}
+			if m, ok := child.(map[string]interface{}); ok && isHiddenField(m) {
+				if hidden, ok := s.hiddenField(v, name, m); ok {
+					t[k] = hidden
+				}
+				continue
+			}
+			s.concealHidden(v, name, child)
+		}
+	case []interface{}:
+		for i, child := range t {
+			s.concealHidden(v, fmt.Sprintf("%s[%d]", field, i), child)
+		}
+	}
+}
+
+// isHiddenField reports whether the object is a hidden field, only containing a value and encryptedValue.
+func isHiddenField(m map[string]interface{}) bool {
+	if len(m) == 0 {
+		return false
+	}
+	for k := range m {
+		if k != "value" && k != "encryptedValue" {
+			return false
+		}
+	}
+	return true
+}
+
+// hiddenField returns the stored form of a hidden field, the value takes precedence over the encryptedValue which must
+// have been issued by this server.
+func (s *Server) hiddenField(v validation, field string, m map[string]interface{}) (map[string]interface{}, bool) {
+	if value, _ := m["value"].(string); value != "" {
+		return map[string]interface{}{"encryptedValue": s.crypter.encrypt(value)}, true
+	}
+	encrypted, _ := m["encryptedValue"].(string)
+	if encrypted == "" {
+		return nil, false
+	}
+	if _, err := s.crypter.decrypt(encrypted); err != nil {
+		v.add(field, "the encryptedValue is not valid")
+		return nil, false
+	}
+	return map[string]interface{}{"encryptedValue": encrypted}, true
+}
@@ -0,0 +1,310 @@
+package pingaccesstest
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Workflow statuses of the config export and import workflows.
+const (
+	workflowComplete = "Complete"
+	workflowFailed   = "Failed"
+)
+
+// workflow is an export or import of the configuration, the fake runs a workflow to completion when it is started so
+// the status is never In Progress.
+type workflow struct {
+	id       int
+	status   string
+	total    int
+	current  map[string]interface{}
+	err      *apiError
+	warnings []string
+	document map[string]interface{}
+}
+
+func (w *workflow) view() map[string]interface{} {
+	view := map[string]interface{}{
+		"id":            jsonInt(w.id),
+		"status":        w.status,
+		"totalEntities": jsonInt(w.total),
+		"warnings":      w.warnings,
+	}
+	if view["warnings"] == nil {
+		view["warnings"] = []string{}
+	}
+	if w.current != nil {
+		view["currentEntity"] = w.current
+	}
+	if w.err != nil {
+		view["apiErrorView"] = map[string]interface{}{"flash": w.err.Flash, "form": w.err.Form}
+	}
+	return view
+}
+
+// attachment is a file download response.
+type attachment struct {
+	name        string
+	contentType string
+	body        []byte
+}
+
+// location is an empty response with the Location header set to the created object.
+type location string
+
+type workflows struct {
+	next  int
+	items map[int]*workflow
+}
+
+func (w *workflows) add(wf *workflow) *workflow {
+	if w.items == nil {
+		w.items = map[int]*workflow{}
+	}
+	w.next++
+	wf.id = w.next
+	w.items[wf.id] = wf
+	return wf
+}
+
+func (w *workflows) list() map[string]interface{} {
+	var ids []int
+	for id := range w.items {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	items := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		items = append(items, w.items[id].view())
+	}
+	return map[string]interface{}{"items": items}
+}
+
+func (w *workflows) get(id string) (*workflow, error) {
+	i, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, notFound()
+	}
+	wf, ok := w.items[i]
+	if !ok {
+		return nil, notFound()
+	}
+	return wf, nil
+}
+
+// serveConfig handles the configuration export and import endpoints, ok is false when the path is not one of them.
+func (s *Server) serveConfig(method, path string, r *http.Request, body map[string]interface{}) (int, interface{}, error, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/config/"), "/")
+	switch {
+	case len(parts) == 1 && (parts[0] == "export" || parts[0] == "import"):
+		if parts[0] == "export" && method == http.MethodGet {
+			return http.StatusOK, s.exportDocument(), nil, true
+		}
+		if parts[0] == "import" && method == http.MethodPost {
+			wf := s.importDocument(body, true)
+			if wf.err != nil {
+				return 0, nil, wf.err, true
+			}
+			return http.StatusOK, nil, nil, true
+		}
+		return 0, nil, methodNotAllowed(), true
+	case len(parts) < 2 || parts[1] != "workflows":
+		return 0, nil, nil, false
+	}
+
+	wfs := &s.exports
+	if parts[0] == "import" {
+		wfs = &s.imports
+	} else if parts[0] != "export" {
+		return 0, nil, nil, false
+	}
+	switch len(parts) {
+	case 2:
+		switch {
+		case method == http.MethodGet:
+			return http.StatusOK, wfs.list(), nil, true
+		case method == http.MethodPost && parts[0] == "export":
+			doc := s.exportDocument()
+			wf := wfs.add(&workflow{status: workflowComplete, total: entities(doc), document: doc})
+			return http.StatusOK, wf.view(), nil, true
+		case method == http.MethodPost:
+			wf := wfs.add(s.importDocument(body, r.URL.Query().Get("failFast") != "false"))
+			return http.StatusAccepted, location(fmt.Sprintf("%s/config/import/workflows/%d", s.Endpoint(), wf.id)), nil, true
+		}
+	case 3:
+		if method != http.MethodGet {
+			return 0, nil, methodNotAllowed(), true
+		}
+		wf, err := wfs.get(parts[2])
+		if err != nil {
+			return 0, nil, err, true
+		}
+		return http.StatusOK, wf.view(), nil, true
+	case 4:
+		if parts[0] != "export" || parts[3] != "data" {
+			return 0, nil, nil, false
+		}
+		if method != http.MethodGet {
+			return 0, nil, methodNotAllowed(), true
+		}
+		wf, err := wfs.get(parts[2])
+		if err != nil {
+			return 0, nil, err, true
+		}
+		return http.StatusOK, wf.document, nil, true
+	}
+	return 0, nil, methodNotAllowed(), true
+}
+
+// exportDocument returns the configuration of the server in the shape of a PingAccess export. The data holds the
+// objects of each collection keyed by the collection path, the master keys are the key of the crypter so concealed
+// values in the document can be decrypted by the server they were exported from.
+func (s *Server) exportDocument() map[string]interface{} {
+	data := map[string]interface{}{}
+	for _, c := range s.sortedCollections() {
+		if c.readOnly {
+			continue
+		}
+		data[strings.TrimPrefix(c.path, "/")] = c.store.list()
+	}
+	// the document is a copy so later changes to the configuration are not reflected in it
+	b, _ := json.Marshal(data)
+	data = map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	_ = dec.Decode(&data)
+	sum := sha256.Sum256(s.crypter.nonce)
+	keyID := base64.RawURLEncoding.EncodeToString(sum[:8])
+	return map[string]interface{}{
+		"version": s.version,
+		"data":    data,
+		"encryptionKey": map[string]interface{}{
+			"keyId":   keyID,
+			"keyType": "oct",
+			"use":     "enc",
+		},
+		"masterKeys": map[string]interface{}{
+			"keyId":          keyID,
+			"encryptedValue": base64.StdEncoding.EncodeToString([]byte(s.crypter.encrypt(keyID))),
+		},
+	}
+}
+
+func entities(doc map[string]interface{}) int {
+	total := 0
+	data, _ := doc["data"].(map[string]interface{})
+	for _, items := range data {
+		list, _ := items.([]interface{})
+		total += len(list)
+	}
+	return total
+}
+
+// importDocument replaces the objects of each collection in the document. The document must be from the same version
+// of PingAccess, an unknown collection or an object without an id fails the entity, when failFast is false the failure
+// is reported as a warning and the remaining entities are imported.
+func (s *Server) importDocument(doc map[string]interface{}, failFast bool) *workflow {
+	wf := &workflow{status: workflowComplete, total: entities(doc)}
+	fail := func(current map[string]interface{}, err *apiError) bool {
+		if failFast {
+			wf.status = workflowFailed
+			wf.current = current
+			wf.err = err
+			return true
+		}
+		entity := str(current["type"])
+		if name := str(current["name"]); name != "" {
+			entity = fmt.Sprintf("%s '%s'", entity, name)
+		}
+		wf.warnings = append(wf.warnings, fmt.Sprintf("%s: %s", entity, err))
+		return false
+	}
+
+	data, ok := doc["data"].(map[string]interface{})
+	if !ok {
+		wf.status = workflowFailed
+		wf.err = badRequest("The import document does not contain any data")
+		return wf
+	}
+	if v := str(doc["version"]); v != s.version {
+		wf.status = workflowFailed
+		wf.err = badRequest("The import document version '%s' does not match the PingAccess version '%s'", v, s.version)
+		return wf
+	}
+
+	var keys []string
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	imported := map[*collection][]map[string]interface{}{}
+	for _, k := range keys {
+		c, ok := s.collections["/"+k]
+		if !ok || c.readOnly {
+			if fail(map[string]interface{}{"type": k}, badRequest("Unknown entity type '%s'", k)) {
+				return wf
+			}
+			continue
+		}
+		list, _ := data[k].([]interface{})
+		items := []map[string]interface{}{}
+		for _, v := range list {
+			item, _ := v.(map[string]interface{})
+			if str(item["id"]) == "" {
+				err := &apiError{status: http.StatusUnprocessableEntity, Flash: []string{"Import Failed"}, Form: map[string][]string{"id": {"id is required"}}}
+				if fail(map[string]interface{}{"type": c.label, "name": item["name"]}, err) {
+					return wf
+				}
+				continue
+			}
+			items = append(items, item)
+		}
+		imported[c] = items
+	}
+
+	for c, items := range imported {
+		for _, item := range c.store.list() {
+			c.store.remove(str(item["id"]))
+		}
+		for _, item := range items {
+			id := str(item["id"])
+			item["id"] = idValue(c, id)
+			c.store.put(id, item)
+			if n, err := strconv.Atoi(id); err == nil && n >= c.store.next {
+				c.store.next = n + 1
+			}
+		}
+	}
+	return wf
+}
+
+// backup returns a zip archive holding the export document of the server, in place of the database backup PingAccess
+// returns.
+func (s *Server) backup() (int, interface{}, error) {
+	doc, err := json.MarshalIndent(s.exportDocument(), "", "  ")
+	if err != nil {
+		return 0, nil, err
+	}
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	f, err := zw.Create("data.json")
+	if err != nil {
+		return 0, nil, err
+	}
+	if _, err := f.Write(doc); err != nil {
+		return 0, nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return 0, nil, err
+	}
+	s.backups++
+	return http.StatusOK, &attachment{name: fmt.Sprintf("pa-data-%d.zip", s.backups), contentType: "application/zip", body: buf.Bytes()}, nil
+}
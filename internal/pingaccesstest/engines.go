@@ -0,0 +1,47 @@
+package pingaccesstest
+
+import (
+	"crypto/sha1" // #nosec G505
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// nodeCreated sets the hash of the certificate an engine, agent or replica admin uses to authenticate. PingAccess
+// generates the key pair when the configuration file is downloaded, the fake derives a stable hash from the name.
+func (s *Server) nodeCreated(item map[string]interface{}) {
+	sum := sha1.Sum([]byte(str(item["name"]))) // #nosec G401
+	item["certificateHash"] = map[string]interface{}{"algorithm": "SHA1", "hexValue": hex.EncodeToString(sum[:])}
+}
+
+// PollEngine records that the engine with the given id has polled the administrative node for the latest
+// configuration, engines are only included in the engine status once they have polled.
+func (s *Server) PollEngine(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.engineStatus == nil {
+		s.engineStatus = map[string]time.Time{}
+	}
+	s.engineStatus[id] = time.Now()
+}
+
+// engineHealthStatus returns when each engine last polled for the configuration, with times in milliseconds since the epoch.
+func (s *Server) engineHealthStatus() (int, interface{}, error) {
+	status := map[string]interface{}{}
+	for id, polled := range s.engineStatus {
+		engine, ok := s.collections["/engines"].store.get(id)
+		if !ok {
+			continue
+		}
+		status[id] = map[string]interface{}{
+			"name":         engine["name"],
+			"description":  engine["description"],
+			"lastUpdated":  jsonInt(int(polled.UnixMilli())),
+			"pollingDelay": jsonInt(0),
+		}
+	}
+	return http.StatusOK, map[string]interface{}{
+		"currentServerTime": jsonInt(int(time.Now().UnixMilli())),
+		"enginesStatus":     status,
+	}, nil
+}
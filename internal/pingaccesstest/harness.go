@@ -0,0 +1,114 @@
+package pingaccesstest
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/version"
+)
+
+const (
+	// ModeEnv selects the PingAccess the acceptance tests run against:
+	//
+	//	live   - the PingAccess at PINGACCESS_BASEURL, https://localhost:9000 by default
+	//	fake   - the in-memory fake
+	//	record - a live PingAccess, recording the traffic into a cassette for its version
+	//	replay - the cassette for the version in VersionEnv
+	//
+	// When not set the tests run against a live PingAccess if one is reachable and the fake otherwise.
+	ModeEnv = "PINGACCESS_TEST_MODE"
+	// VersionEnv is the version of the cassette replayed.
+	VersionEnv = "PINGACCESS_TEST_VERSION"
+	// DefaultBaseURL is the address of the live PingAccess started by the Makefile.
+	DefaultBaseURL = "https://localhost:9000"
+)
+
+// Target is the PingAccess admin API the acceptance tests run against.
+type Target struct {
+	// BaseURL is the scheme, host and port of the admin API.
+	BaseURL string
+	// Version is the version of PingAccess reported by the admin API.
+	Version string
+	// Mode is the mode the target was set up for.
+	Mode  string
+	close func() error
+}
+
+// Endpoint returns the url of the admin API.
+func (t *Target) Endpoint() string {
+	return t.BaseURL + Context
+}
+
+// Config returns the SDK configuration for the admin API.
+func (t *Target) Config() *config.Config {
+	return config.NewConfig().WithUsername(Username).WithPassword(Password).WithEndpoint(t.Endpoint())
+}
+
+// Close stops any server started for the target, writing the cassette when recording.
+func (t *Target) Close() error {
+	if t.close == nil {
+		return nil
+	}
+	return t.close()
+}
+
+// Setup returns the PingAccess the acceptance tests should run against for the ModeEnv. Cassettes are read from and
+// written to dir, the hosts are the addresses of servers started by the tests which are sent to PingAccess, replaced by
+// their placeholders in the cassettes.
+func Setup(dir string, hosts map[string]string) (*Target, error) {
+	baseURL := strings.TrimSuffix(os.Getenv("PINGACCESS_BASEURL"), "/")
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	live := &Target{BaseURL: baseURL, Mode: "live"}
+	mode := os.Getenv(ModeEnv)
+	switch mode {
+	case "", "live", "record":
+		v, _, err := version.New(live.Config()).VersionCommand()
+		if err != nil {
+			if mode == "" {
+				return fake(), nil
+			}
+			return nil, fmt.Errorf("unable to connect to PingAccess at %s: %w", baseURL, err)
+		}
+		live.Version = *v.Version
+		if mode != "record" {
+			return live, nil
+		}
+		rec, err := NewRecorder(baseURL, live.Version, hosts)
+		if err != nil {
+			return nil, err
+		}
+		return &Target{BaseURL: rec.URL, Version: live.Version, Mode: mode, close: func() error {
+			rec.Close()
+			return rec.Cassette().Save(CassettePath(dir, live.Version))
+		}}, nil
+	case "fake":
+		return fake(), nil
+	case "replay":
+		v := os.Getenv(VersionEnv)
+		if v == "" {
+			return nil, fmt.Errorf("%s must be set to the version of the cassette to replay", VersionEnv)
+		}
+		cassette, err := LoadCassette(CassettePath(dir, v))
+		if err != nil {
+			return nil, err
+		}
+		rep := NewReplayer(cassette, hosts)
+		return &Target{BaseURL: rep.URL, Version: cassette.Version, Mode: mode, close: func() error {
+			rep.Close()
+			return nil
+		}}, nil
+	}
+	return nil, fmt.Errorf("unknown %s %q, expected one of: live, fake, record, replay", ModeEnv, mode)
+}
+
+func fake() *Target {
+	s := NewServer(DefaultVersion)
+	return &Target{BaseURL: s.URL, Version: DefaultVersion, Mode: "fake", close: func() error {
+		s.Close()
+		return nil
+	}}
+}
@@ -0,0 +1,207 @@
+package pingaccesstest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/descriptors"
+)
+
+// descriptorKinds are the kinds of plugin descriptors served by the fake, named as in the descriptor cache.
+var descriptorKinds = []string{
+	"access_token_validators",
+	"availability_profiles",
+	"hsm_providers",
+	"identity_mappings",
+	"load_balancing_strategies",
+	"rejection_handlers",
+	"rules",
+	"site_authenticators",
+}
+
+// loadDescriptors returns the plugin descriptors served by the fake, which are those bundled with the provider for
+// PingAccess 6.2 whatever the version of the fake.
+func loadDescriptors() map[string]json.RawMessage {
+	m := map[string]json.RawMessage{}
+	for _, kind := range descriptorKinds {
+		b, ok := descriptors.Bundled("6.2.0", kind)
+		if !ok {
+			panic("no bundled " + kind + " descriptors for PingAccess 6.2.0")
+		}
+		m[kind] = b
+	}
+	return m
+}
+
+func (s *Server) pluginDescriptors(kind string) *models.DescriptorsView {
+	var desc models.DescriptorsView
+	_ = json.Unmarshal(s.descriptors[kind], &desc)
+	return &desc
+}
+
+// pluginDescriptor returns the descriptor of the class name.
+func (s *Server) pluginDescriptor(kind, className string) (*models.DescriptorView, bool) {
+	for _, d := range s.pluginDescriptors(kind).Items {
+		if d != nil && d.ClassName != nil && *d.ClassName == className {
+			return d, true
+		}
+	}
+	return nil, false
+}
+
+// serveDescriptors returns all of the descriptors of the kind or the descriptor of the given type, which can be given
+// as the class name, the simple name of the class or the type of the plugin.
+func (s *Server) serveDescriptors(method, kind, name string) (int, interface{}, error) {
+	if method != http.MethodGet {
+		return 0, nil, methodNotAllowed()
+	}
+	var desc struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	_ = json.Unmarshal(s.descriptors[kind], &desc)
+	if name == "" {
+		return http.StatusOK, desc, nil
+	}
+	for _, d := range desc.Items {
+		className, _ := d["className"].(string)
+		simple := className[strings.LastIndex(className, ".")+1:]
+		if name == className || name == simple || name == d["type"] {
+			return http.StatusOK, d, nil
+		}
+	}
+	return 0, nil, notFound()
+}
+
+// ruleModes returns the modes of the rule descriptor sorted by name, these are the supported destinations of the rule.
+func (s *Server) ruleModes(className string) []interface{} {
+	var desc models.RuleDescriptorsView
+	_ = json.Unmarshal(s.descriptors["rules"], &desc)
+	var modes []string
+	for _, d := range desc.Items {
+		if d == nil || d.ClassName == nil || *d.ClassName != className {
+			continue
+		}
+		for _, m := range d.Modes {
+			if m != nil {
+				modes = append(modes, *m)
+			}
+		}
+	}
+	sort.Strings(modes)
+	destinations := make([]interface{}, 0, len(modes))
+	for _, m := range modes {
+		destinations = append(destinations, m)
+	}
+	return destinations
+}
+
+// validatePlugin checks the className is a known plugin and the configuration satisfies the descriptor.
+func (s *Server) validatePlugin(v validation, kind string, body map[string]interface{}) {
+	className, _ := body["className"].(string)
+	if className == "" {
+		v.add("className", "className is required")
+		return
+	}
+	desc, ok := s.pluginDescriptor(kind, className)
+	if !ok {
+		var known []string
+		for _, d := range s.pluginDescriptors(kind).Items {
+			known = append(known, *d.ClassName)
+		}
+		v.add("className", "'%s' is not a valid className, expected one of: %s", className, strings.Join(known, ", "))
+		return
+	}
+	conf, ok := body["configuration"].(map[string]interface{})
+	if !ok {
+		if body["configuration"] != nil {
+			v.add("configuration", "configuration must be an object")
+			return
+		}
+		conf = map[string]interface{}{}
+	}
+	b, _ := json.Marshal(conf)
+	violations, err := descriptors.ValidateConfiguration(className, desc.ConfigurationFields, string(b))
+	if err != nil {
+		v.add("configuration", "%s", err)
+		return
+	}
+	for _, violation := range violations {
+		v.add(violation.String(), "%s", violation.Message)
+	}
+}
+
+// pluginDefaults sets the configuration fields which are not set to the descriptor defaults, as PingAccess returns
+// every field of the plugin configuration. Rules also have their supported destinations set from the modes of the
+// descriptor.
+func (s *Server) pluginDefaults(kind string, item map[string]interface{}) {
+	className, _ := item["className"].(string)
+	desc, ok := s.pluginDescriptor(kind, className)
+	if !ok {
+		return
+	}
+	conf, _ := item["configuration"].(map[string]interface{})
+	if conf == nil {
+		conf = map[string]interface{}{}
+	}
+	fieldDefaults(desc.ConfigurationFields, conf)
+	item["configuration"] = conf
+	if kind == "rules" {
+		item["supportedDestinations"] = s.ruleModes(className)
+	}
+}
+
+func fieldDefaults(fields []*models.ConfigurationField, conf map[string]interface{}) {
+	for _, f := range fields {
+		if f == nil || f.Name == nil || f.Type == nil {
+			continue
+		}
+		value, ok := conf[*f.Name]
+		if *f.Type == "COMPOSITE" {
+			c, _ := value.(map[string]interface{})
+			if c == nil {
+				c = map[string]interface{}{}
+			}
+			fieldDefaults(f.Fields, c)
+			conf[*f.Name] = c
+			continue
+		}
+		if ok && value != nil {
+			continue
+		}
+		conf[*f.Name] = fieldDefault(f)
+	}
+}
+
+func fieldDefault(f *models.ConfigurationField) interface{} {
+	switch *f.Type {
+	case "CHECKBOX":
+		return f.Default != nil && *f.Default == "true"
+	case "LIST", "TABLE":
+		return []interface{}{}
+	}
+	if f.Default != nil {
+		return *f.Default
+	}
+	return nil
+}
+
+// concealedFields returns the names of the CONCEALED fields of the plugin at the top level of its configuration and
+// within COMPOSITE fields, separated by a dot.
+func concealedFields(fields []*models.ConfigurationField, prefix string) []string {
+	var names []string
+	for _, f := range fields {
+		if f == nil || f.Name == nil || f.Type == nil {
+			continue
+		}
+		switch *f.Type {
+		case "CONCEALED":
+			names = append(names, prefix+*f.Name)
+		case "COMPOSITE":
+			names = append(names, concealedFields(f.Fields, prefix+*f.Name+".")...)
+		}
+	}
+	return names
+}
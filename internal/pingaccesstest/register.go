@@ -0,0 +1,506 @@
+package pingaccesstest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// register adds the collections and singletons of the admin API.
+func (s *Server) register() {
+	named := []string{"name"}
+	tcg := map[string]string{"trustedCertificateGroupId": "/trustedCertificateGroups"}
+	upstream := map[string]string{
+		"availabilityProfileId":     "/highAvailability/availabilityProfiles",
+		"loadBalancingStrategyId":   "/highAvailability/loadBalancingStrategies",
+		"trustedCertificateGroupId": "/trustedCertificateGroups",
+	}
+
+	s.addCollection(&collection{label: "Access Token Validator", path: "/accessTokenValidators", required: named, unique: named, plugin: "access_token_validators"})
+	s.addCollection(&collection{label: "Availability Profile", path: "/highAvailability/availabilityProfiles", required: named, unique: named, plugin: "availability_profiles"})
+	s.addCollection(&collection{label: "Load Balancing Strategy", path: "/highAvailability/loadBalancingStrategies", required: named, unique: named, plugin: "load_balancing_strategies"})
+	s.addCollection(&collection{label: "HSM Provider", path: "/hsmProviders", required: named, unique: named, plugin: "hsm_providers"})
+	s.addCollection(&collection{label: "Identity Mapping", path: "/identityMappings", required: named, unique: named, plugin: "identity_mappings"})
+	s.addCollection(&collection{label: "Rejection Handler", path: "/rejectionHandlers", required: named, unique: named, plugin: "rejection_handlers"})
+	s.addCollection(&collection{label: "Rule", path: "/rules", required: named, unique: named, plugin: "rules"})
+	s.addCollection(&collection{label: "Site Authenticator", path: "/siteAuthenticators", required: named, unique: named, plugin: "site_authenticators"})
+	s.addCollection(&collection{
+		label:    "Ruleset",
+		path:     "/rulesets",
+		required: []string{"name", "policy"},
+		unique:   named,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{"elementType": "Rule", "successCriteria": "SuccessIfAllSucceed"}
+		},
+		validate: func(s *Server, v validation, id string, body map[string]interface{}) { s.validateRuleset(v, id, body) },
+	})
+	s.addCollection(&collection{
+		label:    "Authentication Requirement List",
+		path:     "/authnReqLists",
+		required: []string{"name", "authnReqs"},
+		unique:   named,
+	})
+	s.addCollection(&collection{
+		label:    "Certificate",
+		path:     "/certificates",
+		required: []string{"alias"},
+		unique:   []string{"alias"},
+		deleted:  func(s *Server, id string) { delete(s.certificates, id) },
+	})
+	s.addCollection(&collection{
+		label:    "Trusted Certificate Group",
+		path:     "/trustedCertificateGroups",
+		required: named,
+		unique:   named,
+		refs:     map[string]string{"certIds": "/certificates"},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"certIds":                    []interface{}{},
+				"ignoreAllCertificateErrors": false,
+				"skipCertificateDateCheck":   false,
+				"systemGroup":                false,
+				"useJavaTrustStore":          false,
+				"revocationChecking": map[string]interface{}{
+					"crlChecking":                     false,
+					"denyRevocationStatusUnknown":     false,
+					"denyWhenRevocationCheckingFails": false,
+					"ocsp":                            false,
+				},
+			}
+		},
+	})
+	s.addCollection(&collection{
+		label:    "Key Pair",
+		path:     "/keyPairs",
+		required: []string{"alias"},
+		unique:   []string{"alias"},
+		refs:     map[string]string{"hsmProviderId": "/hsmProviders"},
+		deleted:  func(s *Server, id string) { delete(s.keyPairs, id) },
+	})
+	s.addCollection(&collection{
+		label:    "Engine Listener",
+		path:     "/engineListeners",
+		required: []string{"name", "port"},
+		unique:   []string{"name", "port"},
+		refs:     tcg,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{"secure": true, "trustedCertificateGroupId": jsonInt(0)}
+		},
+	})
+	s.addCollection(&collection{
+		label:    "HTTPS Listener",
+		path:     "/httpsListeners",
+		required: []string{"name", "keyPairId"},
+		refs:     map[string]string{"keyPairId": "/keyPairs"},
+		readOnly: true,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{"restartRequired": false, "useServerCipherSuiteOrder": false}
+		},
+	})
+	s.addCollection(&collection{
+		label:    "Virtual Host",
+		path:     "/virtualhosts",
+		required: []string{"host", "port"},
+		refs:     map[string]string{"keyPairId": "/keyPairs", "trustedCertificateGroupId": "/trustedCertificateGroups"},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{"agentResourceCacheTTL": jsonInt(900), "keyPairId": jsonInt(0), "trustedCertificateGroupId": jsonInt(0)}
+		},
+		validate: func(s *Server, v validation, id string, body map[string]interface{}) {
+			for _, other := range s.collections["/virtualhosts"].store.list() {
+				if str(other["id"]) != id && strings.EqualFold(str(other["host"]), str(body["host"])) && str(other["port"]) == str(body["port"]) {
+					v.add("host", "The virtual host %s:%s already exists", str(body["host"]), str(body["port"]))
+				}
+			}
+		},
+	})
+	s.addCollection(&collection{
+		label:    "Site",
+		path:     "/sites",
+		required: []string{"name", "targets"},
+		unique:   named,
+		refs: map[string]string{
+			"availabilityProfileId":     "/highAvailability/availabilityProfiles",
+			"loadBalancingStrategyId":   "/highAvailability/loadBalancingStrategies",
+			"siteAuthenticatorIds":      "/siteAuthenticators",
+			"trustedCertificateGroupId": "/trustedCertificateGroups",
+		},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"availabilityProfileId":     jsonInt(1),
+				"expectedHostname":          nil,
+				"keepAliveTimeout":          jsonInt(0),
+				"loadBalancingStrategyId":   jsonInt(0),
+				"maxConnections":            jsonInt(-1),
+				"maxWebSocketConnections":   jsonInt(-1),
+				"secure":                    false,
+				"sendPaCookie":              true,
+				"siteAuthenticatorIds":      []interface{}{},
+				"skipHostnameVerification":  false,
+				"trustedCertificateGroupId": jsonInt(0),
+				"useProxy":                  false,
+				"useTargetHostHeader":       true,
+			}
+		},
+	})
+	s.addCollection(&collection{
+		label:    "Third Party Service",
+		path:     "/thirdPartyServices",
+		required: []string{"name", "targets"},
+		unique:   named,
+		refs:     upstream,
+		uuids:    true,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"availabilityProfileId":     jsonInt(1),
+				"expectedHostname":          nil,
+				"hostValue":                 nil,
+				"loadBalancingStrategyId":   jsonInt(0),
+				"maxConnections":            jsonInt(-1),
+				"secure":                    false,
+				"skipHostnameVerification":  false,
+				"trustedCertificateGroupId": jsonInt(0),
+				"useProxy":                  false,
+			}
+		},
+	})
+	s.addCollection(&collection{
+		label:    "Web Session",
+		path:     "/webSessions",
+		required: []string{"name", "audience", "clientCredentials"},
+		unique:   named,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"cacheUserAttributes": false,
+				"cookieDomain":        nil,
+				"cookieType":          "Encrypted",
+				"enableRefreshUser":   true,
+				"failOnUnsupportedPreservationContentType": false,
+				"httpOnlyCookie":                true,
+				"idleTimeoutInMinutes":          jsonInt(60),
+				"oidcLoginType":                 "Code",
+				"pfsessionStateCacheInSeconds":  jsonInt(60),
+				"pkceChallengeType":             "OFF",
+				"refreshUserInfoClaimsInterval": jsonInt(60),
+				"requestPreservationType":       "POST",
+				"requestProfile":                true,
+				"sameSite":                      "None",
+				"scopes":                        []interface{}{},
+				"secureCookie":                  true,
+				"sendRequestedUrlToProvider":    true,
+				"sessionTimeoutInMinutes":       jsonInt(240),
+				"validateSessionIsAlive":        false,
+				"webStorageType":                "SessionStorage",
+			}
+		},
+	})
+	s.addCollection(&collection{
+		label:    "Engine",
+		path:     "/engines",
+		required: named,
+		unique:   named,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"configReplicationEnabled": true,
+				"description":              nil,
+				"httpProxyId":              jsonInt(0),
+				"httpsProxyId":             jsonInt(0),
+				"keys":                     []interface{}{},
+				"selectedCertificateId":    jsonInt(0),
+			}
+		},
+		created: func(s *Server, id string, item map[string]interface{}) { s.nodeCreated(item) },
+		updated: func(s *Server, previous, item map[string]interface{}) {
+			item["certificateHash"] = previous["certificateHash"]
+		},
+		deleted: func(s *Server, id string) { delete(s.engineStatus, id) },
+	})
+	s.addCollection(&collection{
+		label:    "Agent",
+		path:     "/agents",
+		required: []string{"name", "hostname", "port"},
+		unique:   named,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"description":           nil,
+				"failedRetryTimeout":    jsonInt(60),
+				"failoverHosts":         []interface{}{},
+				"maxRetries":            jsonInt(2),
+				"overrideIpSource":      false,
+				"selectedCertificateId": jsonInt(0),
+				"unknownResourceMode":   "Deny",
+			}
+		},
+		created: func(s *Server, id string, item map[string]interface{}) { s.nodeCreated(item) },
+		updated: func(s *Server, previous, item map[string]interface{}) {
+			item["certificateHash"] = previous["certificateHash"]
+		},
+	})
+	s.addCollection(&collection{
+		label:    "Replica Admin",
+		path:     "/adminConfig/replicaAdmins",
+		required: []string{"name", "hostPort"},
+		unique:   named,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"configReplicationEnabled": true,
+				"description":              nil,
+				"httpProxyId":              jsonInt(0),
+				"httpsProxyId":             jsonInt(0),
+				"keys":                     []interface{}{},
+				"selectedCertificateId":    jsonInt(0),
+			}
+		},
+		created: func(s *Server, id string, item map[string]interface{}) { s.nodeCreated(item) },
+		updated: func(s *Server, previous, item map[string]interface{}) {
+			item["certificateHash"] = previous["certificateHash"]
+		},
+	})
+	s.addCollection(&collection{
+		label:    "Application",
+		path:     "/applications",
+		required: []string{"name", "contextRoot", "virtualHostIds"},
+		unique:   named,
+		refs: map[string]string{
+			"accessValidatorId":  "/accessTokenValidators",
+			"identityMappingIds": "/identityMappings",
+			"siteId":             "/sites",
+			"virtualHostIds":     "/virtualhosts",
+			"webSessionId":       "/webSessions",
+		},
+		defaults: applicationDefaults,
+		validate: func(s *Server, v validation, id string, body map[string]interface{}) {
+			s.validateApplication(v, id, body)
+		},
+		present: func(s *Server, item map[string]interface{}) { s.presentApplication(item) },
+		created: func(s *Server, id string, item map[string]interface{}) { s.applicationCreated(id, item) },
+		updated: func(s *Server, previous, item map[string]interface{}) { s.applicationUpdated(previous, item) },
+		deleted: func(s *Server, id string) { s.applicationDeleted(id) },
+	})
+	s.addCollection(&collection{
+		label:    "ACME Server",
+		path:     "/acme/servers",
+		required: []string{"name", "url"},
+		uuids:    true,
+		present: func(s *Server, item map[string]interface{}) {
+			if item["acmeAccounts"] == nil {
+				item["acmeAccounts"] = []interface{}{}
+			}
+		},
+	})
+
+	s.addSingleton(&singleton{
+		path: "/authTokenManagement",
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{"issuer": "PingAccessAuthToken", "keyRollEnabled": true, "keyRollPeriodInHours": jsonInt(24), "signingAlgorithm": "P-256"}
+		},
+	})
+	s.addSingleton(&singleton{
+		path:     "/oauth/authServer",
+		required: []string{"introspectionEndpoint", "subjectAttributeName", "targets"},
+		refs:     tcg,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"auditLevel":                "ON",
+				"cacheTokens":               false,
+				"clientCredentials":         map[string]interface{}{"clientId": "", "credentialsType": "SECRET"},
+				"description":               nil,
+				"introspectionEndpoint":     "",
+				"secure":                    false,
+				"sendAudience":              false,
+				"subjectAttributeName":      "",
+				"targets":                   []interface{}{},
+				"tokenTimeToLiveSeconds":    jsonInt(-1),
+				"trustedCertificateGroupId": jsonInt(0),
+				"useProxy":                  false,
+			}
+		},
+	})
+	s.addSingleton(&singleton{
+		path:     "/pingfederate",
+		required: []string{"host", "port"},
+		refs:     upstream,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"auditLevel":                "ON",
+				"availabilityProfileId":     jsonInt(0),
+				"backChannelSecure":         false,
+				"basePath":                  "",
+				"expectedHostname":          nil,
+				"host":                      "",
+				"loadBalancingStrategyId":   jsonInt(0),
+				"port":                      jsonInt(0),
+				"secure":                    false,
+				"skipHostnameVerification":  false,
+				"targets":                   []interface{}{},
+				"trustedCertificateGroupId": jsonInt(0),
+				"useProxy":                  false,
+				"useSlo":                    false,
+			}
+		},
+	})
+	s.addSingleton(&singleton{
+		path:     "/pingfederate/runtime",
+		required: []string{"issuer"},
+		refs:     tcg,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"description":               nil,
+				"issuer":                    "",
+				"skipHostnameVerification":  false,
+				"stsTokenExchangeEndpoint":  nil,
+				"trustedCertificateGroupId": jsonInt(0),
+				"useProxy":                  false,
+				"useSlo":                    false,
+			}
+		},
+	})
+	s.addSingleton(&singleton{
+		path:     "/pingfederate/admin",
+		required: []string{"adminUsername", "host", "port"},
+		refs:     tcg,
+		fixed:    true,
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"adminUsername":             "",
+				"auditLevel":                "ON",
+				"basePath":                  "",
+				"host":                      "",
+				"port":                      jsonInt(0),
+				"secure":                    false,
+				"trustedCertificateGroupId": jsonInt(0),
+				"useProxy":                  false,
+			}
+		},
+	})
+	s.addSingleton(&singleton{
+		path:     "/pingfederate/accessTokens",
+		required: []string{"subjectAttributeName"},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"accessValidatorId":      jsonInt(0),
+				"cacheTokens":            false,
+				"clientCredentials":      map[string]interface{}{"clientId": "", "credentialsType": "SECRET"},
+				"sendAudience":           false,
+				"subjectAttributeName":   "",
+				"tokenTimeToLiveSeconds": jsonInt(-1),
+				"useTokenIntrospection":  false,
+			}
+		},
+	})
+	s.addSingleton(&singleton{
+		path:     "/httpConfig/request/hostSource",
+		required: []string{"headerNameList", "listValueLocation"},
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{"headerNameList": []interface{}{"Host"}, "listValueLocation": "LAST"}
+		},
+	})
+	s.addSingleton(&singleton{
+		path: "/unknownResources/settings",
+		defaults: func() map[string]interface{} {
+			return map[string]interface{}{
+				"agentDefaultCacheTTL": jsonInt(900),
+				"agentDefaultMode":     "DENY",
+				"auditLevel":           "ON",
+				"errorContentType":     "HTML",
+				"errorStatusCode":      jsonInt(404),
+				"errorTemplateFile":    "general.error.page.template.html",
+			}
+		},
+	})
+}
+
+// seed creates the objects which exist in a new installation of PingAccess.
+func (s *Server) seed() {
+	tcg := s.collections["/trustedCertificateGroups"]
+	s.insert(tcg, map[string]interface{}{"name": "Trust Any", "ignoreAllCertificateErrors": true, "systemGroup": true})
+	s.insert(tcg, map[string]interface{}{"name": "Java Trust Store", "useJavaTrustStore": true, "systemGroup": true})
+
+	s.insert(s.collections["/highAvailability/availabilityProfiles"], map[string]interface{}{
+		"name":      "Default Availability Profile",
+		"className": "com.pingidentity.pa.ha.availability.ondemand.OnDemandAvailabilityPlugin",
+	})
+	s.pluginDefaults("availability_profiles", s.collections["/highAvailability/availabilityProfiles"].store.items["1"])
+
+	kp := s.collections["/keyPairs"]
+	id := s.newID(kp)
+	s.keyPairs[id] = selfSigned(pkix.Name{CommonName: "localhost", Organization: []string{"Ping Identity"}, Country: []string{"US"}})
+	item := keyPairView("Generated: ADMIN", s.keyPairs[id].cert, nil)
+	item["hsmProviderId"] = jsonInt(0)
+	item["id"] = idValue(kp, id)
+	kp.store.put(id, item)
+
+	listeners := s.collections["/httpsListeners"]
+	for _, name := range []string{"ADMIN", "AGENT", "ENGINE", "CONFIG QUERY"} {
+		s.insert(listeners, map[string]interface{}{"name": name, "keyPairId": jsonInt(1), "useServerCipherSuiteOrder": false})
+	}
+
+	acme := s.collections["/acme/servers"]
+	s.insert(acme, map[string]interface{}{"name": "Let's Encrypt", "url": "https://acme-v02.api.letsencrypt.org/directory"})
+	s.insert(acme, map[string]interface{}{"name": "Let's Encrypt Staging", "url": "https://acme-staging-v02.api.letsencrypt.org/directory"})
+}
+
+// defaultAcmeServer returns a link to the default ACME server, the first one created.
+func (s *Server) defaultAcmeServer() (int, interface{}, error) {
+	servers := s.collections["/acme/servers"].store.list()
+	if len(servers) == 0 {
+		return 0, nil, notFound()
+	}
+	id := str(servers[0]["id"])
+	return http.StatusOK, map[string]interface{}{"id": id, "location": fmt.Sprintf("%s/acme/servers/%s", s.Endpoint(), id)}, nil
+}
+
+// pingFederateRuntimeMetadata returns the OpenID Connect metadata of the configured PingFederate runtime, which is
+// fetched from the issuer as PingAccess does.
+func (s *Server) pingFederateRuntimeMetadata() (int, interface{}, error) {
+	issuer := str(s.singletons["/pingfederate/runtime"].value["issuer"])
+	if issuer == "" {
+		return 0, nil, &apiError{status: http.StatusNotFound, Flash: []string{"PingFederate Runtime is not configured"}, Result: "resource_not_found"}
+	}
+	client := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}, //nolint:gosec
+	}
+	resp, err := client.Get(strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return 0, nil, badRequest("Unable to retrieve the PingFederate metadata: %s", err)
+	}
+	defer resp.Body.Close()
+	var metadata map[string]interface{}
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if err := dec.Decode(&metadata); err != nil || resp.StatusCode != http.StatusOK {
+		return 0, nil, badRequest("Unable to retrieve the PingFederate metadata from %s", issuer)
+	}
+	return http.StatusOK, metadata, nil
+}
+
+// selfSigned generates a key pair with a self signed certificate for the subject.
+func selfSigned(subject pkix.Name) *keyPair {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	now := time.Now().Truncate(time.Second)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      subject,
+		NotBefore:    now,
+		NotAfter:     now.AddDate(1, 0, 0),
+		DNSNames:     []string{subject.CommonName},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		panic(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &keyPair{key: key, cert: cert}
+}
@@ -0,0 +1,421 @@
+// Package pingaccesstest provides an in-memory fake of the PingAccess admin API for running the acceptance tests
+// without a licensed PingAccess instance.
+//
+// The fake implements the endpoints used by the provider with the behaviour the provider relies on: ids are allocated
+// per collection and never reused, required fields and references to other objects are validated, names are unique,
+// objects in use cannot be deleted and CONCEALED fields are encrypted. Validation failures use the same error format as
+// PingAccess so the provider reports them against the offending attribute.
+package pingaccesstest
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Context is the path of the admin API on the server.
+const Context = "/pa-admin-api/v3"
+
+const (
+	// DefaultVersion is the PingAccess version reported by the fake when none is given.
+	DefaultVersion = "6.2.0"
+	// Username and Password are the credentials of the administrator account of the fake.
+	Username = "administrator"
+	Password = "2Access"
+)
+
+// Server is a fake PingAccess admin API, the base URL of the server is available from the embedded httptest.Server
+// and the admin API is served under Context.
+type Server struct {
+	*httptest.Server
+
+	version string
+	crypter *crypter
+
+	mu          sync.Mutex
+	collections map[string]*collection
+	singletons  map[string]*singleton
+	descriptors map[string]json.RawMessage
+	resources   *store
+
+	keyPairs     map[string]*keyPair
+	certificates map[string]*x509.Certificate
+
+	exports workflows
+	imports workflows
+	backups int
+
+	engineStatus map[string]time.Time
+}
+
+// MainFunc runs the tests of a package, it allows TestMain to close the servers it starts with defer as
+// resource.TestMain exits the process once the tests have run:
+//
+//	resource.TestMain(pingaccesstest.MainFunc(func() int {
+//		s := pingaccesstest.NewServer(pingaccesstest.DefaultVersion)
+//		defer s.Close()
+//		return m.Run()
+//	}))
+type MainFunc func() int
+
+// Run runs the tests returning the exit code.
+func (f MainFunc) Run() int {
+	return f()
+}
+
+// NewServer starts a fake PingAccess admin API reporting the given version, DefaultVersion is used when empty. The
+// server uses TLS with a self signed certificate the same as PingAccess, the caller should call Close when finished.
+func NewServer(version string) *Server {
+	s := NewUnstartedServer(version)
+	s.StartTLS()
+	return s
+}
+
+// NewUnstartedServer returns a fake PingAccess admin API which is not yet started, so the caller can change the
+// listener before calling StartTLS.
+func NewUnstartedServer(version string) *Server {
+	if version == "" {
+		version = DefaultVersion
+	}
+	s := &Server{
+		version:     version,
+		crypter:     newCrypter(),
+		collections: map[string]*collection{},
+		singletons:  map[string]*singleton{},
+		resources:   newStore(1),
+
+		keyPairs:     map[string]*keyPair{},
+		certificates: map[string]*x509.Certificate{},
+	}
+	s.descriptors = loadDescriptors()
+	s.register()
+	s.seed()
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Endpoint is the admin API endpoint of the server, as used by the PingAccess SDK config.
+func (s *Server) Endpoint() string {
+	return s.URL + Context
+}
+
+// Decrypt returns the plain text of an encryptedValue returned by the server.
+func (s *Server) Decrypt(encryptedValue string) (string, error) {
+	return s.crypter.decrypt(encryptedValue)
+}
+
+// apiError is the error response of the admin API, flash messages describe the failure and form holds the validation
+// failures of each field.
+type apiError struct {
+	status int
+	Flash  []string            `json:"flash"`
+	Form   map[string][]string `json:"form,omitempty"`
+	Result string              `json:"resultId"`
+}
+
+func (e *apiError) Error() string {
+	return strings.Join(e.Flash, ", ")
+}
+
+func notFound() *apiError {
+	return &apiError{status: http.StatusNotFound, Flash: []string{"Resource not found"}, Result: "resource_not_found"}
+}
+
+func badRequest(format string, a ...interface{}) *apiError {
+	return &apiError{status: http.StatusBadRequest, Flash: []string{fmt.Sprintf(format, a...)}, Result: "invalid_request"}
+}
+
+func methodNotAllowed() *apiError {
+	return &apiError{status: http.StatusMethodNotAllowed, Flash: []string{"Method not allowed"}, Result: "method_not_allowed"}
+}
+
+// validation collects the validation failures of a request, which are returned together as PingAccess does.
+type validation map[string][]string
+
+func (v validation) add(field, format string, a ...interface{}) {
+	v[field] = append(v[field], fmt.Sprintf(format, a...))
+}
+
+func (v validation) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+	return &apiError{
+		status: http.StatusUnprocessableEntity,
+		Flash:  []string{"Save Failed"},
+		Form:   v,
+		Result: "invalid_request_body",
+	}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	user, pass, ok := r.BasicAuth()
+	if !ok || !strings.EqualFold(user, Username) || pass != Password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if r.Method != http.MethodGet && r.Header.Get("X-Xsrf-Header") != "pingaccess" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, Context+"/") {
+		writeError(w, notFound())
+		return
+	}
+
+	var body map[string]interface{}
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		dec := json.NewDecoder(r.Body)
+		dec.UseNumber()
+		if err := dec.Decode(&body); err != nil && err != io.EOF {
+			writeError(w, badRequest("Invalid request body: %s", err))
+			return
+		}
+	}
+
+	s.mu.Lock()
+	status, result, err := s.route(r.Method, strings.TrimPrefix(r.URL.Path, Context), r, body)
+	s.mu.Unlock()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if result == nil {
+		w.WriteHeader(status)
+		return
+	}
+	if l, ok := result.(location); ok {
+		w.Header().Set("Location", string(l))
+		w.WriteHeader(status)
+		return
+	}
+	if a, ok := result.(*attachment); ok {
+		w.Header().Set("Content-Type", a.contentType)
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", a.name))
+		w.WriteHeader(status)
+		_, _ = w.Write(a.body)
+		return
+	}
+	if t, ok := result.(text); ok {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(t))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(result)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	e, ok := err.(*apiError)
+	if !ok {
+		e = &apiError{status: http.StatusInternalServerError, Flash: []string{err.Error()}, Result: "server_error"}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(e.status)
+	_ = json.NewEncoder(w).Encode(e)
+}
+
+// route dispatches the request, singletons and special endpoints take precedence over the generic collection
+// endpoints.
+func (s *Server) route(method, path string, r *http.Request, body map[string]interface{}) (int, interface{}, error) {
+	if path == "/version" {
+		if method != http.MethodGet {
+			return 0, nil, methodNotAllowed()
+		}
+		return http.StatusOK, map[string]interface{}{"version": s.version}, nil
+	}
+	if sg, ok := s.singletons[path]; ok {
+		return s.serveSingleton(method, sg, body)
+	}
+	if strings.HasPrefix(path, "/applications/") {
+		if status, result, err, ok := s.serveApplication(method, path, r, body); ok {
+			return status, result, err
+		}
+	}
+	if strings.HasPrefix(path, "/keyPairs") || strings.HasPrefix(path, "/certificates") {
+		if status, result, err, ok := s.serveCertificates(method, path, body); ok {
+			return status, result, err
+		}
+	}
+	if path == "/engines/status" {
+		if method != http.MethodGet {
+			return 0, nil, methodNotAllowed()
+		}
+		return s.engineHealthStatus()
+	}
+	if path == "/backup" {
+		if method != http.MethodGet {
+			return 0, nil, methodNotAllowed()
+		}
+		return s.backup()
+	}
+	if strings.HasPrefix(path, "/config/") {
+		if status, result, err, ok := s.serveConfig(method, path, r, body); ok {
+			return status, result, err
+		}
+	}
+	if path == "/acme/servers/default" {
+		if method != http.MethodGet {
+			return 0, nil, methodNotAllowed()
+		}
+		return s.defaultAcmeServer()
+	}
+	if path == "/pingfederate/runtime/metadata" {
+		if method != http.MethodGet {
+			return 0, nil, methodNotAllowed()
+		}
+		return s.pingFederateRuntimeMetadata()
+	}
+
+	for _, c := range s.sortedCollections() {
+		if path == c.path {
+			switch method {
+			case http.MethodGet:
+				return http.StatusOK, c.list(r), nil
+			case http.MethodPost:
+				if c.readOnly {
+					return 0, nil, methodNotAllowed()
+				}
+				result, err := s.create(c, body)
+				return http.StatusOK, result, err
+			}
+			return 0, nil, methodNotAllowed()
+		}
+		rest := strings.TrimPrefix(path, c.path+"/")
+		if rest == path || strings.Contains(rest, "/") && !strings.HasPrefix(rest, "descriptors/") {
+			continue
+		}
+		if c.plugin != "" && (rest == "descriptors" || strings.HasPrefix(rest, "descriptors/")) {
+			return s.serveDescriptors(method, c.plugin, strings.TrimPrefix(strings.TrimPrefix(rest, "descriptors"), "/"))
+		}
+		switch method {
+		case http.MethodGet:
+			item, ok := c.store.get(rest)
+			if !ok {
+				return 0, nil, notFound()
+			}
+			return http.StatusOK, s.present(c, item), nil
+		case http.MethodPut:
+			result, err := s.update(c, rest, body)
+			return http.StatusOK, result, err
+		case http.MethodDelete:
+			if c.readOnly {
+				return 0, nil, methodNotAllowed()
+			}
+			return http.StatusOK, nil, s.delete(c, rest)
+		}
+		return 0, nil, methodNotAllowed()
+	}
+	return 0, nil, notFound()
+}
+
+// sortedCollections returns the collections with the longest paths first, so nested collection paths are matched
+// before their parents.
+func (s *Server) sortedCollections() []*collection {
+	var list []*collection
+	for _, c := range s.collections {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if len(list[i].path) != len(list[j].path) {
+			return len(list[i].path) > len(list[j].path)
+		}
+		return list[i].path < list[j].path
+	})
+	return list
+}
+
+func (s *Server) serveSingleton(method string, sg *singleton, body map[string]interface{}) (int, interface{}, error) {
+	switch method {
+	case http.MethodGet:
+		return http.StatusOK, s.presentObject(sg.value), nil
+	case http.MethodPut:
+		v := validation{}
+		for _, f := range sg.required {
+			if isEmpty(body[f]) {
+				v.add(f, "%s is required", f)
+			}
+		}
+		s.checkReferences(v, sg.refs, body)
+		updated := merge(sg.defaults(), body)
+		s.conceal(v, "", updated)
+		if err := v.err(); err != nil {
+			return 0, nil, err
+		}
+		sg.value = updated
+		return http.StatusOK, s.presentObject(sg.value), nil
+	case http.MethodDelete:
+		if sg.fixed {
+			return 0, nil, methodNotAllowed()
+		}
+		sg.value = sg.defaults()
+		return http.StatusNoContent, nil, nil
+	}
+	return 0, nil, methodNotAllowed()
+}
+
+// number returns the integer value of a JSON number or numeric string, ok is false for any other value.
+func number(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		i, err := strconv.Atoi(n.String())
+		return i, err == nil
+	case string:
+		i, err := strconv.Atoi(n)
+		return i, err == nil
+	case int:
+		return n, true
+	case float64:
+		return int(n), true
+	}
+	return 0, false
+}
+
+func isEmpty(v interface{}) bool {
+	switch t := v.(type) {
+	case nil:
+		return true
+	case string:
+		return t == ""
+	case []interface{}:
+		return len(t) == 0
+	}
+	return false
+}
+
+// str returns the value of a string field, other values are formatted as a string.
+func str(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	}
+	return fmt.Sprint(v)
+}
+
+func jsonInt(i int) json.Number {
+	return json.Number(strconv.Itoa(i))
+}
+
+// merge returns the defaults overlaid with the values of the body, a null in the body keeps the default as PingAccess
+// does for optional fields.
+func merge(defaults, body map[string]interface{}) map[string]interface{} {
+	for k, v := range body {
+		if _, ok := defaults[k]; ok && v == nil {
+			continue
+		}
+		defaults[k] = v
+	}
+	return defaults
+}
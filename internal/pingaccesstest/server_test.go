@@ -0,0 +1,228 @@
+package pingaccesstest
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/applications"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/keyPairs"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/pingfederate"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/rules"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/siteAuthenticators"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/sites"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/version"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/virtualhosts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T) (*Server, *config.Config) {
+	t.Helper()
+	s := NewServer(DefaultVersion)
+	t.Cleanup(s.Close)
+	return s, config.NewConfig().WithUsername(Username).WithPassword(Password).WithEndpoint(s.Endpoint())
+}
+
+func TestVersionAndAuthentication(t *testing.T) {
+	_, conf := newTestServer(t)
+
+	v, _, err := version.New(conf).VersionCommand()
+	require.NoError(t, err)
+	assert.Equal(t, DefaultVersion, *v.Version)
+
+	_, _, err = version.New(config.NewConfig().WithUsername(Username).WithPassword("wrong").WithEndpoint(*conf.Endpoint)).VersionCommand()
+	assert.Error(t, err)
+}
+
+func TestApplicationLifecycle(t *testing.T) {
+	_, conf := newTestServer(t)
+	vhSvc := virtualhosts.New(conf)
+	siteSvc := sites.New(conf)
+	appSvc := applications.New(conf)
+
+	vh, _, err := vhSvc.AddVirtualHostCommand(&virtualhosts.AddVirtualHostCommandInput{Body: models.VirtualHostView{Host: pingaccess.String("localhost"), Port: pingaccess.Int(3000)}})
+	require.NoError(t, err)
+	_, _, err = vhSvc.AddVirtualHostCommand(&virtualhosts.AddVirtualHostCommandInput{Body: models.VirtualHostView{Host: pingaccess.String("LOCALHOST"), Port: pingaccess.Int(3000)}})
+	assert.Error(t, err, "virtual hosts must be unique")
+
+	site, _, err := siteSvc.AddSiteCommand(&sites.AddSiteCommandInput{Body: models.SiteView{Name: pingaccess.String("site"), Targets: &[]*string{pingaccess.String("localhost:4567")}}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, *site.AvailabilityProfileId)
+	assert.Equal(t, -1, *site.MaxConnections)
+
+	vhID, _ := vh.Id.Int64()
+	siteID, _ := site.Id.Int64()
+	app, _, err := appSvc.AddApplicationCommand(&applications.AddApplicationCommandInput{Body: models.ApplicationView{
+		Name:           pingaccess.String("app"),
+		ContextRoot:    pingaccess.String("/app"),
+		Destination:    pingaccess.String("Site"),
+		SiteId:         pingaccess.Int(int(siteID)),
+		VirtualHostIds: &[]*int{pingaccess.Int(int(vhID))},
+	}})
+	require.NoError(t, err)
+
+	resources, _, err := appSvc.GetApplicationResourcesCommand(&applications.GetApplicationResourcesCommandInput{Id: app.Id.String()})
+	require.NoError(t, err)
+	require.Len(t, resources.Items, 1)
+	assert.True(t, *resources.Items[0].RootResource)
+
+	_, err = siteSvc.DeleteSiteCommand(&sites.DeleteSiteCommandInput{Id: site.Id.String()})
+	assert.Error(t, err, "the site is in use by the application")
+
+	_, err = appSvc.DeleteApplicationCommand(&applications.DeleteApplicationCommandInput{Id: app.Id.String()})
+	require.NoError(t, err)
+	_, err = siteSvc.DeleteSiteCommand(&sites.DeleteSiteCommandInput{Id: site.Id.String()})
+	require.NoError(t, err)
+
+	_, resp, err := siteSvc.GetSiteCommand(&sites.GetSiteCommandInput{Id: site.Id.String()})
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	again, _, err := siteSvc.AddSiteCommand(&sites.AddSiteCommandInput{Body: models.SiteView{Name: pingaccess.String("site"), Targets: &[]*string{pingaccess.String("localhost:4567")}}})
+	require.NoError(t, err)
+	assert.NotEqual(t, site.Id, again.Id, "ids are never reused")
+}
+
+func TestPluginValidationAndConcealedFields(t *testing.T) {
+	s, conf := newTestServer(t)
+	svc := siteAuthenticators.New(conf)
+	className := "com.pingidentity.pa.siteauthenticators.BasicAuthTargetSiteAuthenticator"
+
+	_, _, err := svc.AddSiteAuthenticatorCommand(&siteAuthenticators.AddSiteAuthenticatorCommandInput{Body: models.SiteAuthenticatorView{
+		Name:          pingaccess.String("basic"),
+		ClassName:     pingaccess.String(className),
+		Configuration: map[string]interface{}{"username": "cheese"},
+	}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Save Failed")
+
+	created, _, err := svc.AddSiteAuthenticatorCommand(&siteAuthenticators.AddSiteAuthenticatorCommandInput{Body: models.SiteAuthenticatorView{
+		Name:          pingaccess.String("basic"),
+		ClassName:     pingaccess.String(className),
+		Configuration: map[string]interface{}{"username": "cheese", "password": "top_secret"},
+	}})
+	require.NoError(t, err)
+	password, ok := created.Configuration["password"].(map[string]interface{})
+	require.True(t, ok)
+	encrypted, _ := password["encryptedValue"].(string)
+	plain, err := s.Decrypt(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "top_secret", plain)
+
+	updated, _, err := svc.UpdateSiteAuthenticatorCommand(&siteAuthenticators.UpdateSiteAuthenticatorCommandInput{Id: created.Id.String(), Body: models.SiteAuthenticatorView{
+		Name:          pingaccess.String("basic"),
+		ClassName:     pingaccess.String(className),
+		Configuration: map[string]interface{}{"username": "cheese", "password": map[string]interface{}{"encryptedValue": encrypted}},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, created.Configuration["password"], updated.Configuration["password"])
+
+	_, _, err = svc.UpdateSiteAuthenticatorCommand(&siteAuthenticators.UpdateSiteAuthenticatorCommandInput{Id: created.Id.String(), Body: models.SiteAuthenticatorView{
+		Name:          pingaccess.String("basic"),
+		ClassName:     pingaccess.String(className),
+		Configuration: map[string]interface{}{"username": "cheese", "password": map[string]interface{}{"encryptedValue": "invalid"}},
+	}})
+	assert.Error(t, err)
+}
+
+func TestRuleDescriptors(t *testing.T) {
+	_, conf := newTestServer(t)
+	svc := rules.New(conf)
+
+	desc, _, err := svc.GetRuleDescriptorsCommand()
+	require.NoError(t, err)
+	assert.NotEmpty(t, desc.Items)
+
+	rule, _, err := svc.AddRuleCommand(&rules.AddRuleCommandInput{Body: models.RuleView{
+		Name:          pingaccess.String("cidr"),
+		ClassName:     pingaccess.String("com.pingidentity.pa.policy.CIDRPolicyInterceptor"),
+		Configuration: map[string]interface{}{"cidrNotation": "127.0.0.1/32"},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, false, rule.Configuration["negate"])
+	require.NotNil(t, rule.SupportedDestinations)
+	assert.Len(t, *rule.SupportedDestinations, 2)
+
+	_, _, err = svc.AddRuleCommand(&rules.AddRuleCommandInput{Body: models.RuleView{
+		Name:      pingaccess.String("unknown"),
+		ClassName: pingaccess.String("com.example.Unknown"),
+	}})
+	require.Error(t, err)
+}
+
+func TestKeyPairs(t *testing.T) {
+	_, conf := newTestServer(t)
+	svc := keyPairs.New(conf)
+
+	admin, _, err := svc.GetKeyPairCommand(&keyPairs.GetKeyPairCommandInput{Id: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "Generated: ADMIN", *admin.Alias)
+	assert.Equal(t, "CN=localhost, O=Ping Identity, C=US", *admin.SubjectDn)
+
+	kp, _, err := svc.GenerateKeyPairCommand(&keyPairs.GenerateKeyPairCommandInput{Body: models.NewKeyPairConfigView{
+		Alias:        pingaccess.String("test"),
+		CommonName:   pingaccess.String("test"),
+		Country:      pingaccess.String("GB"),
+		KeyAlgorithm: pingaccess.String("RSA"),
+		KeySize:      pingaccess.Int(2048),
+		Organization: pingaccess.String("Test"),
+		ValidDays:    pingaccess.Int(365),
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "CN=test, O=Test, C=GB", *kp.SubjectDn)
+
+	csr, _, err := svc.GenerateCsrCommand(&keyPairs.GenerateCsrCommandInput{Id: strconv.Itoa(*kp.Id)})
+	require.NoError(t, err)
+	block, _ := pem.Decode([]byte(*csr))
+	require.NotNil(t, block)
+	req, err := x509.ParseCertificateRequest(block.Bytes)
+	require.NoError(t, err)
+	assert.NoError(t, req.CheckSignature())
+
+	data, err := os.ReadFile("../sdkv2provider/test_cases/provider.p12")
+	require.NoError(t, err)
+	imported, _, err := svc.ImportKeyPairCommand(&keyPairs.ImportKeyPairCommandInput{Body: models.PKCS12FileImportDocView{
+		Alias:    pingaccess.String("imported"),
+		FileData: pingaccess.String(base64.StdEncoding.EncodeToString(data)),
+		Password: &models.HiddenFieldView{Value: pingaccess.String("password")},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "CN=localhost.localdomain", *imported.SubjectDn)
+	assert.Len(t, imported.ChainCertificates, 2)
+
+	_, _, err = svc.ImportKeyPairCommand(&keyPairs.ImportKeyPairCommandInput{Body: models.PKCS12FileImportDocView{
+		Alias:    pingaccess.String("wrong password"),
+		FileData: pingaccess.String(base64.StdEncoding.EncodeToString(data)),
+		Password: &models.HiddenFieldView{Value: pingaccess.String("wrong")},
+	}})
+	assert.Error(t, err)
+}
+
+func TestPingFederateRuntimeMetadata(t *testing.T) {
+	pf := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"issuer": "https://pf", "token_endpoint": "https://pf/as/token.oauth2"}`))
+	}))
+	defer pf.Close()
+	_, conf := newTestServer(t)
+	svc := pingfederate.New(conf)
+
+	_, _, err := svc.GetPingFederateRuntimeMetadataCommand()
+	assert.Error(t, err, "the runtime is not configured")
+
+	_, _, err = svc.UpdatePingFederateRuntimeCommand(&pingfederate.UpdatePingFederateRuntimeCommandInput{Body: models.PingFederateMetadataRuntimeView{Issuer: pingaccess.String(pf.URL)}})
+	require.NoError(t, err)
+
+	metadata, _, err := svc.GetPingFederateRuntimeMetadataCommand()
+	require.NoError(t, err)
+	assert.Equal(t, "https://pf/as/token.oauth2", *metadata.TokenEndpoint)
+}
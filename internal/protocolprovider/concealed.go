@@ -0,0 +1,154 @@
+package protocol
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// concealedFields tracks the CONCEALED fields of a plugin configuration in the private state of the resource, keyed
+// by the path of the field. PingAccess never returns the value of these fields, but from 6.1 the encryptedValue is
+// stable so a change made outside of terraform can be detected. The value applied by terraform is only kept as a
+// salted hash so that it can be compared with the value held in state without storing it a second time.
+type concealedFields map[string]concealedField
+
+type concealedField struct {
+	EncryptedValue string `json:"encryptedValue"`
+	Salt           string `json:"salt"`
+	Hash           string `json:"hash"`
+}
+
+type privateState struct {
+	Concealed concealedFields `json:"concealed,omitempty"`
+}
+
+// parsePrivateState returns the CONCEALED fields tracked in the private state, invalid private state is ignored.
+func parsePrivateState(b []byte) concealedFields {
+	if len(b) == 0 {
+		return nil
+	}
+	var p privateState
+	if err := json.Unmarshal(b, &p); err != nil {
+		log.Printf("[WARN] ignoring invalid private state: %s", err)
+		return nil
+	}
+	return p.Concealed
+}
+
+func (c concealedFields) privateState() []byte {
+	if len(c) == 0 {
+		return nil
+	}
+	b, _ := json.Marshal(privateState{Concealed: c})
+	return b
+}
+
+// concealedPaths returns the paths of the CONCEALED fields in the descriptor for the class name, including those
+// within COMPOSITE fields.
+func concealedPaths(desc *models.DescriptorsView, className string) []string {
+	var paths []string
+	if desc == nil {
+		return paths
+	}
+	for _, item := range desc.Items {
+		if item.ClassName != nil && *item.ClassName == className {
+			paths = appendConcealedPaths(paths, "", item.ConfigurationFields)
+		}
+	}
+	return paths
+}
+
+func appendConcealedPaths(paths []string, prefix string, fields []*models.ConfigurationField) []string {
+	for _, f := range fields {
+		if f == nil || f.Name == nil || f.Type == nil {
+			continue
+		}
+		switch *f.Type {
+		case "CONCEALED":
+			paths = append(paths, prefix+*f.Name)
+		case "COMPOSITE":
+			paths = appendConcealedPaths(paths, prefix+*f.Name+".", f.Fields)
+		}
+	}
+	return paths
+}
+
+// concealedValue returns the plain text value of a CONCEALED field, which may be set directly or as the value of an
+// object.
+func concealedValue(configuration, path string) (string, bool) {
+	if v := gjson.Get(configuration, path+".value"); v.Exists() {
+		return v.String(), true
+	}
+	if v := gjson.Get(configuration, path); v.Exists() && v.Type == gjson.String {
+		return v.String(), true
+	}
+	return "", false
+}
+
+func hashConcealedValue(salt, value string) string {
+	h := sha256.Sum256([]byte(salt + value))
+	return hex.EncodeToString(h[:])
+}
+
+// trackConcealedFields records the encryptedValue returned by PingAccess along with a salted hash of the value in the
+// configuration applied for each CONCEALED field.
+func trackConcealedFields(paths []string, configuration, result string) concealedFields {
+	tracked := concealedFields{}
+	for _, path := range paths {
+		value, ok := concealedValue(configuration, path)
+		if !ok {
+			continue
+		}
+		encrypted := gjson.Get(result, path+".encryptedValue")
+		if !encrypted.Exists() {
+			continue
+		}
+		salt := make([]byte, 16)
+		if _, err := rand.Read(salt); err != nil {
+			log.Printf("[WARN] unable to track the CONCEALED field %s: %s", path, err)
+			continue
+		}
+		s := hex.EncodeToString(salt)
+		tracked[path] = concealedField{
+			EncryptedValue: encrypted.String(),
+			Salt:           s,
+			Hash:           hashConcealedValue(s, value),
+		}
+	}
+	return tracked
+}
+
+// drifted returns the paths of the tracked CONCEALED fields which have changed, either the encryptedValue returned by
+// PingAccess no longer matches the applied value or the value in state is not the value which was applied.
+func (c concealedFields) drifted(state, result string) []string {
+	var paths []string
+	for path, tracked := range c {
+		encrypted := gjson.Get(result, path+".encryptedValue")
+		if encrypted.Exists() && encrypted.String() != tracked.EncryptedValue {
+			paths = append(paths, path)
+			continue
+		}
+		if value, ok := concealedValue(state, path); ok && hashConcealedValue(tracked.Salt, value) != tracked.Hash {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// clearConcealedFields clears the value of the CONCEALED fields so that the configured value shows as a change.
+func clearConcealedFields(configuration string, paths []string) string {
+	for _, path := range paths {
+		if gjson.Get(configuration, path+".value").Exists() {
+			configuration, _ = sjson.Set(configuration, path+".value", "")
+		} else if gjson.Get(configuration, path).Exists() {
+			configuration, _ = sjson.Set(configuration, path, "")
+		}
+	}
+	return configuration
+}
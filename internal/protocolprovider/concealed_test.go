@@ -0,0 +1,63 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcealedPaths(t *testing.T) {
+	desc := &models.DescriptorsView{
+		Items: []*models.DescriptorView{
+			{
+				ClassName: String("something"),
+				ConfigurationFields: []*models.ConfigurationField{
+					{Name: String("username"), Type: String("TEXT")},
+					{Name: String("password"), Type: String("CONCEALED")},
+					{
+						Name: String("client"),
+						Type: String("COMPOSITE"),
+						Fields: []*models.ConfigurationField{
+							{Name: String("secret"), Type: String("CONCEALED")},
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, []string{"password", "client.secret"}, concealedPaths(desc, "something"))
+	assert.Empty(t, concealedPaths(desc, "other"))
+}
+
+func TestConcealedFieldsDrift(t *testing.T) {
+	paths := []string{"password", "secret"}
+	config := `{"password":{"value":"hunter2"},"secret":"foo"}`
+	result := `{"password":{"encryptedValue":"enc1"},"secret":{"encryptedValue":"enc2"}}`
+
+	tracked := trackConcealedFields(paths, config, result)
+	require.Len(t, tracked, 2)
+	assert.Equal(t, "enc1", tracked["password"].EncryptedValue)
+	assert.NotContains(t, string(tracked.privateState()), "hunter2")
+
+	private := parsePrivateState(tracked.privateState())
+	assert.Equal(t, tracked, private)
+	assert.Empty(t, private.drifted(config, result))
+
+	t.Run("changed outside of terraform", func(t *testing.T) {
+		changed := `{"password":{"encryptedValue":"enc3"},"secret":{"encryptedValue":"enc2"}}`
+		drifted := private.drifted(config, changed)
+		assert.Equal(t, []string{"password"}, drifted)
+		assert.Equal(t, `{"password":{"value":""},"secret":"foo"}`, clearConcealedFields(config, drifted))
+	})
+
+	t.Run("state does not match the applied value", func(t *testing.T) {
+		assert.Equal(t, []string{"secret"}, private.drifted(`{"password":{"value":"hunter2"},"secret":"bar"}`, result))
+	})
+
+	t.Run("invalid private state is ignored", func(t *testing.T) {
+		assert.Empty(t, parsePrivateState([]byte("not json")))
+		assert.Empty(t, parsePrivateState(nil))
+	})
+}
@@ -2,15 +2,19 @@ package protocol
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
-	"regexp"
 	"syscall"
 
+	goversion "github.com/hashicorp/go-version"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/descriptors"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pav7"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/paversion"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 
@@ -63,61 +67,68 @@ import (
 )
 
 type cfg struct {
-	Username string
-	Password string
-	Context  string
-	BaseURL  string
+	Username           string
+	Password           string
+	Context            string
+	BaseURL            string
+	DescriptorCacheDir string
+	Offline            bool
+	OfflineVersion     string
+	OfflineDescriptors string
 }
 
 type paClient struct {
-	AccessTokenValidators            accessTokenValidators.AccessTokenValidatorsAPI
-	AccessTokenValidatorsDescriptors *models.DescriptorsView
-	Acme                             acme.AcmeAPI
-	AdminConfig                      adminConfig.AdminConfigAPI
-	AdminSessionInfo                 adminSessionInfo.AdminSessionInfoAPI
-	Agents                           agents.AgentsAPI
-	Applications                     applications.ApplicationsAPI
-	Auth                             auth.AuthAPI
-	AuthTokenManagement              authTokenManagement.AuthTokenManagementAPI
-	AuthnReqLists                    authnReqLists.AuthnReqListsAPI
-	Backup                           backup.BackupAPI
-	Certificates                     certificates.CertificatesAPI
-	Config                           config.ConfigAPI
-	EngineListeners                  engineListeners.EngineListenersAPI
-	Engines                          engines.EnginesAPI
-	GlobalUnprotectedResources       globalUnprotectedResources.GlobalUnprotectedResourcesAPI
-	HighAvailability                 highAvailability.HighAvailabilityAPI
-	HsmProviders                     hsmProviders.HsmProvidersAPI
-	HttpConfig                       httpConfig.HttpConfigAPI
-	HttpsListeners                   httpsListeners.HttpsListenersAPI
-	IdentityMappings                 identityMappings.IdentityMappingsAPI
-	KeyPairs                         keyPairs.KeyPairsAPI
-	License                          license.LicenseAPI
-	Oauth                            oauth.OauthAPI
-	OauthKeyManagement               oauthKeyManagement.OauthKeyManagementAPI
-	Oidc                             oidc.OidcAPI
-	Pingfederate                     pingfederate.PingfederateAPI
-	Pingone                          pingone.PingoneAPI
-	Proxies                          proxies.ProxiesAPI
-	Redirects                        redirects.RedirectsAPI
-	RejectionHandlers                rejectionHandlers.RejectionHandlersAPI
-	Rules                            rules.RulesAPI
-	Rulesets                         rulesets.RulesetsAPI
-	SharedSecrets                    sharedSecrets.SharedSecretsAPI
-	SiteAuthenticators               siteAuthenticators.SiteAuthenticatorsAPI
-	SiteAuthenticatorsDescriptors    *models.DescriptorsView
-	Sites                            sites.SitesAPI
-	ThirdPartyServices               thirdPartyServices.ThirdPartyServicesAPI
-	TokenProvider                    tokenProvider.TokenProviderAPI
-	TrustedCertificateGroups         trustedCertificateGroups.TrustedCertificateGroupsAPI
-	UnknownResources                 unknownResources.UnknownResourcesAPI
-	Users                            users.UsersAPI
-	Version                          version.VersionAPI
-	Virtualhosts                     virtualhosts.VirtualhostsAPI
-	WebSessionManagement             webSessionManagement.WebSessionManagementAPI
-	WebSessions                      webSessions.WebSessionsAPI
-
-	apiVersion string
+	AccessTokenValidators      accessTokenValidators.AccessTokenValidatorsAPI
+	Acme                       acme.AcmeAPI
+	AdminConfig                adminConfig.AdminConfigAPI
+	AdminSessionInfo           adminSessionInfo.AdminSessionInfoAPI
+	Agents                     agents.AgentsAPI
+	Applications               applications.ApplicationsAPI
+	Auth                       auth.AuthAPI
+	AuthTokenManagement        authTokenManagement.AuthTokenManagementAPI
+	AuthnReqLists              authnReqLists.AuthnReqListsAPI
+	Backup                     backup.BackupAPI
+	Certificates               certificates.CertificatesAPI
+	Config                     config.ConfigAPI
+	EngineListeners            engineListeners.EngineListenersAPI
+	Engines                    engines.EnginesAPI
+	GlobalUnprotectedResources globalUnprotectedResources.GlobalUnprotectedResourcesAPI
+	HighAvailability           highAvailability.HighAvailabilityAPI
+	HsmProviders               hsmProviders.HsmProvidersAPI
+	HttpConfig                 httpConfig.HttpConfigAPI
+	HttpsListeners             httpsListeners.HttpsListenersAPI
+	IdentityMappings           identityMappings.IdentityMappingsAPI
+	KeyPairs                   keyPairs.KeyPairsAPI
+	License                    license.LicenseAPI
+	Oauth                      oauth.OauthAPI
+	OauthKeyManagement         oauthKeyManagement.OauthKeyManagementAPI
+	Oidc                       oidc.OidcAPI
+	Pingfederate               pingfederate.PingfederateAPI
+	Pingone                    pingone.PingoneAPI
+	Proxies                    proxies.ProxiesAPI
+	Redirects                  redirects.RedirectsAPI
+	RejectionHandlers          rejectionHandlers.RejectionHandlersAPI
+	Rules                      rules.RulesAPI
+	Rulesets                   rulesets.RulesetsAPI
+	SharedSecrets              sharedSecrets.SharedSecretsAPI
+	SiteAuthenticators         siteAuthenticators.SiteAuthenticatorsAPI
+	Sites                      sites.SitesAPI
+	ThirdPartyServices         thirdPartyServices.ThirdPartyServicesAPI
+	TokenProvider              tokenProvider.TokenProviderAPI
+	TrustedCertificateGroups   trustedCertificateGroups.TrustedCertificateGroupsAPI
+	UnknownResources           unknownResources.UnknownResourcesAPI
+	Users                      users.UsersAPI
+	Version                    version.VersionAPI
+	Virtualhosts               virtualhosts.VirtualhostsAPI
+	WebSessionManagement       webSessionManagement.WebSessionManagementAPI
+	WebSessions                webSessions.WebSessionsAPI
+	// V7 is configured when the server reports PingAccess 7.0 or above.
+	V7 *pav7.Client
+
+	apiVersion  *goversion.Version
+	descriptors *descriptors.Cache
+	// offline is set when the provider is configured offline, reads are skipped rather than sent to PingAccess.
+	offline bool
 }
 
 // Client configures and returns a fully initialized PAClient
@@ -138,6 +149,9 @@ func (c *cfg) Client() (*paClient, *tfprotov5.Diagnostic) {
 	if os.Getenv("TF_LOG") == "DEBUG" || os.Getenv("TF_LOG") == "TRACE" || os.Getenv("TF_LOG_PROVIDER") == "DEBUG" || os.Getenv("TF_LOG_PROVIDER") == "TRACE" {
 		cfg.WithDebug(true)
 	}
+	if c.Offline {
+		cfg.HTTPClient = &http.Client{Transport: offlineTransport{}}
+	}
 
 	client := paClient{
 		AccessTokenValidators:      accessTokenValidators.New(cfg),
@@ -186,6 +200,10 @@ func (c *cfg) Client() (*paClient, *tfprotov5.Diagnostic) {
 		WebSessions:                webSessions.New(cfg),
 	}
 
+	if c.Offline {
+		return c.offlineClient(client, cfg)
+	}
+
 	v, _, err := client.Version.VersionCommand()
 	if err != nil {
 		return nil, &tfprotov5.Diagnostic{
@@ -194,31 +212,127 @@ func (c *cfg) Client() (*paClient, *tfprotov5.Diagnostic) {
 			Detail:   fmt.Sprintf("Unable to connect to PingAccess: %s", checkErr(err)),
 		}
 	}
-	client.apiVersion = *v.Version
-	client.AccessTokenValidatorsDescriptors, _, err = client.AccessTokenValidators.GetAccessTokenValidatorDescriptorsCommand()
+	client.apiVersion, err = paversion.Parse(*v.Version)
+	if err != nil {
+		return nil, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Unsupported Version",
+			Detail:   fmt.Sprintf("Unable to parse PingAccess version '%s': %s", *v.Version, err),
+		}
+	}
+	if paversion.AtLeast(client.apiVersion, "7.0") {
+		client.V7 = pav7.New(cfg)
+	}
+	client.descriptors = descriptors.Shared(*cfg.Endpoint, client.apiVersion.String(), c.DescriptorCacheDir)
+
+	return &client, nil
+}
+
+// offlineClient completes the client configuration without a connection to PingAccess, the version is taken from the
+// provider configuration and descriptors are loaded locally.
+func (c *cfg) offlineClient(client paClient, cfg *paCfg.Config) (*paClient, *tfprotov5.Diagnostic) {
+	if c.OfflineVersion == "" {
+		return nil, &tfprotov5.Diagnostic{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "Missing Version",
+			Detail:   "offline_version must be set when the provider is configured offline",
+		}
+	}
+	client.offline = true
+	var err error
+	client.apiVersion, err = paversion.Parse(c.OfflineVersion)
 	if err != nil {
 		return nil, &tfprotov5.Diagnostic{
 			Severity: tfprotov5.DiagnosticSeverityError,
-			Summary:  "Setup Error",
-			Detail:   fmt.Sprintf("Unable to retrieve AccessTokenValidatorsDescriptors: %s", err.Error()),
+			Summary:  "Unsupported Version",
+			Detail:   fmt.Sprintf("Unable to parse PingAccess version '%s': %s", c.OfflineVersion, err),
 		}
 	}
-	client.SiteAuthenticatorsDescriptors, _, err = client.SiteAuthenticators.GetSiteAuthenticatorDescriptorsCommand()
+	if paversion.AtLeast(client.apiVersion, "7.0") {
+		client.V7 = pav7.New(cfg)
+	}
+	client.descriptors, err = descriptors.NewOfflineCache(client.apiVersion.String(), c.DescriptorCacheDir, c.OfflineDescriptors)
 	if err != nil {
 		return nil, &tfprotov5.Diagnostic{
 			Severity: tfprotov5.DiagnosticSeverityError,
-			Summary:  "Setup Error",
-			Detail:   fmt.Sprintf("Unable to retrieve SiteAuthenticatorsDescriptors: %s", err.Error()),
+			Summary:  "Invalid Descriptors",
+			Detail:   err.Error(),
 		}
 	}
-
 	return &client, nil
 }
 
+// Returns the access token validator descriptors, these are retrieved from PingAccess on first use
+func (c *paClient) accessTokenValidatorDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "access_token_validators", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.AccessTokenValidators.GetAccessTokenValidatorDescriptorsCommand()
+		return desc, err
+	})
+}
+
+// Returns the identity mapping descriptors, these are retrieved from PingAccess on first use
+func (c *paClient) identityMappingDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "identity_mappings", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.IdentityMappings.GetIdentityMappingDescriptorsCommand()
+		return desc, err
+	})
+}
+
+// Returns the rejection handler descriptors, these are retrieved from PingAccess on first use
+func (c *paClient) rejectionHandlerDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "rejection_handlers", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.RejectionHandlers.GetRejectionHandlerDescriptorsCommand()
+		return desc, err
+	})
+}
+
+// Returns the rule descriptors as plugin descriptors, these are retrieved from PingAccess on first use
+func (c *paClient) ruleDescriptors() (*models.DescriptorsView, error) {
+	desc, err := descriptors.Load(c.descriptors, "rules", func() (*models.RuleDescriptorsView, error) {
+		desc, _, err := c.Rules.GetRuleDescriptorsCommand()
+		return desc, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	view := &models.DescriptorsView{}
+	for _, item := range desc.Items {
+		view.Items = append(view.Items, &models.DescriptorView{
+			ClassName:           item.ClassName,
+			ConfigurationFields: item.ConfigurationFields,
+			Label:               item.Label,
+			Type:                item.Type,
+		})
+	}
+	return view, nil
+}
+
+// Returns the site authenticator descriptors, these are retrieved from PingAccess on first use
+func (c *paClient) siteAuthenticatorDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "site_authenticators", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.SiteAuthenticators.GetSiteAuthenticatorDescriptorsCommand()
+		return desc, err
+	})
+}
+
 // Checks whether we are running against PingAccess 6.1 or above and can track password changes
 func (c paClient) CanMaskPasswords() bool {
-	re := regexp.MustCompile(`^(6\.[1-9])`)
-	return re.MatchString(c.apiVersion)
+	return c.apiVersion != nil && paversion.Supports(c.apiVersion, paversion.FeatureMaskedPasswords)
+}
+
+// Checks whether we are running against the given PingAccess version or above
+func (c paClient) versionAtLeast(v string) bool {
+	return paversion.AtLeast(c.apiVersion, v)
+}
+
+// errOffline is returned for any request to the PingAccess API made while the provider is configured offline.
+var errOffline = errors.New("the provider is configured offline, this operation requires a connection to the PingAccess admin API")
+
+// offlineTransport fails every request, so operations needing the API only report an error when they are performed.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errOffline
 }
 
 func checkErr(err error) string {
@@ -1,12 +1,14 @@
 package protocol
 
 import (
+	"errors"
 	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
 	"testing"
 
+	goversion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 )
 
@@ -78,3 +80,63 @@ func TestConfig_Client(t *testing.T) {
 		})
 	}
 }
+
+func Test_CanMaskPasswords(t *testing.T) {
+	tests := []struct {
+		version string
+		expect  bool
+	}{
+		{"6.0.2", false},
+		{"6.1.0", true},
+		{"6.3.1", true},
+		{"7.1.0", true},
+		{"10.0.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			v, err := goversion.NewVersion(tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := (paClient{apiVersion: v}).CanMaskPasswords(); got != tt.expect {
+				t.Errorf("CanMaskPasswords() = %v, want %v", got, tt.expect)
+			}
+		})
+	}
+	if (paClient{}).CanMaskPasswords() {
+		t.Error("CanMaskPasswords() should be false without a version")
+	}
+}
+
+func TestConfig_ClientOffline(t *testing.T) {
+	c := &cfg{BaseURL: "https://unreachable.invalid:9000", Context: "/pa-admin-api/v3", Offline: true, OfflineVersion: "6.2.0"}
+	client, diag := c.Client()
+	if diag != nil {
+		t.Fatalf("unexpected diagnostic: %v", diag)
+	}
+	if !client.versionAtLeast("6.2") {
+		t.Errorf("expected the offline version to be used, got %s", client.apiVersion)
+	}
+	if client.V7 != nil {
+		t.Error("expected no 7.x client for PingAccess 6.2")
+	}
+	if _, err := client.accessTokenValidatorDescriptors(); err != nil {
+		t.Errorf("expected the bundled descriptors to be loaded offline, got %v", err)
+	}
+	if _, _, err := client.Version.VersionCommand(); !errors.Is(err, errOffline) {
+		t.Errorf("expected the offline error, got %v", err)
+	}
+
+	c.OfflineVersion = "7.0.3"
+	if client, _ = c.Client(); client.V7 == nil {
+		t.Error("expected the 7.x client to be configured for PingAccess 7.0")
+	}
+	if _, err := client.accessTokenValidatorDescriptors(); err == nil {
+		t.Error("expected an error loading descriptors which are not available offline")
+	}
+
+	c.OfflineVersion = ""
+	if _, diag := c.Client(); diag == nil || diag.Summary != "Missing Version" {
+		t.Errorf("expected a missing version diagnostic, got %v", diag)
+	}
+}
@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 )
@@ -13,25 +14,31 @@ func (e errUnsupportedDataSource) Error() string {
 }
 
 func (p *provider) ValidateDataSourceConfig(ctx context.Context, req *tfprotov5.ValidateDataSourceConfigRequest) (*tfprotov5.ValidateDataSourceConfigResponse, error) {
-	switch req.TypeName {
-	case "pingaccess_trusted_certificate_group":
-		res := &dataPingAccessTrustedCertificateGroups{
-			client: nil,
-		}
-		return res.ValidateDataSourceConfig(ctx, req)
-
+	res, err := p.dataSourceServer(req.TypeName)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errUnsupportedDataSource(req.TypeName)
+	return res.ValidateDataSourceConfig(ctx, req)
 }
 
 func (p *provider) ReadDataSource(ctx context.Context, req *tfprotov5.ReadDataSourceRequest) (*tfprotov5.ReadDataSourceResponse, error) {
-	switch req.TypeName {
-	case "pingaccess_trusted_certificate_group":
-		res := &dataPingAccessTrustedCertificateGroups{
-			client: p.client.TrustedCertificateGroups,
-		}
-		return res.ReadDataSource(ctx, req)
-
+	res, err := p.dataSourceServer(req.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	if p.client != nil && p.client.offline {
+		return &tfprotov5.ReadDataSourceResponse{
+			Diagnostics: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Data source requires a connection",
+					Detail:   fmt.Sprintf("%s cannot be read while the provider is configured offline, it requires a connection to the PingAccess admin API.", req.TypeName),
+				},
+			},
+		}, nil
+	}
+	if diags := p.dataSourceVersionDiagnostics(req.TypeName); len(diags) > 0 {
+		return &tfprotov5.ReadDataSourceResponse{Diagnostics: diags}, nil
 	}
-	return nil, errUnsupportedDataSource(req.TypeName)
+	return res.ReadDataSource(ctx, req)
 }
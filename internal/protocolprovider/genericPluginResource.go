@@ -3,15 +3,36 @@ package protocol
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-go-contrib/asgotypes"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pav7"
 )
 
 type genericPluginResource struct {
-	descriptors *models.DescriptorsView
+	// descriptors retrieves the plugin descriptors for the resource on first use, it is nil if the provider is not configured
+	descriptors func() (*models.DescriptorsView, error)
+	// attributes declares any computed attributes the resource has in addition to the common plugin attributes
+	attributes map[string]tftypes.Type
+	// trackConcealed enables drift detection of CONCEALED fields, this requires the stable encryptedValue returned by
+	// PingAccess 6.1 and above
+	trackConcealed bool
+	// v7 updates the plugin when the server is running PingAccess 7.0 or above, keeping any attribute of the plugin
+	// the 6.2 model does not declare
+	v7 *pav7.Client
+}
+
+// pluginResult is the plugin returned by the API after it has been created or updated.
+type pluginResult struct {
+	id            string
+	name          string
+	className     string
+	configuration map[string]interface{}
+	// attributes holds the values of the additional computed attributes of the resource
+	attributes map[string]tftypes.Value
 }
 
 func (r genericPluginResource) resourceType() tftypes.Type {
@@ -21,12 +42,44 @@ func (r genericPluginResource) resourceType() tftypes.Type {
 }
 
 func (r genericPluginResource) resourceTypes() map[string]tftypes.Type {
-	return map[string]tftypes.Type{
+	types := map[string]tftypes.Type{
 		"id":            tftypes.String,
 		"name":          tftypes.String,
 		"class_name":    tftypes.String,
 		"configuration": tftypes.DynamicPseudoType,
 	}
+	for k, t := range r.attributes {
+		types[k] = t
+	}
+	return types
+}
+
+// state builds the resource state, any additional attributes without a value are set to null.
+func (r genericPluginResource) state(id interface{}, name, class string, config tftypes.Value, attributes map[string]tftypes.Value) (tfprotov5.DynamicValue, error) {
+	values := map[string]tftypes.Value{
+		"id":            tftypes.NewValue(tftypes.String, id),
+		"name":          tftypes.NewValue(tftypes.String, name),
+		"class_name":    tftypes.NewValue(tftypes.String, class),
+		"configuration": config,
+	}
+	for k, t := range r.attributes {
+		if v, ok := attributes[k]; ok {
+			values[k] = v
+		} else {
+			values[k] = tftypes.NewValue(t, nil)
+		}
+	}
+	return tfprotov5.NewDynamicValue(r.resourceType(), tftypes.NewValue(tftypes.Object{
+		AttributeTypes: r.resourceTypes(),
+	}, values))
+}
+
+// loadDescriptors returns the plugin descriptors for the resource, retrieving them from PingAccess if required.
+func (r genericPluginResource) loadDescriptors() (*models.DescriptorsView, error) {
+	if r.descriptors == nil {
+		return nil, fmt.Errorf("the provider has not been configured")
+	}
+	return r.descriptors()
 }
 
 func (r genericPluginResource) ValidateResourceTypeConfig(_ context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
@@ -40,14 +93,23 @@ func (r genericPluginResource) ValidateResourceTypeConfig(_ context.Context, req
 	if !values["configuration"].IsFullyKnown() {
 		return &tfprotov5.ValidateResourceTypeConfigResponse{}, nil
 	}
+	desc, err := r.descriptors()
+	if err != nil {
+		return &tfprotov5.ValidateResourceTypeConfigResponse{
+			Diagnostics: []*tfprotov5.Diagnostic{descriptorsDiagnostic(err)},
+		}, nil
+	}
 	var name, className string
 	var configuration asgotypes.GoPrimitive
 	_ = values["name"].As(&name)
 	_ = values["class_name"].As(&className)
 	_ = values["configuration"].As(&configuration)
-	diags := []*tfprotov5.Diagnostic{}
-	diags = append(diags, descriptorsHasClassName(className, r.descriptors))
-	if diags = append(diags, validateConfiguration(className, configuration, r.descriptors)...); len(diags) > 0 {
+	if diag := descriptorsHasClassName(className, desc); diag != nil {
+		return &tfprotov5.ValidateResourceTypeConfigResponse{
+			Diagnostics: []*tfprotov5.Diagnostic{diag},
+		}, nil
+	}
+	if diags := validateConfiguration(className, configuration, desc); len(diags) > 0 {
 		return &tfprotov5.ValidateResourceTypeConfigResponse{
 			Diagnostics: diags,
 		}, nil
@@ -108,13 +170,26 @@ func (r genericPluginResource) PlanResourceChange(_ context.Context, req *tfprot
 		v = proposedValues["configuration"]
 	}
 
-	state, err := createGenericClassResourceState(r.resourceType(), r.resourceTypes(), id, name, className, v)
+	// the additional attributes are read from PingAccess, they are only expected to change with the class name
+	attributes := map[string]tftypes.Value{}
+	var priorClassName string
+	_ = priorValues["class_name"].As(&priorClassName)
+	for k, t := range r.attributes {
+		if !prior.IsNull() && priorClassName == className {
+			attributes[k] = priorValues[k]
+		} else {
+			attributes[k] = tftypes.NewValue(t, tftypes.UnknownValue)
+		}
+	}
+
+	state, err := r.state(id, name, className, v, attributes)
 	if err != nil {
 		return planResourceChangeError(err), nil
 	}
 
 	return &tfprotov5.PlanResourceChangeResponse{
-		PlannedState: &state,
+		PlannedState:   &state,
+		PlannedPrivate: req.PriorPrivate,
 	}, nil
 }
 
@@ -122,13 +197,12 @@ func (r genericPluginResource) UpgradeResourceState(_ context.Context, req *tfpr
 	var t tftypes.Type
 	switch req.Version {
 	case 0:
+		// version 0 stored the configuration as a JSON string, this is also the state written by the sdkv2 provider
+		// for resources which have since moved to this provider
+		types := r.resourceTypes()
+		types["configuration"] = tftypes.String
 		t = tftypes.Object{
-			AttributeTypes: map[string]tftypes.Type{
-				"id":            tftypes.String,
-				"name":          tftypes.String,
-				"class_name":    tftypes.String,
-				"configuration": tftypes.String,
-			},
+			AttributeTypes: types,
 		}
 
 	case 1:
@@ -173,6 +247,58 @@ func (r genericPluginResource) UpgradeResourceState(_ context.Context, req *tfpr
 	}, nil
 }
 
+// configurationValue builds the state value of the configuration returned by PingAccess in the same style as the
+// original configuration, the given CONCEALED fields are cleared so that they show as a change.
+func configurationValue(desc *models.DescriptorsView, className string, configuration asgotypes.GoPrimitive, result map[string]interface{}, drifted []string) tftypes.Value {
+	b, _ := json.Marshal(result)
+	if orig, ok := configuration.Value.(string); ok {
+		str := maskConfigFromDescriptors(desc, className, string(b), orig)
+		str = clearConcealedFields(str, drifted)
+		if suppressEquivalentJSONDiffs(orig, str) {
+			return tftypes.NewValue(tftypes.String, orig)
+		}
+		return tftypes.NewValue(tftypes.String, str)
+	}
+	orig, _ := configuration.Value.(map[string]interface{})
+	s := maskConfigFromDescriptorsAsMap(desc, className, result, orig)
+	s = clearConcealedFields(s, drifted)
+	var dat map[string]interface{}
+	_ = json.Unmarshal([]byte(s), &dat)
+	_, v, _ := marshal(dat)
+	return v
+}
+
+// configurationJSON returns the configuration as json regardless of the style it was written in.
+func configurationJSON(configuration asgotypes.GoPrimitive) string {
+	if s, ok := configuration.Value.(string); ok {
+		return s
+	}
+	b, _ := json.Marshal(configuration.Value)
+	return string(b)
+}
+
+// refreshConfiguration returns the state value of the configuration read from PingAccess, any CONCEALED fields
+// tracked in the private state which have changed are cleared.
+func (r genericPluginResource) refreshConfiguration(desc *models.DescriptorsView, className string, configuration asgotypes.GoPrimitive, result map[string]interface{}, private []byte) tftypes.Value {
+	var drifted []string
+	if r.trackConcealed {
+		b, _ := json.Marshal(result)
+		drifted = parsePrivateState(private).drifted(configurationJSON(configuration), string(b))
+	}
+	return configurationValue(desc, className, configuration, result, drifted)
+}
+
+// appliedConfiguration returns the state value of the configuration returned by PingAccess once applied, along with
+// the private state tracking its CONCEALED fields.
+func (r genericPluginResource) appliedConfiguration(desc *models.DescriptorsView, className string, configuration asgotypes.GoPrimitive, result map[string]interface{}) (tftypes.Value, []byte) {
+	var private []byte
+	if r.trackConcealed {
+		b, _ := json.Marshal(result)
+		private = trackConcealedFields(concealedPaths(desc, className), configurationJSON(configuration), string(b)).privateState()
+	}
+	return configurationValue(desc, className, configuration, result, nil), private
+}
+
 func genericPluginResourceDelete(req *tfprotov5.ApplyResourceChangeRequest, prior tftypes.Value, cb func(id string) error) (*tfprotov5.ApplyResourceChangeResponse, error) {
 	values := map[string]tftypes.Value{}
 	err := prior.As(&values)
@@ -201,7 +327,7 @@ func genericPluginResourceDelete(req *tfprotov5.ApplyResourceChangeRequest, prio
 	}, nil
 }
 
-func (r genericPluginResource) genericPluginResourceCreate(planned tftypes.Value, cb func(name, class string, dat map[string]interface{}) (string, string, string, map[string]interface{}, error)) (*tfprotov5.ApplyResourceChangeResponse, error) {
+func (r genericPluginResource) genericPluginResourceCreate(planned tftypes.Value, cb func(name, class string, dat map[string]interface{}) (*pluginResult, error)) (*tfprotov5.ApplyResourceChangeResponse, error) {
 	values := map[string]tftypes.Value{}
 	err := planned.As(&values)
 	if err != nil {
@@ -227,44 +353,30 @@ func (r genericPluginResource) genericPluginResourceCreate(planned tftypes.Value
 	} else {
 		dat = configuration.Value.(map[string]interface{})
 	}
-	id, name, class, result, err := cb(name, className, dat)
+	desc, err := r.loadDescriptors()
 	if err != nil {
+		return &tfprotov5.ApplyResourceChangeResponse{Diagnostics: []*tfprotov5.Diagnostic{descriptorsDiagnostic(err)}}, nil
+	}
+	result, err := cb(name, className, dat)
+	if err != nil {
+		_, isJSON := configuration.Value.(string)
 		return &tfprotov5.ApplyResourceChangeResponse{
-			Diagnostics: []*tfprotov5.Diagnostic{
-				{
-					Severity: tfprotov5.DiagnosticSeverityError,
-					Summary:  unexpectedConfigurationFormat,
-					Detail:   err.Error(),
-				},
-			},
+			Diagnostics: apiErrorDiagnostics(err, !isJSON),
 		}, nil
 	}
 
-	var v tftypes.Value
-	if _, ok := configuration.Value.(string); ok {
-		b, _ := json.Marshal(result)
-		str := maskConfigFromDescriptors(r.descriptors, className, string(b), configuration.Value.(string))
-		if suppressEquivalentJSONDiffs(configuration.Value.(string), str) {
-			v = tftypes.NewValue(tftypes.String, configuration.Value.(string))
-		} else {
-			v = tftypes.NewValue(tftypes.String, str)
-		}
-	} else {
-		var dat map[string]interface{}
-		s := maskConfigFromDescriptorsAsMap(r.descriptors, className, result, configuration.Value.(map[string]interface{}))
-		_ = json.Unmarshal([]byte(s), &dat)
-		_, v, _ = marshal(dat)
-	}
-	state, err := createGenericClassResourceState(r.resourceType(), r.resourceTypes(), id, name, class, v)
+	v, private := r.appliedConfiguration(desc, className, configuration, result.configuration)
+	state, err := r.state(result.id, result.name, result.className, v, result.attributes)
 	if err != nil {
 		return &tfprotov5.ApplyResourceChangeResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
 	}
 	return &tfprotov5.ApplyResourceChangeResponse{
 		NewState: &state,
+		Private:  private,
 	}, nil
 }
 
-func (r genericPluginResource) genericPluginResourceUpdate(planned tftypes.Value, cb func(id, name, class string, dat map[string]interface{}) (string, string, string, map[string]interface{}, error)) (*tfprotov5.ApplyResourceChangeResponse, error) {
+func (r genericPluginResource) genericPluginResourceUpdate(planned tftypes.Value, cb func(id, name, class string, dat map[string]interface{}) (*pluginResult, error)) (*tfprotov5.ApplyResourceChangeResponse, error) {
 	values := map[string]tftypes.Value{}
 	err := planned.As(&values)
 	if err != nil {
@@ -294,39 +406,25 @@ func (r genericPluginResource) genericPluginResourceUpdate(planned tftypes.Value
 	} else {
 		dat = configuration.Value.(map[string]interface{})
 	}
-	id, name, class, result, err := cb(id, name, className, dat)
+	desc, err := r.loadDescriptors()
+	if err != nil {
+		return &tfprotov5.ApplyResourceChangeResponse{Diagnostics: []*tfprotov5.Diagnostic{descriptorsDiagnostic(err)}}, nil
+	}
+	result, err := cb(id, name, className, dat)
 
 	if err != nil {
+		_, isJSON := configuration.Value.(string)
 		return &tfprotov5.ApplyResourceChangeResponse{
-			Diagnostics: []*tfprotov5.Diagnostic{
-				{
-					Severity: tfprotov5.DiagnosticSeverityError,
-					Summary:  unexpectedConfigurationFormat,
-					Detail:   err.Error(),
-				},
-			},
+			Diagnostics: apiErrorDiagnostics(err, !isJSON),
 		}, nil
 	}
-	var v tftypes.Value
-	if _, ok := configuration.Value.(string); ok {
-		b, _ := json.Marshal(result)
-		str := maskConfigFromDescriptors(r.descriptors, className, string(b), configuration.Value.(string))
-		if suppressEquivalentJSONDiffs(configuration.Value.(string), str) {
-			v = tftypes.NewValue(tftypes.String, configuration.Value.(string))
-		} else {
-			v = tftypes.NewValue(tftypes.String, str)
-		}
-	} else {
-		var dat map[string]interface{}
-		s := maskConfigFromDescriptorsAsMap(r.descriptors, className, result, configuration.Value.(map[string]interface{}))
-		_ = json.Unmarshal([]byte(s), &dat)
-		_, v, _ = marshal(dat)
-	}
-	state, err := createGenericClassResourceState(r.resourceType(), r.resourceTypes(), id, name, class, v)
+	v, private := r.appliedConfiguration(desc, className, configuration, result.configuration)
+	state, err := r.state(result.id, result.name, result.className, v, result.attributes)
 	if err != nil {
 		return &tfprotov5.ApplyResourceChangeResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
 	}
 	return &tfprotov5.ApplyResourceChangeResponse{
 		NewState: &state,
+		Private:  private,
 	}, nil
 }
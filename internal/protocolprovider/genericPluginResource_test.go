@@ -0,0 +1,204 @@
+package protocol
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPluginResourceValue(t *testing.T, r genericPluginResource, className string, destinations tftypes.Value) *tfprotov5.DynamicValue {
+	values := map[string]tftypes.Value{
+		"id":                     tftypes.NewValue(tftypes.String, "1"),
+		"name":                   tftypes.NewValue(tftypes.String, "foo"),
+		"class_name":             tftypes.NewValue(tftypes.String, className),
+		"configuration":          tftypes.NewValue(tftypes.String, `{"cidrNotation":"127.0.0.1/32"}`),
+		"supported_destinations": destinations,
+	}
+	dv, err := tfprotov5.NewDynamicValue(r.resourceType(), tftypes.NewValue(r.resourceType(), values))
+	require.NoError(t, err)
+	return &dv
+}
+
+func TestGenericPluginResource_UpgradeResourceStateFromJSONConfiguration(t *testing.T) {
+	r := genericPluginResource{attributes: ruleAttributes}
+	resp, err := r.UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+		Version: 0,
+		RawState: &tfprotov5.RawState{
+			JSON: []byte(`{"id":"1","name":"foo","class_name":"com.pingidentity.pa.policy.CIDRPolicyInterceptor","configuration":"{\"cidrNotation\":\"127.0.0.1/32\"}","supported_destinations":["Agent","Site"]}`),
+		},
+	})
+	require.NoError(t, err)
+	require.Empty(t, resp.Diagnostics)
+
+	state, err := resp.UpgradedState.Unmarshal(r.resourceType())
+	require.NoError(t, err)
+	values := map[string]tftypes.Value{}
+	require.NoError(t, state.As(&values))
+
+	var configuration string
+	require.NoError(t, values["configuration"].As(&configuration))
+	assert.Equal(t, `{"cidrNotation":"127.0.0.1/32"}`, configuration)
+	var destinations []tftypes.Value
+	require.NoError(t, values["supported_destinations"].As(&destinations))
+	assert.Len(t, destinations, 2)
+}
+
+// sdkv2State returns the version 0 state the sdkv2 provider wrote for a resource with the schema, before the resource
+// moved to this provider.
+func sdkv2State(t *testing.T, s map[string]*schema.Schema, raw map[string]interface{}, computed map[string]interface{}) []byte {
+	res := &schema.Resource{Schema: s}
+	d := schema.TestResourceDataRaw(t, s, raw)
+	d.SetId("1")
+	for k, v := range computed {
+		require.NoError(t, d.Set(k, v))
+	}
+	ty := res.CoreConfigSchema().ImpliedType()
+	val, err := d.State().AttrsAsObjectValue(ty)
+	require.NoError(t, err)
+	m, err := schema.StateValueToJSONMap(val, ty)
+	require.NoError(t, err)
+	b, err := json.Marshal(m)
+	require.NoError(t, err)
+	return b
+}
+
+func TestGenericPluginResource_UpgradeResourceStateFromSDKv2(t *testing.T) {
+	// the schemas of pingaccess_rule and pingaccess_identity_mapping in the sdkv2 provider
+	ruleSchema := map[string]*schema.Schema{
+		"class_name":             {Type: schema.TypeString, Required: true},
+		"name":                   {Type: schema.TypeString, Required: true},
+		"supported_destinations": {Type: schema.TypeSet, Optional: true, Computed: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"configuration":          {Type: schema.TypeString, Required: true},
+	}
+	identityMappingSchema := map[string]*schema.Schema{
+		"class_name":    {Type: schema.TypeString, Required: true},
+		"name":          {Type: schema.TypeString, Required: true},
+		"configuration": {Type: schema.TypeString, Required: true},
+	}
+	tests := []struct {
+		typeName      string
+		schema        map[string]*schema.Schema
+		className     string
+		configuration string
+		computed      map[string]interface{}
+	}{
+		{
+			typeName:      "pingaccess_rule",
+			schema:        ruleSchema,
+			className:     "com.pingidentity.pa.policy.CIDRPolicyInterceptor",
+			configuration: "{\n\t\"cidrNotation\": \"127.0.0.1/32\",\n\t\"negate\": false\n}\n",
+			computed:      map[string]interface{}{"supported_destinations": []interface{}{"Site", "Agent"}},
+		},
+		{
+			typeName:      "pingaccess_identity_mapping",
+			schema:        identityMappingSchema,
+			className:     "com.pingidentity.pa.identitymappings.HeaderIdentityMapping",
+			configuration: `{"attributeHeaderMappings":[{"attributeName":"sub","headerName":"SUB","subject":true}],"headerClientCertificateMappings":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typeName, func(t *testing.T) {
+			raw := sdkv2State(t, tt.schema, map[string]interface{}{
+				"name":          "foo",
+				"class_name":    tt.className,
+				"configuration": tt.configuration,
+			}, tt.computed)
+
+			p := Server().(*provider)
+			res, err := p.resourceServer(tt.typeName)
+			require.NoError(t, err)
+			resp, err := res.UpgradeResourceState(context.Background(), &tfprotov5.UpgradeResourceStateRequest{
+				TypeName: tt.typeName,
+				Version:  0,
+				RawState: &tfprotov5.RawState{JSON: raw},
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp.Diagnostics)
+
+			typ := p.resources[tt.typeName].schema().ValueType()
+			state, err := resp.UpgradedState.Unmarshal(typ)
+			require.NoError(t, err)
+			values := map[string]tftypes.Value{}
+			require.NoError(t, state.As(&values))
+			var id, configuration string
+			require.NoError(t, values["id"].As(&id))
+			require.NoError(t, values["configuration"].As(&configuration))
+			assert.Equal(t, "1", id)
+			assert.Equal(t, tt.configuration, configuration, "the JSON string is kept as written")
+			if tt.computed != nil {
+				var destinations []tftypes.Value
+				require.NoError(t, values["supported_destinations"].As(&destinations))
+				assert.Len(t, destinations, 2)
+			}
+
+			// the same configuration plans no changes against the upgraded state
+			plan, err := res.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+				TypeName:         tt.typeName,
+				PriorState:       resp.UpgradedState,
+				ProposedNewState: resp.UpgradedState,
+			})
+			require.NoError(t, err)
+			require.Empty(t, plan.Diagnostics)
+			planned, err := plan.PlannedState.Unmarshal(typ)
+			require.NoError(t, err)
+			assert.True(t, state.Equal(planned), "got %s", planned)
+		})
+	}
+}
+
+func TestGenericPluginResource_PlanResourceChangeAttributes(t *testing.T) {
+	r := genericPluginResource{attributes: ruleAttributes}
+	destinationsType := ruleAttributes["supported_destinations"]
+	destinations := tftypes.NewValue(destinationsType, []tftypes.Value{tftypes.NewValue(tftypes.String, "Site")})
+	nullDestinations := tftypes.NewValue(destinationsType, nil)
+	null, err := tfprotov5.NewDynamicValue(r.resourceType(), tftypes.NewValue(r.resourceType(), nil))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		prior    *tfprotov5.DynamicValue
+		proposed *tfprotov5.DynamicValue
+		expected tftypes.Value
+	}{
+		{
+			name:     "create",
+			prior:    &null,
+			proposed: testPluginResourceValue(t, r, "foo", nullDestinations),
+			expected: tftypes.NewValue(destinationsType, tftypes.UnknownValue),
+		},
+		{
+			name:     "update",
+			prior:    testPluginResourceValue(t, r, "foo", destinations),
+			proposed: testPluginResourceValue(t, r, "foo", destinations),
+			expected: destinations,
+		},
+		{
+			name:     "class name changed",
+			prior:    testPluginResourceValue(t, r, "foo", destinations),
+			proposed: testPluginResourceValue(t, r, "bar", destinations),
+			expected: tftypes.NewValue(destinationsType, tftypes.UnknownValue),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := r.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{
+				PriorState:       tt.prior,
+				ProposedNewState: tt.proposed,
+			})
+			require.NoError(t, err)
+			require.Empty(t, resp.Diagnostics)
+
+			planned, err := resp.PlannedState.Unmarshal(r.resourceType())
+			require.NoError(t, err)
+			values := map[string]tftypes.Value{}
+			require.NoError(t, planned.As(&values))
+			assert.True(t, tt.expected.Equal(values["supported_destinations"]), "got %s", values["supported_destinations"])
+		})
+	}
+}
@@ -2,10 +2,14 @@ package protocol
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"net/http"
 	"reflect"
+	"regexp"
+	"strconv"
 	"strings"
 
 	"github.com/tidwall/sjson"
@@ -14,6 +18,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/request"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/apierror"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/descriptors"
 	"github.com/tidwall/gjson"
 )
 
@@ -99,6 +106,95 @@ func readResourceChangeError(err error) *tfprotov5.ReadResourceResponse {
 	}
 }
 
+// isNotFound checks whether the PingAccess API responded that the requested object does not exist.
+func isNotFound(resp *http.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+// readResourceNotFound returns a null state for a resource which has been deleted outside of terraform, this removes it
+// from the state so that terraform will plan to recreate it.
+func readResourceNotFound(typ tftypes.Type) *tfprotov5.ReadResourceResponse {
+	state, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+	if err != nil {
+		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}
+	}
+	return &tfprotov5.ReadResourceResponse{
+		NewState: &state,
+	}
+}
+
+// apiErrorDiagnostics translates an error returned by the PingAccess API into diagnostics, each validation failure is
+// reported against the attribute matching the field path returned by the API so terraform can highlight the offending
+// configuration. When the configuration is a json string, failures for configuration fields are reported against the
+// whole attribute. The callbacks annotate the error with the operation being performed using apierror.Wrap, which is
+// used as the summary, any other error is returned as a single diagnostic.
+func apiErrorDiagnostics(err error, structuredConfiguration bool) []*tfprotov5.Diagnostic {
+	v, ok := apierror.Parse(err, "")
+	if !ok {
+		summary, detail := "", err.Error()
+		var opErr *apierror.OperationError
+		if errors.As(err, &opErr) {
+			summary, detail = opErr.Operation, opErr.Err.Error()
+		}
+		var paErr *request.PingAccessError
+		if errors.As(err, &paErr) {
+			summary = apierror.Summary(summary, paErr)
+		}
+		if summary == "" {
+			summary, detail = detail, ""
+		}
+		return []*tfprotov5.Diagnostic{
+			{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  summary,
+				Detail:   detail,
+			},
+		}
+	}
+	var diags []*tfprotov5.Diagnostic
+	for _, f := range v.Failures {
+		diags = append(diags, &tfprotov5.Diagnostic{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   v.Summary,
+			Detail:    fmt.Sprintf("%s: %s", f.Field, f.Message),
+			Attribute: apiFieldAttributePath(f.Field, structuredConfiguration),
+		})
+	}
+	return diags
+}
+
+var apiFieldIndexRegex = regexp.MustCompile(`\[(\d+)]`)
+
+// apiFieldAttributePath maps a field path returned by the PingAccess API (e.g. `configuration.path` or
+// `configuration.audience[0]`) to the matching attribute path of a plugin resource.
+func apiFieldAttributePath(field string, structuredConfiguration bool) *tftypes.AttributePath {
+	segments := strings.Split(field, ".")
+	var steps []tftypes.AttributePathStep
+	switch segments[0] {
+	case "name", "id":
+		steps = append(steps, tftypes.AttributeName(segments[0]))
+	case "className":
+		steps = append(steps, tftypes.AttributeName("class_name"))
+	case "configuration":
+		steps = append(steps, tftypes.AttributeName("configuration"))
+		if !structuredConfiguration {
+			break
+		}
+		for _, segment := range segments[1:] {
+			name := segment
+			if i := strings.Index(segment, "["); i > 0 {
+				name = segment[:i]
+			}
+			steps = append(steps, tftypes.ElementKeyString(name))
+			for _, m := range apiFieldIndexRegex.FindAllStringSubmatch(segment[len(name):], -1) {
+				idx, _ := strconv.ParseInt(m[1], 10, 64)
+				steps = append(steps, tftypes.ElementKeyInt(idx))
+			}
+		}
+	}
+	return tftypes.NewAttributePathWithSteps(steps)
+}
+
 func planResourceChangeError(err error) *tfprotov5.PlanResourceChangeResponse {
 	return &tfprotov5.PlanResourceChangeResponse{Diagnostics: []*tfprotov5.Diagnostic{schemaResourceMistmatchDiagnostic(err)}}
 }
@@ -135,6 +231,14 @@ func schemaDataSourceMistmatchDiagnostic(err error) *tfprotov5.Diagnostic {
 	}
 }
 
+func descriptorsDiagnostic(err error) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  "Error retrieving descriptors",
+		Detail:   fmt.Sprintf("Unable to retrieve plugin descriptors from PingAccess: %s", err.Error()),
+	}
+}
+
 func stateEncodingDiagnostic(err error) *tfprotov5.Diagnostic {
 	return &tfprotov5.Diagnostic{
 		Severity: tfprotov5.DiagnosticSeverityError,
@@ -290,12 +394,14 @@ func descriptorsHasClassName(className string, desc *models.DescriptorsView) *tf
 	}
 }
 
-// Checks the class name specified exists in the DescriptorsView
+// Validates the configuration against the descriptor for the class name, each failure is reported against the
+// offending configuration field when the configuration is structured, otherwise against the configuration attribute.
 func validateConfiguration(className string, configuration asgotypes.GoPrimitive, desc *models.DescriptorsView) []*tfprotov5.Diagnostic {
 	var diags []*tfprotov5.Diagnostic
 	diags = append(diags, validateNoNullConfigurationAttributes(configuration)...)
 	var conf string
-	if str, ok := configuration.Value.(string); ok {
+	str, isJSON := configuration.Value.(string)
+	if isJSON {
 		conf = str
 	} else {
 		b, _ := json.Marshal(configuration.Value)
@@ -306,40 +412,46 @@ func validateConfiguration(className string, configuration asgotypes.GoPrimitive
 		return nil
 	}
 	for _, value := range desc.Items {
-		if *value.ClassName == className {
-			for _, f := range value.ConfigurationFields {
-				if *f.Required {
-					v := gjson.Get(conf, *f.Name)
-					if !v.Exists() {
-						diags = append(diags, &tfprotov5.Diagnostic{
-							Severity:  tfprotov5.DiagnosticSeverityError,
-							Summary:   "Configuration Validation Failure",
-							Detail:    fmt.Sprintf("the field '%s' is required for the class_name '%s'", *f.Name, className),
-							Attribute: &tftypes.AttributePath{},
-						})
-					}
-					if !v.IsObject() && v.Str == "" {
-						steps := []tftypes.AttributePathStep{
-							tftypes.AttributeName("configuration"),
-						}
-						if _, ok := configuration.Value.(string); !ok {
-							steps = append(steps, tftypes.ElementKeyString(*f.Name))
-						}
-
-						diags = append(diags, &tfprotov5.Diagnostic{
-							Severity:  tfprotov5.DiagnosticSeverityError,
-							Summary:   "Configuration Validation Failure",
-							Detail:    fmt.Sprintf("the field '%s' is required for the class_name '%s'", *f.Name, className),
-							Attribute: tftypes.NewAttributePathWithSteps(steps),
-						})
-					}
-				}
-			}
+		if *value.ClassName != className {
+			continue
+		}
+		violations, err := descriptors.ValidateConfiguration(className, value.ConfigurationFields, conf)
+		if err != nil {
+			return append(diags, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Configuration Validation Failure",
+				Detail:    err.Error(),
+				Attribute: tftypes.NewAttributePath().WithAttributeName("configuration"),
+			})
+		}
+		for _, v := range violations {
+			diags = append(diags, &tfprotov5.Diagnostic{
+				Severity:  tfprotov5.DiagnosticSeverityError,
+				Summary:   "Configuration Validation Failure",
+				Detail:    v.Message,
+				Attribute: violationAttributePath(v, !isJSON),
+			})
 		}
 	}
 	return diags
 }
 
+// Maps the path of a configuration violation to the attribute path of a plugin resource.
+func violationAttributePath(v descriptors.Violation, structuredConfiguration bool) *tftypes.AttributePath {
+	steps := []tftypes.AttributePathStep{tftypes.AttributeName("configuration")}
+	if structuredConfiguration {
+		for _, step := range v.Path {
+			switch s := step.(type) {
+			case int:
+				steps = append(steps, tftypes.ElementKeyInt(int64(s)))
+			case string:
+				steps = append(steps, tftypes.ElementKeyString(s))
+			}
+		}
+	}
+	return tftypes.NewAttributePathWithSteps(steps)
+}
+
 func validateNoNullConfigurationAttributes(configuration asgotypes.GoPrimitive) []*tfprotov5.Diagnostic {
 	var diags []*tfprotov5.Diagnostic
 	if v, ok := configuration.Value.(map[string]interface{}); ok {
@@ -361,9 +473,10 @@ func validateNoNullConfigurationAttributes(configuration asgotypes.GoPrimitive)
 // Searches a given set of descriptors for a matching className, when found it will check all fields types for
 // a CONCEALED flag or COMPOSITE if CONCEALED, we massage the configuration to to remove the encryptedValue returned by
 // current API and set the value back to the original defined. For COMPOSITE fields we then iterate recursively on its
-// fields.
+// fields. The result is then overlaid on the original configuration, so only the values which actually differ are
+// changed and omitted defaults do not show as a difference.
 //
-// TODO This has a drawback that we cannot detect drift in CONCEALED fields due to the way the PingAccess API works.
+// On PingAccess 6.1 and above drift in CONCEALED fields is detected using the private state, see concealedFields.
 func maskConfigFromDescriptorsAsMap(desc *models.DescriptorsView, className string, input, config map[string]interface{}) string {
 	in, _ := json.Marshal(input)
 	orig, _ := json.Marshal(config)
@@ -371,6 +484,7 @@ func maskConfigFromDescriptorsAsMap(desc *models.DescriptorsView, className stri
 	for _, value := range desc.Items {
 		if *value.ClassName == className {
 			newConf = maskConfigFromDescriptor(value, String(""), string(orig), string(in))
+			newConf = descriptors.Overlay(value.ConfigurationFields, string(orig), newConf)
 		}
 	}
 	return newConf
@@ -379,9 +493,10 @@ func maskConfigFromDescriptorsAsMap(desc *models.DescriptorsView, className stri
 // Searches a given set of descriptors for a matching className, when found it will check all fields types for
 // a CONCEALED flag or COMPOSITE if CONCEALED, we massage the configuration to to remove the encryptedValue returned by
 // current API and set the value back to the original defined. For COMPOSITE fields we then iterate recursively on its
-// fields.
+// fields. The result is then overlaid on the original configuration, so only the values which actually differ are
+// changed and omitted defaults do not show as a difference.
 //
-// TODO This has a drawback that we cannot detect drift in CONCEALED fields due to the way the PingAccess API works.
+// On PingAccess 6.1 and above drift in CONCEALED fields is detected using the private state, see concealedFields.
 func maskConfigFromDescriptors(desc *models.DescriptorsView, className string, input, config string) string {
 	//var conf string
 	//if input.Is(tftypes.String) {
@@ -405,6 +520,7 @@ func maskConfigFromDescriptors(desc *models.DescriptorsView, className string, i
 	for _, value := range desc.Items {
 		if *value.ClassName == className {
 			newConf = maskConfigFromDescriptor(value, String(""), config, input)
+			newConf = descriptors.Overlay(value.ConfigurationFields, config, newConf)
 		}
 	}
 	return newConf
@@ -1,10 +1,16 @@
 package protocol
 
 import (
+	"fmt"
 	"math/big"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go-contrib/asgotypes"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/request"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/apierror"
 
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
@@ -148,11 +154,152 @@ func Test_maskConfigFromDescriptors(t *testing.T) {
 			config:   "{\"password\":{\"value\":\"secret\"},\"username\":\"cheese\"}",
 			expected: "{\"password\":{\"value\":\"secret\"},\"username\":\"cheese\"}",
 		},
+		"omitted defaults keep the original configuration": {
+			descriptors: &models.DescriptorsView{
+				Items: []*models.DescriptorView{
+					{
+						ClassName: String("something"),
+						ConfigurationFields: []*models.ConfigurationField{
+							{
+								Name:    String("errorResponseCode"),
+								Type:    String("TEXT"),
+								Default: String("403"),
+							},
+							{
+								Name: String("username"),
+								Type: String("TEXT"),
+							},
+						},
+					}}},
+			input:    "{\"errorResponseCode\":403,\"username\":\"cheese\"}",
+			config:   "{\"username\":\"cheese\"}",
+			expected: "{\"username\":\"cheese\"}",
+		},
+		"drift only overwrites the differing values": {
+			descriptors: &models.DescriptorsView{
+				Items: []*models.DescriptorView{
+					{
+						ClassName: String("something"),
+						ConfigurationFields: []*models.ConfigurationField{
+							{
+								Name:    String("errorResponseCode"),
+								Type:    String("TEXT"),
+								Default: String("403"),
+							},
+							{
+								Name: String("username"),
+								Type: String("TEXT"),
+							},
+						},
+					}}},
+			input:    "{\"errorResponseCode\":404,\"username\":\"bread\"}",
+			config:   "{\"username\":\"cheese\"}",
+			expected: "{\"errorResponseCode\":404,\"username\":\"bread\"}",
+		},
 	}
 	for name, testCase := range cases {
+		name, testCase := name, testCase
 		t.Run(name, func(t *testing.T) {
 			t.Parallel()
 			assert.Equal(t, testCase.expected, maskConfigFromDescriptors(testCase.descriptors, "something", testCase.input, testCase.config))
 		})
 	}
 }
+
+func Test_validateConfiguration(t *testing.T) {
+	desc := &models.DescriptorsView{
+		Items: []*models.DescriptorView{
+			{
+				ClassName: String("something"),
+				ConfigurationFields: []*models.ConfigurationField{
+					{
+						Name:     String("method"),
+						Type:     String("SELECT"),
+						Required: Bool(true),
+						Options: []*models.ConfigurationOption{
+							{Label: String("Get"), Value: String("GET")},
+						},
+					},
+					{
+						Name: String("headers"),
+						Type: String("TABLE"),
+						Fields: []*models.ConfigurationField{
+							{Name: String("name"), Type: String("TEXT"), Required: Bool(true)},
+						},
+					},
+				},
+			},
+		},
+	}
+	config := map[string]interface{}{
+		"method":  "PUT",
+		"headers": []interface{}{map[string]interface{}{"name": ""}},
+	}
+
+	diags := validateConfiguration("something", asgotypes.GoPrimitive{Value: config}, desc)
+	require.Len(t, diags, 2)
+	assert.Equal(t, "'PUT' is not a valid option for the field 'method', expected one of: GET (Get)", diags[0].Detail)
+	assert.Equal(t, tftypes.NewAttributePath().WithAttributeName("configuration").WithElementKeyString("method"), diags[0].Attribute)
+	assert.Equal(t, "the field 'name' is required for the class_name 'something'", diags[1].Detail)
+	assert.Equal(t, tftypes.NewAttributePath().WithAttributeName("configuration").WithElementKeyString("headers").WithElementKeyInt(0).WithElementKeyString("name"), diags[1].Attribute)
+
+	diags = validateConfiguration("something", asgotypes.GoPrimitive{Value: `{"method": "PUT"}`}, desc)
+	require.Len(t, diags, 1)
+	assert.Equal(t, tftypes.NewAttributePath().WithAttributeName("configuration"), diags[0].Attribute)
+
+	assert.Empty(t, validateConfiguration("something", asgotypes.GoPrimitive{Value: `{"method": "GET"}`}, desc))
+}
+
+func Test_readResourceNotFound(t *testing.T) {
+	typ := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"id": tftypes.String}}
+
+	assert.True(t, isNotFound(&http.Response{StatusCode: http.StatusNotFound}))
+	assert.False(t, isNotFound(&http.Response{StatusCode: http.StatusInternalServerError}))
+	assert.False(t, isNotFound(nil))
+
+	resp := readResourceNotFound(typ)
+	require.Empty(t, resp.Diagnostics)
+	val, err := resp.NewState.Unmarshal(typ)
+	require.Nil(t, err)
+	assert.True(t, val.IsNull())
+}
+
+func Test_apiErrorDiagnostics(t *testing.T) {
+	paErr := &request.PingAccessError{ApiErrorView: models.ApiErrorView{
+		Flash: &[]*string{String("Save Failed")},
+		Form: map[string]*[]*string{
+			"configuration.path":        {String("Path is required")},
+			"configuration.audience[1]": {String("Invalid audience")},
+			"name":                      {String("Name must be unique")},
+		},
+	}}
+	err := apierror.Wrap("unable to create AccessTokenValidator", paErr)
+
+	diags := apiErrorDiagnostics(err, true)
+	require.Len(t, diags, 3)
+	assert.Equal(t, "unable to create AccessTokenValidator: Save Failed", diags[0].Summary)
+	assert.Equal(t, "configuration.audience[1]: Invalid audience", diags[0].Detail)
+	assert.Equal(t, tftypes.NewAttributePath().WithAttributeName("configuration").WithElementKeyString("audience").WithElementKeyInt(1), diags[0].Attribute)
+	assert.Equal(t, tftypes.NewAttributePath().WithAttributeName("configuration").WithElementKeyString("path"), diags[1].Attribute)
+	assert.Equal(t, tftypes.NewAttributePath().WithAttributeName("name"), diags[2].Attribute)
+
+	diags = apiErrorDiagnostics(err, false)
+	require.Len(t, diags, 3)
+	assert.Equal(t, tftypes.NewAttributePath().WithAttributeName("configuration"), diags[1].Attribute)
+
+	diags = apiErrorDiagnostics(apierror.Wrap("unable to create AccessTokenValidator", fmt.Errorf("boom")), true)
+	require.Len(t, diags, 1)
+	assert.Equal(t, tfprotov5.DiagnosticSeverityError, diags[0].Severity)
+	assert.Equal(t, "unable to create AccessTokenValidator", diags[0].Summary)
+	assert.Equal(t, "boom", diags[0].Detail)
+
+	flashOnly := &request.PingAccessError{ApiErrorView: models.ApiErrorView{Flash: &[]*string{String("License expired")}}}
+	diags = apiErrorDiagnostics(apierror.Wrap("unable to update AccessTokenValidator", flashOnly), true)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "unable to update AccessTokenValidator: License expired", diags[0].Summary)
+
+	diags = apiErrorDiagnostics(fmt.Errorf("connection refused"), true)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "connection refused", diags[0].Summary)
+	assert.Empty(t, diags[0].Detail)
+}
@@ -1,15 +1,31 @@
 package protocol
 
 import (
+	"fmt"
+	"os"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	paCfg "github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pingaccesstest"
 )
 
 var conf *paCfg.Config
 
 func TestMain(m *testing.M) {
-	conf = paCfg.NewConfig().WithUsername("administrator").WithPassword("2Access").WithEndpoint("https://localhost:9000/pa-admin-api/v3")
-	resource.TestMain(m)
+	resource.TestMain(pingaccesstest.MainFunc(func() int {
+		target, err := pingaccesstest.Setup("testdata/cassettes", nil)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		os.Setenv("PINGACCESS_BASEURL", target.BaseURL)
+		conf = target.Config()
+		code := m.Run()
+		if err := target.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to close the PingAccess test target: %s\n", err)
+			return 1
+		}
+		return code
+	}))
 }
@@ -0,0 +1,108 @@
+package protocol
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+)
+
+// resourceRegistration declares a resource served by the protocol provider, the router builds a new server for each
+// request from the registration so resources only need to be added here to be fully wired.
+type resourceRegistration struct {
+	schema func() *tfprotov5.Schema
+	// descriptors returns the plugin descriptors for plugin style resources, it is nil for any other resource.
+	descriptors func(*paClient) (*models.DescriptorsView, error)
+	// server returns the resource server using the client, the plugin has the descriptors configured when available.
+	server func(c *paClient, plugin genericPluginResource) tfprotov5.ResourceServer
+}
+
+// dataSourceRegistration declares a data source served by the protocol provider.
+type dataSourceRegistration struct {
+	schema func() *tfprotov5.Schema
+	server func(c *paClient) tfprotov5.DataSourceServer
+}
+
+func resourceRegistry() map[string]resourceRegistration {
+	return map[string]resourceRegistration{
+		"pingaccess_access_token_validator": {
+			schema:      resourcePingAccessAccessTokenValidator{}.schema,
+			descriptors: (*paClient).accessTokenValidatorDescriptors,
+			server: func(c *paClient, plugin genericPluginResource) tfprotov5.ResourceServer {
+				return &resourcePingAccessAccessTokenValidator{client: c.AccessTokenValidators, genericPluginResource: plugin}
+			},
+		},
+		"pingaccess_identity_mapping": {
+			schema:      resourcePingAccessIdentityMapping{}.schema,
+			descriptors: (*paClient).identityMappingDescriptors,
+			server: func(c *paClient, plugin genericPluginResource) tfprotov5.ResourceServer {
+				return &resourcePingAccessIdentityMapping{client: c.IdentityMappings, genericPluginResource: plugin}
+			},
+		},
+		"pingaccess_rejection_handler": {
+			schema:      resourcePingAccessRejectionHandler{}.schema,
+			descriptors: (*paClient).rejectionHandlerDescriptors,
+			server: func(c *paClient, plugin genericPluginResource) tfprotov5.ResourceServer {
+				return &resourcePingAccessRejectionHandler{client: c.RejectionHandlers, genericPluginResource: plugin}
+			},
+		},
+		"pingaccess_rule": {
+			schema:      resourcePingAccessRule{}.schema,
+			descriptors: (*paClient).ruleDescriptors,
+			server: func(c *paClient, plugin genericPluginResource) tfprotov5.ResourceServer {
+				plugin.attributes = ruleAttributes
+				return &resourcePingAccessRule{client: c.Rules, genericPluginResource: plugin}
+			},
+		},
+		"pingaccess_site_authenticator": {
+			schema:      resourcePingAccessSiteAuthenticator{}.schema,
+			descriptors: (*paClient).siteAuthenticatorDescriptors,
+			server: func(c *paClient, plugin genericPluginResource) tfprotov5.ResourceServer {
+				return &resourcePingAccessSiteAuthenticator{client: c.SiteAuthenticators, genericPluginResource: plugin}
+			},
+		},
+	}
+}
+
+func dataSourceRegistry() map[string]dataSourceRegistration {
+	return map[string]dataSourceRegistration{
+		"pingaccess_trusted_certificate_group": {
+			schema: dataPingAccessTrustedCertificateGroups{}.schema,
+			server: func(c *paClient) tfprotov5.DataSourceServer {
+				return &dataPingAccessTrustedCertificateGroups{client: c.TrustedCertificateGroups}
+			},
+		},
+	}
+}
+
+// resourceServer builds the server for the resource type, before the provider is configured the server has no client
+// or descriptors and can only validate the configuration.
+func (p *provider) resourceServer(typeName string) (tfprotov5.ResourceServer, error) {
+	reg, ok := p.resources[typeName]
+	if !ok {
+		return nil, errUnsupportedResource(typeName)
+	}
+	var plugin genericPluginResource
+	client := p.client
+	if client == nil {
+		client = &paClient{}
+	} else if reg.descriptors != nil {
+		plugin.descriptors = func() (*models.DescriptorsView, error) {
+			return reg.descriptors(client)
+		}
+		plugin.trackConcealed = client.CanMaskPasswords()
+		plugin.v7 = client.V7
+	}
+	return reg.server(client, plugin), nil
+}
+
+// dataSourceServer builds the server for the data source type.
+func (p *provider) dataSourceServer(typeName string) (tfprotov5.DataSourceServer, error) {
+	reg, ok := p.dataSources[typeName]
+	if !ok {
+		return nil, errUnsupportedDataSource(typeName)
+	}
+	client := p.client
+	if client == nil {
+		client = &paClient{}
+	}
+	return reg.server(client), nil
+}
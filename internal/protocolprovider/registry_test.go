@@ -0,0 +1,107 @@
+package protocol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/descriptors"
+)
+
+func TestRegistrySchemas(t *testing.T) {
+	p := Server().(*provider)
+	resp, err := p.GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name := range resourceRegistry() {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("missing schema for resource %s", name)
+		}
+	}
+	for name := range dataSourceRegistry() {
+		if _, ok := resp.DataSourceSchemas[name]; !ok {
+			t.Errorf("missing schema for data source %s", name)
+		}
+	}
+}
+
+func TestRegistryServers(t *testing.T) {
+	p := Server().(*provider)
+	for name := range p.resources {
+		res, err := p.resourceServer(name)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		if res == nil {
+			t.Errorf("%s: expected a server before the provider is configured", name)
+		}
+	}
+	for name := range p.dataSources {
+		if _, err := p.dataSourceServer(name); err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+	}
+
+	if _, err := p.resourceServer("pingaccess_unknown"); err == nil || err.Error() != "unsupported resource: pingaccess_unknown" {
+		t.Errorf("unexpected error for unknown resource: %v", err)
+	}
+	if _, err := p.dataSourceServer("pingaccess_unknown"); err == nil || err.Error() != "unsupported data source: pingaccess_unknown" {
+		t.Errorf("unexpected error for unknown data source: %v", err)
+	}
+}
+
+func TestRegistryWiresDescriptors(t *testing.T) {
+	cache, err := descriptors.NewOfflineCache("5.3.2", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := Server().(*provider)
+	p.client = &paClient{descriptors: cache}
+	for name, reg := range p.resources {
+		if reg.descriptors == nil {
+			continue
+		}
+		res, err := p.resourceServer(name)
+		if err != nil {
+			t.Fatalf("%s: %s", name, err)
+		}
+		plugin, ok := res.(interface {
+			loadDescriptors() (*models.DescriptorsView, error)
+		})
+		if !ok {
+			t.Fatalf("%s: expected a plugin resource", name)
+		}
+		// the offline cache has no descriptors, reaching it shows the descriptors are wired to the client
+		if _, err := plugin.loadDescriptors(); err == nil || err.Error() == "the provider has not been configured" {
+			t.Errorf("%s: expected descriptors to be loaded from the client, got %v", name, err)
+		}
+	}
+}
+
+func TestRegistryOfflineReads(t *testing.T) {
+	p := Server().(*provider)
+	p.client = &paClient{offline: true}
+	state := &tfprotov5.DynamicValue{JSON: []byte(`{"id":"1"}`)}
+	resp, err := p.ReadResource(context.Background(), &tfprotov5.ReadResourceRequest{TypeName: "pingaccess_rule", CurrentState: state, Private: []byte("private")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.NewState != state || string(resp.Private) != "private" {
+		t.Error("expected the prior state to be kept while offline")
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity != tfprotov5.DiagnosticSeverityWarning {
+		t.Errorf("expected a single warning, got %v", resp.Diagnostics)
+	}
+
+	for name := range p.dataSources {
+		resp, err := p.ReadDataSource(context.Background(), &tfprotov5.ReadDataSourceRequest{TypeName: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Severity != tfprotov5.DiagnosticSeverityError {
+			t.Errorf("%s: expected a single error, got %v", name, resp.Diagnostics)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log"
 	"reflect"
 
 	"github.com/hashicorp/terraform-plugin-go-contrib/asgotypes"
@@ -13,6 +14,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/services/accessTokenValidators"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/apierror"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/importid"
 )
 
 type resourcePingAccessAccessTokenValidator struct {
@@ -26,7 +29,7 @@ func (r resourcePingAccessAccessTokenValidator) schema() *tfprotov5.Schema {
 		Block: &tfprotov5.SchemaBlock{
 			Description: `Provides configuration for Access Token Validators within PingAccess.
 
--> The PingAccess API does not provider repeatable means of querying a sensitive value, we are unable to detect configuration drift of any sensitive fields in the ` + "configuration" + ` block.
+-> The PingAccess API does not provider repeatable means of querying a sensitive value, configuration drift of sensitive fields in the ` + "configuration" + ` block is only detected on PingAccess 6.1 and above.
 `,
 			Attributes: []*tfprotov5.SchemaAttribute{
 				{
@@ -71,32 +74,33 @@ func (r resourcePingAccessAccessTokenValidator) ReadResource(_ context.Context,
 	input := &accessTokenValidators.GetAccessTokenValidatorCommandInput{
 		Id: id,
 	}
-	result, _, err := r.client.GetAccessTokenValidatorCommand(input)
+	result, resp, err := r.client.GetAccessTokenValidatorCommand(input)
+	if isNotFound(resp) {
+		log.Printf("[WARN] AccessTokenValidator (%s) not found, removing from state", id)
+		return readResourceNotFound(r.resourceType()), nil
+	}
 	if err != nil {
-		return readResourceChangeError(fmt.Errorf("unable to find AccessTokenValidator with the id '%s', result was nil", id)), nil
+		return readResourceChangeError(fmt.Errorf("unable to read AccessTokenValidator with the id '%s': %s", id, err)), nil
 	}
 	if result == nil {
 		return readResourceChangeError(fmt.Errorf("unable to find AccessTokenValidator with the id '%s', result was nil", id)), nil
 	}
+	desc, err := r.loadDescriptors()
+	if err != nil {
+		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{descriptorsDiagnostic(err)}}, nil
+	}
+	var className string
+	_ = values["class_name"].As(&className)
 	var configuration asgotypes.GoPrimitive
 	_ = values["configuration"].As(&configuration)
-	var v tftypes.Value
-	if _, ok := configuration.Value.(string); ok {
-		b, _ := json.Marshal(result.Configuration)
-		if suppressEquivalentJSONDiffs(configuration.Value.(string), string(b)) {
-			v = tftypes.NewValue(tftypes.String, configuration.Value.(string))
-		} else {
-			v = tftypes.NewValue(tftypes.String, string(b))
-		}
-	} else {
-		_, v, _ = marshal(result.Configuration)
-	}
-	state, err := createGenericClassResourceState(r.resourceType(), r.resourceTypes(), result.Id.String(), *result.Name, *result.ClassName, v)
+	v := r.refreshConfiguration(desc, className, configuration, result.Configuration, req.Private)
+	state, err := r.state(result.Id.String(), *result.Name, *result.ClassName, v, nil)
 	if err != nil {
 		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
 	}
 	return &tfprotov5.ReadResourceResponse{
 		NewState: &state,
+		Private:  req.Private,
 	}, nil
 }
 
@@ -113,7 +117,7 @@ func (r resourcePingAccessAccessTokenValidator) ApplyResourceChange(_ context.Co
 	switch {
 	case prior.IsNull():
 		{ //create
-			return r.genericPluginResourceCreate(planned, func(name, class string, dat map[string]interface{}) (string, string, string, map[string]interface{}, error) {
+			return r.genericPluginResourceCreate(planned, func(name, class string, dat map[string]interface{}) (*pluginResult, error) {
 				input := &accessTokenValidators.AddAccessTokenValidatorCommandInput{
 					Body: models.AccessTokenValidatorView{
 						ClassName:     String(class),
@@ -122,9 +126,9 @@ func (r resourcePingAccessAccessTokenValidator) ApplyResourceChange(_ context.Co
 					},
 				}
 				if result, _, err := r.client.AddAccessTokenValidatorCommand(input); err != nil {
-					return "", "", "", nil, fmt.Errorf("unable to create AccessTokenValidator: %s", err)
+					return nil, apierror.Wrap("unable to create AccessTokenValidator", err)
 				} else {
-					return result.Id.String(), *result.Name, *result.ClassName, result.Configuration, nil
+					return &pluginResult{id: result.Id.String(), name: *result.Name, className: *result.ClassName, configuration: result.Configuration}, nil
 				}
 			})
 		}
@@ -142,7 +146,7 @@ func (r resourcePingAccessAccessTokenValidator) ApplyResourceChange(_ context.Co
 		}
 	case !planned.IsNull() && !prior.IsNull():
 		{ //update
-			return r.genericPluginResourceUpdate(planned, func(id, name, class string, dat map[string]interface{}) (string, string, string, map[string]interface{}, error) {
+			return r.genericPluginResourceUpdate(planned, func(id, name, class string, dat map[string]interface{}) (*pluginResult, error) {
 				input := &accessTokenValidators.UpdateAccessTokenValidatorCommandInput{
 					Id: id,
 					Body: models.AccessTokenValidatorView{
@@ -151,36 +155,35 @@ func (r resourcePingAccessAccessTokenValidator) ApplyResourceChange(_ context.Co
 						Name:          String(name),
 					},
 				}
-				if result, _, err := r.client.UpdateAccessTokenValidatorCommand(input); err != nil {
-					return "", "", "", nil, fmt.Errorf("unable to update AccessTokenValidator: %s", err)
+				result := &models.AccessTokenValidatorView{}
+				var err error
+				if r.v7 != nil {
+					_, err = r.v7.Update("/accessTokenValidators/"+id, &input.Body, result)
 				} else {
-					return result.Id.String(), *result.Name, *result.ClassName, result.Configuration, nil
+					result, _, err = r.client.UpdateAccessTokenValidatorCommand(input)
 				}
+				if err != nil {
+					return nil, apierror.Wrap("unable to update AccessTokenValidator", err)
+				}
+				return &pluginResult{id: result.Id.String(), name: *result.Name, className: *result.ClassName, configuration: result.Configuration}, nil
 			})
 		}
 	}
 	return nil, nil
 }
 
-func createGenericClassResourceState(typ tftypes.Type, attrTypes map[string]tftypes.Type, id interface{}, name, class string, config tftypes.Value) (tfprotov5.DynamicValue, error) {
-	return tfprotov5.NewDynamicValue(typ, tftypes.NewValue(tftypes.Object{
-		AttributeTypes: attrTypes,
-	}, map[string]tftypes.Value{
-		"id":            tftypes.NewValue(tftypes.String, id),
-		"name":          tftypes.NewValue(tftypes.String, name),
-		"class_name":    tftypes.NewValue(tftypes.String, class),
-		"configuration": config,
-	}))
-}
-
 func (r resourcePingAccessAccessTokenValidator) ImportResourceState(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
-	result, _, err := r.client.GetAccessTokenValidatorCommand(&accessTokenValidators.GetAccessTokenValidatorCommandInput{Id: req.ID})
+	id, err := importid.Name("access token validator", r.matches).Resolve(req.ID)
+	if err != nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to resolve the access token validator import ID: '%s'.\n\nError:\n%s", req.ID, err.Error())), nil
+	}
+	result, _, err := r.client.GetAccessTokenValidatorCommand(&accessTokenValidators.GetAccessTokenValidatorCommandInput{Id: id})
 	if err != nil {
-		return importResourceError(fmt.Sprintf("The provider was unable to retrieve the access token validator with ID: '%s'.\n\nError:\n%s", req.ID, err.Error())), nil
+		return importResourceError(fmt.Sprintf("The provider was unable to retrieve the access token validator with ID: '%s'.\n\nError:\n%s", id, err.Error())), nil
 	}
 	var v tftypes.Value
 	_, v, _ = marshal(result.Configuration)
-	state, err := createGenericClassResourceState(r.resourceType(), r.resourceTypes(), result.Id.String(), *result.Name, *result.ClassName, v)
+	state, err := r.state(result.Id.String(), *result.Name, *result.ClassName, v, nil)
 	if err != nil {
 		return &tfprotov5.ImportResourceStateResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
 	}
@@ -194,6 +197,19 @@ func (r resourcePingAccessAccessTokenValidator) ImportResourceState(_ context.Co
 	}, nil
 }
 
+// matches returns the access token validators which may have the name, for imports by name.
+func (r resourcePingAccessAccessTokenValidator) matches(name string) ([]importid.Match, error) {
+	result, _, err := r.client.GetAccessTokenValidatorsCommand(&accessTokenValidators.GetAccessTokenValidatorsCommandInput{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	var matches []importid.Match
+	for _, item := range result.Items {
+		matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+	}
+	return matches, nil
+}
+
 func suppressEquivalentJSONDiffs(old, new string) bool {
 	ob := bytes.NewBufferString("")
 	if err := json.Compact(ob, []byte(old)); err != nil {
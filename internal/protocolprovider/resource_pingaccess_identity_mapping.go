@@ -0,0 +1,207 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go-contrib/asgotypes"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/identityMappings"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/apierror"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/importid"
+)
+
+type resourcePingAccessIdentityMapping struct {
+	client identityMappings.IdentityMappingsAPI
+	genericPluginResource
+}
+
+func (r resourcePingAccessIdentityMapping) schema() *tfprotov5.Schema {
+	return &tfprotov5.Schema{
+		Version: 1,
+		Block: &tfprotov5.SchemaBlock{
+			Description: `Provides configuration for Identity Mappings within PingAccess.
+-> The PingAccess API does not provider repeatable means of querying a sensitive value, configuration drift of sensitive fields in the ` + "configuration" + ` block is only detected on PingAccess 6.1 and above.
+`,
+			Attributes: []*tfprotov5.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "When creating a new IdentityMapping, this is the ID for the IdentityMapping.",
+				},
+				{
+					Name:        "name",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The name of the identity mapping.",
+				},
+				{
+					Name:        "class_name",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The identity mapping's class name.",
+				},
+				{
+					Name:        "configuration",
+					Type:        tftypes.DynamicPseudoType,
+					Required:    true,
+					Description: "The identity mapping's configuration data.",
+				},
+			},
+		},
+	}
+}
+
+func (r resourcePingAccessIdentityMapping) ReadResource(_ context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	values, diags := resourceDynamicValueToTftypesValues(req.CurrentState, r.resourceType())
+	if len(diags) > 0 {
+		return &tfprotov5.ReadResourceResponse{
+			Diagnostics: diags,
+		}, nil
+	}
+	var id string
+	_ = values["id"].As(&id)
+
+	input := &identityMappings.GetIdentityMappingCommandInput{
+		Id: id,
+	}
+	result, resp, err := r.client.GetIdentityMappingCommand(input)
+	if isNotFound(resp) {
+		log.Printf("[WARN] IdentityMapping (%s) not found, removing from state", id)
+		return readResourceNotFound(r.resourceType()), nil
+	}
+	if err != nil {
+		return readResourceChangeError(fmt.Errorf("unable to read IdentityMapping with the id '%s': %s", id, err)), nil
+	}
+	if result == nil {
+		return readResourceChangeError(fmt.Errorf("unable to find IdentityMapping with the id '%s', result was nil", id)), nil
+	}
+	desc, err := r.loadDescriptors()
+	if err != nil {
+		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{descriptorsDiagnostic(err)}}, nil
+	}
+	var className string
+	_ = values["class_name"].As(&className)
+	var configuration asgotypes.GoPrimitive
+	_ = values["configuration"].As(&configuration)
+	v := r.refreshConfiguration(desc, className, configuration, result.Configuration, req.Private)
+	state, err := r.state(result.Id.String(), *result.Name, *result.ClassName, v, nil)
+	if err != nil {
+		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
+	}
+	return &tfprotov5.ReadResourceResponse{
+		NewState: &state,
+		Private:  req.Private,
+	}, nil
+}
+
+func (r resourcePingAccessIdentityMapping) ApplyResourceChange(_ context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	planned, err := req.PlannedState.Unmarshal(r.resourceType())
+	if err != nil {
+		return applyResourceChangeError(err), nil
+	}
+	prior, err := req.PriorState.Unmarshal(r.resourceType())
+	if err != nil {
+		return applyResourceChangeError(err), nil
+	}
+
+	switch {
+	case prior.IsNull():
+		{ //create
+			return r.genericPluginResourceCreate(planned, func(name, class string, dat map[string]interface{}) (*pluginResult, error) {
+				input := &identityMappings.AddIdentityMappingCommandInput{
+					Body: models.IdentityMappingView{
+						ClassName:     String(class),
+						Configuration: dat,
+						Name:          String(name),
+					},
+				}
+				if result, _, err := r.client.AddIdentityMappingCommand(input); err != nil {
+					return nil, apierror.Wrap("unable to create IdentityMapping", err)
+				} else {
+					return &pluginResult{id: result.Id.String(), name: *result.Name, className: *result.ClassName, configuration: result.Configuration}, nil
+				}
+			})
+		}
+	case planned.IsNull():
+		{ //delete
+			return genericPluginResourceDelete(req, prior, func(id string) error {
+				input := &identityMappings.DeleteIdentityMappingCommandInput{
+					Id: id,
+				}
+				if _, err = r.client.DeleteIdentityMappingCommand(input); err != nil {
+					return fmt.Errorf("unable to delete IdentityMapping: %s", err)
+				}
+				return nil
+			})
+		}
+	case !planned.IsNull() && !prior.IsNull():
+		{ //update
+			return r.genericPluginResourceUpdate(planned, func(id, name, class string, dat map[string]interface{}) (*pluginResult, error) {
+				input := &identityMappings.UpdateIdentityMappingCommandInput{
+					Id: id,
+					Body: models.IdentityMappingView{
+						ClassName:     String(class),
+						Configuration: dat,
+						Name:          String(name),
+					},
+				}
+				result := &models.IdentityMappingView{}
+				var err error
+				if r.v7 != nil {
+					_, err = r.v7.Update("/identityMappings/"+id, &input.Body, result)
+				} else {
+					result, _, err = r.client.UpdateIdentityMappingCommand(input)
+				}
+				if err != nil {
+					return nil, apierror.Wrap("unable to update IdentityMapping", err)
+				}
+				return &pluginResult{id: result.Id.String(), name: *result.Name, className: *result.ClassName, configuration: result.Configuration}, nil
+			})
+		}
+	}
+	return nil, nil
+}
+
+func (r resourcePingAccessIdentityMapping) ImportResourceState(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	id, err := importid.Name("identity mapping", r.matches).Resolve(req.ID)
+	if err != nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to resolve the identity mapping import ID: '%s'.\n\nError:\n%s", req.ID, err.Error())), nil
+	}
+	result, _, err := r.client.GetIdentityMappingCommand(&identityMappings.GetIdentityMappingCommandInput{Id: id})
+	if err != nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to retrieve the identity mapping with ID: '%s'.\n\nError:\n%s", id, err.Error())), nil
+	}
+	var v tftypes.Value
+	_, v, _ = marshal(result.Configuration)
+	state, err := r.state(result.Id.String(), *result.Name, *result.ClassName, v, nil)
+	if err != nil {
+		return &tfprotov5.ImportResourceStateResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
+	}
+	return &tfprotov5.ImportResourceStateResponse{
+		ImportedResources: []*tfprotov5.ImportedResource{
+			{
+				TypeName: req.TypeName,
+				State:    &state,
+			},
+		},
+	}, nil
+}
+
+// matches returns the identity mappings which may have the name, for imports by name.
+func (r resourcePingAccessIdentityMapping) matches(name string) ([]importid.Match, error) {
+	result, _, err := r.client.GetIdentityMappingsCommand(&identityMappings.GetIdentityMappingsCommandInput{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	var matches []importid.Match
+	for _, item := range result.Items {
+		matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+	}
+	return matches, nil
+}
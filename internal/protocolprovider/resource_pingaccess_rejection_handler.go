@@ -0,0 +1,207 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go-contrib/asgotypes"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/rejectionHandlers"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/apierror"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/importid"
+)
+
+type resourcePingAccessRejectionHandler struct {
+	client rejectionHandlers.RejectionHandlersAPI
+	genericPluginResource
+}
+
+func (r resourcePingAccessRejectionHandler) schema() *tfprotov5.Schema {
+	return &tfprotov5.Schema{
+		Version: 1,
+		Block: &tfprotov5.SchemaBlock{
+			Description: `Provides configuration for Rejection Handlers within PingAccess.
+-> The PingAccess API does not provider repeatable means of querying a sensitive value, configuration drift of sensitive fields in the ` + "configuration" + ` block is only detected on PingAccess 6.1 and above.
+`,
+			Attributes: []*tfprotov5.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "When creating a new RejectionHandler, this is the ID for the RejectionHandler.",
+				},
+				{
+					Name:        "name",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The rejection handler's name.",
+				},
+				{
+					Name:        "class_name",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The rejection handler's class name.",
+				},
+				{
+					Name:        "configuration",
+					Type:        tftypes.DynamicPseudoType,
+					Required:    true,
+					Description: "The rejection handler's configuration data.",
+				},
+			},
+		},
+	}
+}
+
+func (r resourcePingAccessRejectionHandler) ReadResource(_ context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	values, diags := resourceDynamicValueToTftypesValues(req.CurrentState, r.resourceType())
+	if len(diags) > 0 {
+		return &tfprotov5.ReadResourceResponse{
+			Diagnostics: diags,
+		}, nil
+	}
+	var id string
+	_ = values["id"].As(&id)
+
+	input := &rejectionHandlers.GetRejectionHandlerCommandInput{
+		Id: id,
+	}
+	result, resp, err := r.client.GetRejectionHandlerCommand(input)
+	if isNotFound(resp) {
+		log.Printf("[WARN] RejectionHandler (%s) not found, removing from state", id)
+		return readResourceNotFound(r.resourceType()), nil
+	}
+	if err != nil {
+		return readResourceChangeError(fmt.Errorf("unable to read RejectionHandler with the id '%s': %s", id, err)), nil
+	}
+	if result == nil {
+		return readResourceChangeError(fmt.Errorf("unable to find RejectionHandler with the id '%s', result was nil", id)), nil
+	}
+	desc, err := r.loadDescriptors()
+	if err != nil {
+		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{descriptorsDiagnostic(err)}}, nil
+	}
+	var className string
+	_ = values["class_name"].As(&className)
+	var configuration asgotypes.GoPrimitive
+	_ = values["configuration"].As(&configuration)
+	v := r.refreshConfiguration(desc, className, configuration, result.Configuration, req.Private)
+	state, err := r.state(result.Id.String(), *result.Name, *result.ClassName, v, nil)
+	if err != nil {
+		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
+	}
+	return &tfprotov5.ReadResourceResponse{
+		NewState: &state,
+		Private:  req.Private,
+	}, nil
+}
+
+func (r resourcePingAccessRejectionHandler) ApplyResourceChange(_ context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	planned, err := req.PlannedState.Unmarshal(r.resourceType())
+	if err != nil {
+		return applyResourceChangeError(err), nil
+	}
+	prior, err := req.PriorState.Unmarshal(r.resourceType())
+	if err != nil {
+		return applyResourceChangeError(err), nil
+	}
+
+	switch {
+	case prior.IsNull():
+		{ //create
+			return r.genericPluginResourceCreate(planned, func(name, class string, dat map[string]interface{}) (*pluginResult, error) {
+				input := &rejectionHandlers.AddRejectionHandlerCommandInput{
+					Body: models.RejectionHandlerView{
+						ClassName:     String(class),
+						Configuration: dat,
+						Name:          String(name),
+					},
+				}
+				if result, _, err := r.client.AddRejectionHandlerCommand(input); err != nil {
+					return nil, apierror.Wrap("unable to create RejectionHandler", err)
+				} else {
+					return &pluginResult{id: result.Id.String(), name: *result.Name, className: *result.ClassName, configuration: result.Configuration}, nil
+				}
+			})
+		}
+	case planned.IsNull():
+		{ //delete
+			return genericPluginResourceDelete(req, prior, func(id string) error {
+				input := &rejectionHandlers.DeleteRejectionHandlerCommandInput{
+					Id: id,
+				}
+				if _, err = r.client.DeleteRejectionHandlerCommand(input); err != nil {
+					return fmt.Errorf("unable to delete RejectionHandler: %s", err)
+				}
+				return nil
+			})
+		}
+	case !planned.IsNull() && !prior.IsNull():
+		{ //update
+			return r.genericPluginResourceUpdate(planned, func(id, name, class string, dat map[string]interface{}) (*pluginResult, error) {
+				input := &rejectionHandlers.UpdateRejectionHandlerCommandInput{
+					Id: id,
+					Body: models.RejectionHandlerView{
+						ClassName:     String(class),
+						Configuration: dat,
+						Name:          String(name),
+					},
+				}
+				result := &models.RejectionHandlerView{}
+				var err error
+				if r.v7 != nil {
+					_, err = r.v7.Update("/rejectionHandlers/"+id, &input.Body, result)
+				} else {
+					result, _, err = r.client.UpdateRejectionHandlerCommand(input)
+				}
+				if err != nil {
+					return nil, apierror.Wrap("unable to update RejectionHandler", err)
+				}
+				return &pluginResult{id: result.Id.String(), name: *result.Name, className: *result.ClassName, configuration: result.Configuration}, nil
+			})
+		}
+	}
+	return nil, nil
+}
+
+func (r resourcePingAccessRejectionHandler) ImportResourceState(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	id, err := importid.Name("rejection handler", r.matches).Resolve(req.ID)
+	if err != nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to resolve the rejection handler import ID: '%s'.\n\nError:\n%s", req.ID, err.Error())), nil
+	}
+	result, _, err := r.client.GetRejectionHandlerCommand(&rejectionHandlers.GetRejectionHandlerCommandInput{Id: id})
+	if err != nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to retrieve the rejection handler with ID: '%s'.\n\nError:\n%s", id, err.Error())), nil
+	}
+	var v tftypes.Value
+	_, v, _ = marshal(result.Configuration)
+	state, err := r.state(result.Id.String(), *result.Name, *result.ClassName, v, nil)
+	if err != nil {
+		return &tfprotov5.ImportResourceStateResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
+	}
+	return &tfprotov5.ImportResourceStateResponse{
+		ImportedResources: []*tfprotov5.ImportedResource{
+			{
+				TypeName: req.TypeName,
+				State:    &state,
+			},
+		},
+	}, nil
+}
+
+// matches returns the rejection handlers which may have the name, for imports by name.
+func (r resourcePingAccessRejectionHandler) matches(name string) ([]importid.Match, error) {
+	result, _, err := r.client.GetRejectionHandlersCommand(&rejectionHandlers.GetRejectionHandlersCommandInput{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	var matches []importid.Match
+	for _, item := range result.Items {
+		matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+	}
+	return matches, nil
+}
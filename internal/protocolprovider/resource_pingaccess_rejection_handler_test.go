@@ -0,0 +1,137 @@
+package protocol
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/rejectionHandlers"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func init() {
+	resource.AddTestSweepers("rejection_handler", &resource.Sweeper{
+		Name: "rejection_handler",
+		F: func(r string) error {
+			svc := rejectionHandlers.New(conf)
+			results, _, err := svc.GetRejectionHandlersCommand(&rejectionHandlers.GetRejectionHandlersCommandInput{Filter: "acctest_"})
+			if err != nil {
+				return fmt.Errorf("unable to list rejection_handlers to sweep %s", err)
+			}
+			for _, item := range results.Items {
+				_, err = svc.DeleteRejectionHandlerCommand(&rejectionHandlers.DeleteRejectionHandlerCommandInput{Id: item.Id.String()})
+				if err != nil {
+					return fmt.Errorf("unable to sweep rejection_handler %s because %s", item.Id.String(), err)
+				}
+			}
+			return nil
+		},
+	})
+}
+
+func TestAccPingAccessRejectionHandler(t *testing.T) {
+	resourceName := "pingaccess_rejection_handler.test"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV5ProviderFactories: map[string]func() (tfprotov5.ProviderServer, error){
+			"pingaccess": func() (tfprotov5.ProviderServer, error) {
+				return Server(), nil
+			},
+		},
+		CheckDestroy: testAccCheckPingAccessRejectionHandlerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPingAccessRejectionHandlerConfig("acctest_foo", `{
+			"redirectUrl": "https://localhost/foo"
+		}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPingAccessRejectionHandlerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "acctest_foo"),
+					resource.TestCheckResourceAttr(resourceName, "class_name", "com.pingidentity.pa.rejectionhandlers.RedirectRejectionHandler"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.redirectUrl", "https://localhost/foo"),
+				),
+			},
+			{
+				Config: testAccPingAccessRejectionHandlerConfig("acctest_foo", `{
+			"redirectUrl": "https://localhost/bar"
+		}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPingAccessRejectionHandlerExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "configuration.redirectUrl", "https://localhost/bar"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "name=acctest_foo",
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccPingAccessRejectionHandlerConfigInvalidClassName(`{
+			"redirectUrl": "https://localhost/bar"
+		}`),
+				ExpectError: regexp.MustCompile(`unable to find className 'com.pingidentity.pa.rejectionhandlers.foo'`),
+			},
+		},
+	})
+}
+
+func testAccCheckPingAccessRejectionHandlerDestroy(s *terraform.State) error {
+	return nil
+}
+
+func testAccPingAccessRejectionHandlerConfig(name, configUpdate string) string {
+	return fmt.Sprintf(`
+	resource "pingaccess_rejection_handler" "test" {
+		class_name = "com.pingidentity.pa.rejectionhandlers.RedirectRejectionHandler"
+		name = "%s"
+
+		configuration = %s
+	}
+`, name, configUpdate)
+}
+
+func testAccPingAccessRejectionHandlerConfigInvalidClassName(configUpdate string) string {
+	return fmt.Sprintf(`
+	resource "pingaccess_rejection_handler" "test" {
+		class_name		= "com.pingidentity.pa.rejectionhandlers.foo"
+		name = "acctest_foo"
+		configuration = %s
+	}`, configUpdate)
+}
+
+func testAccCheckPingAccessRejectionHandlerExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" || rs.Primary.ID == "0" {
+			return fmt.Errorf("no rejection_handler ID is set")
+		}
+
+		conn := rejectionHandlers.New(conf)
+		result, _, err := conn.GetRejectionHandlerCommand(&rejectionHandlers.GetRejectionHandlerCommandInput{
+			Id: rs.Primary.ID,
+		})
+
+		if err != nil {
+			return fmt.Errorf("error: RejectionHandler (%s) not found", n)
+		}
+
+		if *result.Name != rs.Primary.Attributes["name"] {
+			return fmt.Errorf("error: RejectionHandler response (%s) didnt match state (%s)", *result.Name, rs.Primary.Attributes["name"])
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,233 @@
+package protocol
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-go-contrib/asgotypes"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/rules"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/apierror"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/importid"
+)
+
+type resourcePingAccessRule struct {
+	client rules.RulesAPI
+	genericPluginResource
+}
+
+// ruleAttributes are the computed attributes of a rule in addition to the common plugin attributes.
+var ruleAttributes = map[string]tftypes.Type{
+	"supported_destinations": tftypes.Set{ElementType: tftypes.String},
+}
+
+// ruleResult returns the additional attributes of the rule returned by the API.
+func ruleResult(result *models.RuleView) map[string]tftypes.Value {
+	dests := []tftypes.Value{}
+	if result.SupportedDestinations != nil {
+		for _, d := range *result.SupportedDestinations {
+			if d != nil {
+				dests = append(dests, tftypes.NewValue(tftypes.String, *d))
+			}
+		}
+	}
+	return map[string]tftypes.Value{
+		"supported_destinations": tftypes.NewValue(ruleAttributes["supported_destinations"], dests),
+	}
+}
+
+func (r resourcePingAccessRule) schema() *tfprotov5.Schema {
+	return &tfprotov5.Schema{
+		Version: 1,
+		Block: &tfprotov5.SchemaBlock{
+			Description: `Provides configuration for Rules within PingAccess.
+-> The PingAccess API does not provider repeatable means of querying a sensitive value, configuration drift of sensitive fields in the ` + "configuration" + ` block is only detected on PingAccess 6.1 and above.
+`,
+			Attributes: []*tfprotov5.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Computed:    true,
+					Description: "When creating a new Rule, this is the ID for the Rule.",
+				},
+				{
+					Name:        "name",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The rule's name.",
+				},
+				{
+					Name:        "class_name",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The rule's class name.",
+				},
+				{
+					Name:        "configuration",
+					Type:        tftypes.DynamicPseudoType,
+					Required:    true,
+					Description: "The rule's configuration data.",
+				},
+				{
+					Name:        "supported_destinations",
+					Type:        tftypes.Set{ElementType: tftypes.String},
+					Computed:    true,
+					Description: "The supported destinations for this rule.",
+				},
+			},
+		},
+	}
+}
+
+func (r resourcePingAccessRule) ReadResource(_ context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
+	values, diags := resourceDynamicValueToTftypesValues(req.CurrentState, r.resourceType())
+	if len(diags) > 0 {
+		return &tfprotov5.ReadResourceResponse{
+			Diagnostics: diags,
+		}, nil
+	}
+	var id string
+	_ = values["id"].As(&id)
+
+	input := &rules.GetRuleCommandInput{
+		Id: id,
+	}
+	result, resp, err := r.client.GetRuleCommand(input)
+	if isNotFound(resp) {
+		log.Printf("[WARN] Rule (%s) not found, removing from state", id)
+		return readResourceNotFound(r.resourceType()), nil
+	}
+	if err != nil {
+		return readResourceChangeError(fmt.Errorf("unable to read Rule with the id '%s': %s", id, err)), nil
+	}
+	if result == nil {
+		return readResourceChangeError(fmt.Errorf("unable to find Rule with the id '%s', result was nil", id)), nil
+	}
+	desc, err := r.loadDescriptors()
+	if err != nil {
+		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{descriptorsDiagnostic(err)}}, nil
+	}
+	var className string
+	_ = values["class_name"].As(&className)
+	var configuration asgotypes.GoPrimitive
+	_ = values["configuration"].As(&configuration)
+	v := r.refreshConfiguration(desc, className, configuration, result.Configuration, req.Private)
+	state, err := r.state(result.Id.String(), *result.Name, *result.ClassName, v, ruleResult(result))
+	if err != nil {
+		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
+	}
+	return &tfprotov5.ReadResourceResponse{
+		NewState: &state,
+		Private:  req.Private,
+	}, nil
+}
+
+func (r resourcePingAccessRule) ApplyResourceChange(_ context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
+	planned, err := req.PlannedState.Unmarshal(r.resourceType())
+	if err != nil {
+		return applyResourceChangeError(err), nil
+	}
+	prior, err := req.PriorState.Unmarshal(r.resourceType())
+	if err != nil {
+		return applyResourceChangeError(err), nil
+	}
+
+	switch {
+	case prior.IsNull():
+		{ //create
+			return r.genericPluginResourceCreate(planned, func(name, class string, dat map[string]interface{}) (*pluginResult, error) {
+				input := &rules.AddRuleCommandInput{
+					Body: models.RuleView{
+						ClassName:     String(class),
+						Configuration: dat,
+						Name:          String(name),
+					},
+				}
+				if result, _, err := r.client.AddRuleCommand(input); err != nil {
+					return nil, apierror.Wrap("unable to create Rule", err)
+				} else {
+					return &pluginResult{id: result.Id.String(), name: *result.Name, className: *result.ClassName, configuration: result.Configuration, attributes: ruleResult(result)}, nil
+				}
+			})
+		}
+	case planned.IsNull():
+		{ //delete
+			return genericPluginResourceDelete(req, prior, func(id string) error {
+				input := &rules.DeleteRuleCommandInput{
+					Id: id,
+				}
+				if _, err = r.client.DeleteRuleCommand(input); err != nil {
+					return fmt.Errorf("unable to delete Rule: %s", err)
+				}
+				return nil
+			})
+		}
+	case !planned.IsNull() && !prior.IsNull():
+		{ //update
+			return r.genericPluginResourceUpdate(planned, func(id, name, class string, dat map[string]interface{}) (*pluginResult, error) {
+				input := &rules.UpdateRuleCommandInput{
+					Id: id,
+					Body: models.RuleView{
+						ClassName:     String(class),
+						Configuration: dat,
+						Name:          String(name),
+					},
+				}
+				result := &models.RuleView{}
+				var err error
+				if r.v7 != nil {
+					_, err = r.v7.Update("/rules/"+id, &input.Body, result)
+				} else {
+					result, _, err = r.client.UpdateRuleCommand(input)
+				}
+				if err != nil {
+					return nil, apierror.Wrap("unable to update Rule", err)
+				}
+				return &pluginResult{id: result.Id.String(), name: *result.Name, className: *result.ClassName, configuration: result.Configuration, attributes: ruleResult(result)}, nil
+			})
+		}
+	}
+	return nil, nil
+}
+
+func (r resourcePingAccessRule) ImportResourceState(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
+	id, err := importid.Name("rule", r.matches).Resolve(req.ID)
+	if err != nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to resolve the rule import ID: '%s'.\n\nError:\n%s", req.ID, err.Error())), nil
+	}
+	result, _, err := r.client.GetRuleCommand(&rules.GetRuleCommandInput{Id: id})
+	if err != nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to retrieve the rule with ID: '%s'.\n\nError:\n%s", id, err.Error())), nil
+	}
+	var v tftypes.Value
+	_, v, _ = marshal(result.Configuration)
+	state, err := r.state(result.Id.String(), *result.Name, *result.ClassName, v, ruleResult(result))
+	if err != nil {
+		return &tfprotov5.ImportResourceStateResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
+	}
+	return &tfprotov5.ImportResourceStateResponse{
+		ImportedResources: []*tfprotov5.ImportedResource{
+			{
+				TypeName: req.TypeName,
+				State:    &state,
+			},
+		},
+	}, nil
+}
+
+// matches returns the rules which may have the name, for imports by name.
+func (r resourcePingAccessRule) matches(name string) ([]importid.Match, error) {
+	result, _, err := r.client.GetRulesCommand(&rules.GetRulesCommandInput{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	var matches []importid.Match
+	for _, item := range result.Items {
+		matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+	}
+	return matches, nil
+}
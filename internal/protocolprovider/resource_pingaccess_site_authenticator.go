@@ -2,8 +2,8 @@ package protocol
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-go-contrib/asgotypes"
 
@@ -11,6 +11,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/services/siteAuthenticators"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/apierror"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/importid"
 )
 
 type resourcePingAccessSiteAuthenticator struct {
@@ -24,7 +26,7 @@ func (r resourcePingAccessSiteAuthenticator) schema() *tfprotov5.Schema {
 		Block: &tfprotov5.SchemaBlock{
 			Description: `Provides configuration for Site Authenticators within PingAccess.
 ~> This resource will store any credentials in the backend state file, please ensure you use an appropriate backend with the relevant encryption/access controls etc for this.
--> The PingAccess API does not provider repeatable means of querying a sensitive value, we are unable to detect configuration drift of any sensitive fields in the ` + "configuration" + ` block.
+-> The PingAccess API does not provider repeatable means of querying a sensitive value, configuration drift of sensitive fields in the ` + "configuration" + ` block is only detected on PingAccess 6.1 and above.
 `,
 			Attributes: []*tfprotov5.SchemaAttribute{
 				{
@@ -69,38 +71,33 @@ func (r resourcePingAccessSiteAuthenticator) ReadResource(_ context.Context, req
 	input := &siteAuthenticators.GetSiteAuthenticatorCommandInput{
 		Id: id,
 	}
-	result, _, err := r.client.GetSiteAuthenticatorCommand(input)
+	result, resp, err := r.client.GetSiteAuthenticatorCommand(input)
+	if isNotFound(resp) {
+		log.Printf("[WARN] SiteAuthenticator (%s) not found, removing from state", id)
+		return readResourceNotFound(r.resourceType()), nil
+	}
 	if err != nil {
-		return readResourceChangeError(fmt.Errorf("unable to find SiteAuthenticator with the id '%s', result was nil", id)), nil
+		return readResourceChangeError(fmt.Errorf("unable to read SiteAuthenticator with the id '%s': %s", id, err)), nil
 	}
 	if result == nil {
 		return readResourceChangeError(fmt.Errorf("unable to find SiteAuthenticator with the id '%s', result was nil", id)), nil
 	}
+	desc, err := r.loadDescriptors()
+	if err != nil {
+		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{descriptorsDiagnostic(err)}}, nil
+	}
 	var className string
 	_ = values["class_name"].As(&className)
 	var configuration asgotypes.GoPrimitive
 	_ = values["configuration"].As(&configuration)
-	var v tftypes.Value
-	if _, ok := configuration.Value.(string); ok {
-		b, _ := json.Marshal(result.Configuration)
-		str := maskConfigFromDescriptors(r.descriptors, className, string(b), configuration.Value.(string))
-		if suppressEquivalentJSONDiffs(configuration.Value.(string), str) {
-			v = tftypes.NewValue(tftypes.String, configuration.Value.(string))
-		} else {
-			v = tftypes.NewValue(tftypes.String, str)
-		}
-	} else {
-		var dat map[string]interface{}
-		s := maskConfigFromDescriptorsAsMap(r.descriptors, className, result.Configuration, configuration.Value.(map[string]interface{}))
-		_ = json.Unmarshal([]byte(s), &dat)
-		_, v, _ = marshal(dat)
-	}
-	state, err := createGenericClassResourceState(r.resourceType(), r.resourceTypes(), result.Id.String(), *result.Name, *result.ClassName, v)
+	v := r.refreshConfiguration(desc, className, configuration, result.Configuration, req.Private)
+	state, err := r.state(result.Id.String(), *result.Name, *result.ClassName, v, nil)
 	if err != nil {
 		return &tfprotov5.ReadResourceResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
 	}
 	return &tfprotov5.ReadResourceResponse{
 		NewState: &state,
+		Private:  req.Private,
 	}, nil
 }
 
@@ -117,7 +114,7 @@ func (r resourcePingAccessSiteAuthenticator) ApplyResourceChange(_ context.Conte
 	switch {
 	case prior.IsNull():
 		{ //create
-			return r.genericPluginResourceCreate(planned, func(name, class string, dat map[string]interface{}) (string, string, string, map[string]interface{}, error) {
+			return r.genericPluginResourceCreate(planned, func(name, class string, dat map[string]interface{}) (*pluginResult, error) {
 				input := &siteAuthenticators.AddSiteAuthenticatorCommandInput{
 					Body: models.SiteAuthenticatorView{
 						ClassName:     String(class),
@@ -126,9 +123,9 @@ func (r resourcePingAccessSiteAuthenticator) ApplyResourceChange(_ context.Conte
 					},
 				}
 				if result, _, err := r.client.AddSiteAuthenticatorCommand(input); err != nil {
-					return "", "", "", nil, fmt.Errorf("unable to create SiteAuthenticator: %s", err)
+					return nil, apierror.Wrap("unable to create SiteAuthenticator", err)
 				} else {
-					return result.Id.String(), *result.Name, *result.ClassName, result.Configuration, nil
+					return &pluginResult{id: result.Id.String(), name: *result.Name, className: *result.ClassName, configuration: result.Configuration}, nil
 				}
 			})
 		}
@@ -146,7 +143,7 @@ func (r resourcePingAccessSiteAuthenticator) ApplyResourceChange(_ context.Conte
 		}
 	case !planned.IsNull() && !prior.IsNull():
 		{ //update
-			return r.genericPluginResourceUpdate(planned, func(id, name, class string, dat map[string]interface{}) (string, string, string, map[string]interface{}, error) {
+			return r.genericPluginResourceUpdate(planned, func(id, name, class string, dat map[string]interface{}) (*pluginResult, error) {
 				input := &siteAuthenticators.UpdateSiteAuthenticatorCommandInput{
 					Id: id,
 					Body: models.SiteAuthenticatorView{
@@ -155,11 +152,17 @@ func (r resourcePingAccessSiteAuthenticator) ApplyResourceChange(_ context.Conte
 						Name:          String(name),
 					},
 				}
-				if result, _, err := r.client.UpdateSiteAuthenticatorCommand(input); err != nil {
-					return "", "", "", nil, fmt.Errorf("unable to update AccessTokenValidator: %s", err)
+				result := &models.SiteAuthenticatorView{}
+				var err error
+				if r.v7 != nil {
+					_, err = r.v7.Update("/siteAuthenticators/"+id, &input.Body, result)
 				} else {
-					return result.Id.String(), *result.Name, *result.ClassName, result.Configuration, nil
+					result, _, err = r.client.UpdateSiteAuthenticatorCommand(input)
 				}
+				if err != nil {
+					return nil, apierror.Wrap("unable to update SiteAuthenticator", err)
+				}
+				return &pluginResult{id: result.Id.String(), name: *result.Name, className: *result.ClassName, configuration: result.Configuration}, nil
 			})
 		}
 	}
@@ -167,5 +170,44 @@ func (r resourcePingAccessSiteAuthenticator) ApplyResourceChange(_ context.Conte
 }
 
 func (r resourcePingAccessSiteAuthenticator) ImportResourceState(_ context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
-	return &tfprotov5.ImportResourceStateResponse{}, nil
+	id, err := importid.Name("site authenticator", r.matches).Resolve(req.ID)
+	if err != nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to resolve the site authenticator import ID: '%s'.\n\nError:\n%s", req.ID, err.Error())), nil
+	}
+	result, _, err := r.client.GetSiteAuthenticatorCommand(&siteAuthenticators.GetSiteAuthenticatorCommandInput{Id: id})
+	if err != nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to retrieve the site authenticator with ID: '%s'.\n\nError:\n%s", id, err.Error())), nil
+	}
+	if result.Name == nil || result.ClassName == nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to import the site authenticator with ID: '%s', PingAccess did not return its name and class name.", id)), nil
+	}
+	_, v, err := marshal(result.Configuration)
+	if err != nil {
+		return importResourceError(fmt.Sprintf("The provider was unable to read the configuration of the site authenticator with ID: '%s'.\n\nError:\n%s", id, err.Error())), nil
+	}
+	state, err := r.state(result.Id.String(), *result.Name, *result.ClassName, v, nil)
+	if err != nil {
+		return &tfprotov5.ImportResourceStateResponse{Diagnostics: []*tfprotov5.Diagnostic{stateEncodingDiagnostic(err)}}, nil
+	}
+	return &tfprotov5.ImportResourceStateResponse{
+		ImportedResources: []*tfprotov5.ImportedResource{
+			{
+				TypeName: req.TypeName,
+				State:    &state,
+			},
+		},
+	}, nil
+}
+
+// matches returns the site authenticators which may have the name, for imports by name.
+func (r resourcePingAccessSiteAuthenticator) matches(name string) ([]importid.Match, error) {
+	result, _, err := r.client.GetSiteAuthenticatorsCommand(&siteAuthenticators.GetSiteAuthenticatorsCommandInput{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	var matches []importid.Match
+	for _, item := range result.Items {
+		matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+	}
+	return matches, nil
 }
@@ -2,10 +2,17 @@ package protocol
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/iwarapter/terraform-provider-pingaccess/internal/sdkv2provider"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
@@ -303,3 +310,80 @@ func testAccCheckPingAccessSiteAuthenticatorExists(n string) resource.TestCheckF
 		return nil
 	}
 }
+
+type siteAuthenticatorsStub struct {
+	siteAuthenticators.SiteAuthenticatorsAPI
+	result *models.SiteAuthenticatorView
+}
+
+func (s siteAuthenticatorsStub) GetSiteAuthenticatorCommand(input *siteAuthenticators.GetSiteAuthenticatorCommandInput) (*models.SiteAuthenticatorView, *http.Response, error) {
+	if s.result == nil {
+		return nil, nil, fmt.Errorf("site authenticator %s not found", input.Id)
+	}
+	return s.result, nil, nil
+}
+
+func Test_resourcePingAccessSiteAuthenticator_ImportResourceState(t *testing.T) {
+	id := json.Number("1")
+	tests := []struct {
+		name   string
+		result *models.SiteAuthenticatorView
+		err    string
+	}{
+		{
+			name: "we can import a site authenticator",
+			result: &models.SiteAuthenticatorView{
+				Id:            id,
+				Name:          String("demo"),
+				ClassName:     String("com.pingidentity.pa.siteauthenticators.BasicAuthTargetSiteAuthenticator"),
+				Configuration: map[string]interface{}{"username": "cheese"},
+			},
+		},
+		{
+			name: "missing name is reported",
+			result: &models.SiteAuthenticatorView{
+				Id:            id,
+				ClassName:     String("com.pingidentity.pa.siteauthenticators.BasicAuthTargetSiteAuthenticator"),
+				Configuration: map[string]interface{}{},
+			},
+			err: "did not return its name and class name",
+		},
+		{
+			name: "missing class name is reported",
+			result: &models.SiteAuthenticatorView{
+				Id:            id,
+				Name:          String("demo"),
+				Configuration: map[string]interface{}{},
+			},
+			err: "did not return its name and class name",
+		},
+		{
+			name: "api errors are reported",
+			err:  "site authenticator 1 not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := resourcePingAccessSiteAuthenticator{client: siteAuthenticatorsStub{result: tt.result}}
+			resp, err := r.ImportResourceState(context.Background(), &tfprotov5.ImportResourceStateRequest{TypeName: "pingaccess_site_authenticator", ID: "1"})
+			require.NoError(t, err)
+			if tt.err != "" {
+				require.Len(t, resp.Diagnostics, 1)
+				assert.Contains(t, resp.Diagnostics[0].Detail, tt.err)
+				assert.Empty(t, resp.ImportedResources)
+				return
+			}
+			require.Empty(t, resp.Diagnostics)
+			require.Len(t, resp.ImportedResources, 1)
+			state, err := resp.ImportedResources[0].State.Unmarshal(r.resourceType())
+			require.NoError(t, err)
+			values := map[string]tftypes.Value{}
+			require.NoError(t, state.As(&values))
+			var name, className string
+			require.NoError(t, values["name"].As(&name))
+			require.NoError(t, values["class_name"].As(&className))
+			assert.Equal(t, "demo", name)
+			assert.Equal(t, *tt.result.ClassName, className)
+		})
+	}
+}
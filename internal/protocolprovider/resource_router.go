@@ -2,6 +2,7 @@ package protocol
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 )
@@ -13,121 +14,67 @@ func (e errUnsupportedResource) Error() string {
 }
 
 func (p *provider) ValidateResourceTypeConfig(ctx context.Context, req *tfprotov5.ValidateResourceTypeConfigRequest) (*tfprotov5.ValidateResourceTypeConfigResponse, error) {
-	switch req.TypeName {
-	case "pingaccess_access_token_validator":
-		res := &resourcePingAccessAccessTokenValidator{}
-		if p.client != nil {
-			res.client = p.client.AccessTokenValidators
-			res.descriptors = p.client.AccessTokenValidatorsDescriptors
-		}
-		return res.ValidateResourceTypeConfig(ctx, req)
-	case "pingaccess_site_authenticator":
-		res := &resourcePingAccessSiteAuthenticator{}
-		if p.client != nil {
-			res.client = p.client.SiteAuthenticators
-			res.descriptors = p.client.SiteAuthenticatorsDescriptors
-		}
-		return res.ValidateResourceTypeConfig(ctx, req)
+	res, err := p.resourceServer(req.TypeName)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errUnsupportedResource(req.TypeName)
+	return res.ValidateResourceTypeConfig(ctx, req)
 }
 
 func (p *provider) UpgradeResourceState(ctx context.Context, req *tfprotov5.UpgradeResourceStateRequest) (*tfprotov5.UpgradeResourceStateResponse, error) {
-	switch req.TypeName {
-	case "pingaccess_access_token_validator":
-		res := &resourcePingAccessAccessTokenValidator{}
-		if p.client != nil {
-			res.client = p.client.AccessTokenValidators
-			res.descriptors = p.client.AccessTokenValidatorsDescriptors
-		}
-		return res.UpgradeResourceState(ctx, req)
-	case "pingaccess_site_authenticator":
-		res := &resourcePingAccessSiteAuthenticator{}
-		if p.client != nil {
-			res.client = p.client.SiteAuthenticators
-			res.descriptors = p.client.SiteAuthenticatorsDescriptors
-		}
-		return res.UpgradeResourceState(ctx, req)
+	res, err := p.resourceServer(req.TypeName)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errUnsupportedResource(req.TypeName)
+	return res.UpgradeResourceState(ctx, req)
 }
 
 func (p *provider) ReadResource(ctx context.Context, req *tfprotov5.ReadResourceRequest) (*tfprotov5.ReadResourceResponse, error) {
-	switch req.TypeName {
-	case "pingaccess_access_token_validator":
-		res := &resourcePingAccessAccessTokenValidator{}
-		if p.client != nil {
-			res.client = p.client.AccessTokenValidators
-			res.descriptors = p.client.AccessTokenValidatorsDescriptors
-		}
-		return res.ReadResource(ctx, req)
-	case "pingaccess_site_authenticator":
-		res := &resourcePingAccessSiteAuthenticator{}
-		if p.client != nil {
-			res.client = p.client.SiteAuthenticators
-			res.descriptors = p.client.SiteAuthenticatorsDescriptors
-		}
-		return res.ReadResource(ctx, req)
+	res, err := p.resourceServer(req.TypeName)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errUnsupportedResource(req.TypeName)
+	if p.client != nil && p.client.offline {
+		// the offline mode is limited to plan time validation, the prior state is kept so plans against existing
+		// state still validate the configuration
+		return &tfprotov5.ReadResourceResponse{
+			NewState: req.CurrentState,
+			Private:  req.Private,
+			Diagnostics: []*tfprotov5.Diagnostic{
+				{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "Resource not refreshed",
+					Detail:   fmt.Sprintf("The provider is configured offline, the state of %s was not refreshed from PingAccess.", req.TypeName),
+				},
+			},
+		}, nil
+	}
+	return res.ReadResource(ctx, req)
 }
 
 func (p *provider) PlanResourceChange(ctx context.Context, req *tfprotov5.PlanResourceChangeRequest) (*tfprotov5.PlanResourceChangeResponse, error) {
-	switch req.TypeName {
-	case "pingaccess_access_token_validator":
-		res := &resourcePingAccessAccessTokenValidator{}
-		if p.client != nil {
-			res.client = p.client.AccessTokenValidators
-			res.descriptors = p.client.AccessTokenValidatorsDescriptors
-		}
-		return res.PlanResourceChange(ctx, req)
-	case "pingaccess_site_authenticator":
-		res := &resourcePingAccessSiteAuthenticator{}
-		if p.client != nil {
-			res.client = p.client.SiteAuthenticators
-			res.descriptors = p.client.SiteAuthenticatorsDescriptors
-		}
-		return res.PlanResourceChange(ctx, req)
+	res, err := p.resourceServer(req.TypeName)
+	if err != nil {
+		return nil, err
+	}
+	if diags := p.versionRequirementDiagnostics(req.TypeName, req.ProposedNewState); len(diags) > 0 {
+		return &tfprotov5.PlanResourceChangeResponse{Diagnostics: diags}, nil
 	}
-	return nil, errUnsupportedResource(req.TypeName)
+	return res.PlanResourceChange(ctx, req)
 }
 
 func (p *provider) ApplyResourceChange(ctx context.Context, req *tfprotov5.ApplyResourceChangeRequest) (*tfprotov5.ApplyResourceChangeResponse, error) {
-	switch req.TypeName {
-	case "pingaccess_access_token_validator":
-		res := &resourcePingAccessAccessTokenValidator{}
-		if p.client != nil {
-			res.client = p.client.AccessTokenValidators
-			res.descriptors = p.client.AccessTokenValidatorsDescriptors
-		}
-		return res.ApplyResourceChange(ctx, req)
-	case "pingaccess_site_authenticator":
-		res := &resourcePingAccessSiteAuthenticator{}
-		if p.client != nil {
-			res.client = p.client.SiteAuthenticators
-			res.descriptors = p.client.SiteAuthenticatorsDescriptors
-		}
-		return res.ApplyResourceChange(ctx, req)
+	res, err := p.resourceServer(req.TypeName)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errUnsupportedResource(req.TypeName)
+	return res.ApplyResourceChange(ctx, req)
 }
 
 func (p *provider) ImportResourceState(ctx context.Context, req *tfprotov5.ImportResourceStateRequest) (*tfprotov5.ImportResourceStateResponse, error) {
-	switch req.TypeName {
-	case "pingaccess_access_token_validator":
-		res := &resourcePingAccessAccessTokenValidator{}
-		if p.client != nil {
-			res.client = p.client.AccessTokenValidators
-			res.descriptors = p.client.AccessTokenValidatorsDescriptors
-		}
-		return res.ImportResourceState(ctx, req)
-	case "pingaccess_site_authenticator":
-		res := &resourcePingAccessSiteAuthenticator{}
-		if p.client != nil {
-			res.client = p.client.SiteAuthenticators
-			res.descriptors = p.client.SiteAuthenticatorsDescriptors
-		}
-		return res.ImportResourceState(ctx, req)
+	res, err := p.resourceServer(req.TypeName)
+	if err != nil {
+		return nil, err
 	}
-	return nil, errUnsupportedResource(req.TypeName)
+	return res.ImportResourceState(ctx, req)
 }
@@ -3,6 +3,7 @@ package protocol
 import (
 	"context"
 	"os"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -14,8 +15,8 @@ type provider struct {
 	resourceSchemas    map[string]*tfprotov5.Schema
 	dataSourceSchemas  map[string]*tfprotov5.Schema
 
-	resourceRouter   map[string]tfprotov5.ResourceServer
-	dataSourceRouter map[string]tfprotov5.DataSourceServer
+	resources   map[string]resourceRegistration
+	dataSources map[string]dataSourceRegistration
 
 	client *paClient
 }
@@ -44,10 +45,14 @@ func (p *provider) ConfigureProvider(_ context.Context, req *tfprotov5.Configure
 	}
 	configType := tftypes.Object{
 		AttributeTypes: map[string]tftypes.Type{
-			"username": tftypes.String,
-			"password": tftypes.String,
-			"context":  tftypes.String,
-			"base_url": tftypes.String,
+			"username":             tftypes.String,
+			"password":             tftypes.String,
+			"context":              tftypes.String,
+			"base_url":             tftypes.String,
+			"descriptor_cache_dir": tftypes.String,
+			"offline":              tftypes.Bool,
+			"offline_version":      tftypes.String,
+			"offline_descriptors":  tftypes.String,
 		},
 	}
 	val, err := req.Config.Unmarshal(configType)
@@ -87,6 +92,43 @@ func (p *provider) ConfigureProvider(_ context.Context, req *tfprotov5.Configure
 				&tftypes.AttributePath{})}}, nil
 		}
 	}
+	if values["descriptor_cache_dir"].IsKnown() && !values["descriptor_cache_dir"].IsNull() {
+		err = values["descriptor_cache_dir"].As(&c.DescriptorCacheDir)
+		if err != nil {
+			return &tfprotov5.ConfigureProviderResponse{Diagnostics: []*tfprotov5.Diagnostic{unexpectedProviderConfigDiagnostic(err,
+				&tftypes.AttributePath{})}}, nil
+		}
+	} else {
+		// like the DefaultFunc of the sdkv2 provider, the environment is only used when the attribute is not set
+		c.DescriptorCacheDir = os.Getenv("PINGACCESS_DESCRIPTOR_CACHE_DIR")
+	}
+	if values["offline"].IsKnown() && !values["offline"].IsNull() {
+		err = values["offline"].As(&c.Offline)
+		if err != nil {
+			return &tfprotov5.ConfigureProviderResponse{Diagnostics: []*tfprotov5.Diagnostic{unexpectedProviderConfigDiagnostic(err,
+				&tftypes.AttributePath{})}}, nil
+		}
+	} else if v, err := strconv.ParseBool(os.Getenv("PINGACCESS_OFFLINE")); err == nil {
+		c.Offline = v
+	}
+	if values["offline_version"].IsKnown() && !values["offline_version"].IsNull() {
+		err = values["offline_version"].As(&c.OfflineVersion)
+		if err != nil {
+			return &tfprotov5.ConfigureProviderResponse{Diagnostics: []*tfprotov5.Diagnostic{unexpectedProviderConfigDiagnostic(err,
+				&tftypes.AttributePath{})}}, nil
+		}
+	} else {
+		c.OfflineVersion = os.Getenv("PINGACCESS_OFFLINE_VERSION")
+	}
+	if values["offline_descriptors"].IsKnown() && !values["offline_descriptors"].IsNull() {
+		err = values["offline_descriptors"].As(&c.OfflineDescriptors)
+		if err != nil {
+			return &tfprotov5.ConfigureProviderResponse{Diagnostics: []*tfprotov5.Diagnostic{unexpectedProviderConfigDiagnostic(err,
+				&tftypes.AttributePath{})}}, nil
+		}
+	} else {
+		c.OfflineDescriptors = os.Getenv("PINGACCESS_OFFLINE_DESCRIPTORS")
+	}
 	if v := os.Getenv("PINGACCESS_USERNAME"); v != "" {
 		c.Username = v
 	}
@@ -117,6 +159,16 @@ func (p *provider) StopProvider(ctx context.Context, req *tfprotov5.StopProvider
 }
 
 func Server() tfprotov5.ProviderServer {
+	resources := resourceRegistry()
+	resourceSchemas := map[string]*tfprotov5.Schema{}
+	for name, r := range resources {
+		resourceSchemas[name] = r.schema()
+	}
+	dataSources := dataSourceRegistry()
+	dataSourceSchemas := map[string]*tfprotov5.Schema{}
+	for name, d := range dataSources {
+		dataSourceSchemas[name] = d.schema()
+	}
 	return &provider{
 		providerSchema: &tfprotov5.Schema{
 			Version: 0,
@@ -137,6 +189,34 @@ func Server() tfprotov5.ProviderServer {
 						Description:     "The context path of the pingaccess API.",
 						DescriptionKind: tfprotov5.StringKindPlain,
 					},
+					{
+						Name:            "descriptor_cache_dir",
+						Optional:        true,
+						Type:            tftypes.String,
+						Description:     "A directory to cache plugin descriptors retrieved from the pingaccess API, these are stored per PingAccess version.",
+						DescriptionKind: tfprotov5.StringKindPlain,
+					},
+					{
+						Name:            "offline",
+						Optional:        true,
+						Type:            tftypes.Bool,
+						Description:     "Configure the provider without connecting to the pingaccess API, plugin configuration is validated using local descriptors and any operation requiring the API will fail.",
+						DescriptionKind: tfprotov5.StringKindPlain,
+					},
+					{
+						Name:            "offline_descriptors",
+						Optional:        true,
+						Type:            tftypes.String,
+						Description:     "A JSON file of plugin descriptors to use when the provider is offline, these take precedence over the descriptor cache and bundled descriptors.",
+						DescriptionKind: tfprotov5.StringKindPlain,
+					},
+					{
+						Name:            "offline_version",
+						Optional:        true,
+						Type:            tftypes.String,
+						Description:     "The PingAccess version to validate against when the provider is offline.",
+						DescriptionKind: tfprotov5.StringKindPlain,
+					},
 					{
 						Name:            "password",
 						Optional:        true,
@@ -155,20 +235,10 @@ func Server() tfprotov5.ProviderServer {
 				},
 			},
 		},
-		dataSourceSchemas: map[string]*tfprotov5.Schema{
-			"pingaccess_trusted_certificate_group": dataPingAccessTrustedCertificateGroups{}.schema(),
-		},
-		dataSourceRouter: map[string]tfprotov5.DataSourceServer{
-			"pingaccess_trusted_certificate_group": dataPingAccessTrustedCertificateGroups{},
-		},
-		resourceSchemas: map[string]*tfprotov5.Schema{
-			"pingaccess_access_token_validator": resourcePingAccessAccessTokenValidator{}.schema(),
-			"pingaccess_site_authenticator":     resourcePingAccessSiteAuthenticator{}.schema(),
-		},
-		resourceRouter: map[string]tfprotov5.ResourceServer{
-			"pingaccess_access_token_validator": resourcePingAccessAccessTokenValidator{},
-			"pingaccess_site_authenticator":     resourcePingAccessSiteAuthenticator{},
-		},
+		dataSourceSchemas: dataSourceSchemas,
+		dataSources:       dataSources,
+		resourceSchemas:   resourceSchemas,
+		resources:         resources,
 	}
 }
 
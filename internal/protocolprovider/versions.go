@@ -0,0 +1,63 @@
+package protocol
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/paversion"
+)
+
+// versionRequirementDiagnostics validates the planned value of a resource against the version requirements declared in
+// paversion for the type and its attributes, so unsupported configuration is reported during plan instead of by the
+// API. Nothing is checked before the provider is configured or when the resource is being destroyed.
+func (p *provider) versionRequirementDiagnostics(typeName string, planned *tfprotov5.DynamicValue) []*tfprotov5.Diagnostic {
+	if p.client == nil || planned == nil {
+		return nil
+	}
+	reg, ok := p.resources[typeName]
+	if !ok {
+		return nil
+	}
+	val, err := planned.Unmarshal(reg.schema().ValueType())
+	if err != nil || val.IsNull() {
+		return nil
+	}
+	if constraint := paversion.Unsatisfied(p.client.apiVersion, typeName); constraint != "" {
+		return []*tfprotov5.Diagnostic{versionRequirementDiagnostic(typeName, constraint, p.client.apiVersion.String(), nil)}
+	}
+	values := map[string]tftypes.Value{}
+	if err := val.As(&values); err != nil {
+		return nil
+	}
+	var diags []*tfprotov5.Diagnostic
+	for _, attr := range paversion.Attributes(typeName) {
+		if v, ok := values[attr]; !ok || v.IsNull() {
+			continue
+		}
+		if constraint := paversion.Unsatisfied(p.client.apiVersion, typeName+"."+attr); constraint != "" {
+			diags = append(diags, versionRequirementDiagnostic(fmt.Sprintf("attribute %q", attr), constraint, p.client.apiVersion.String(), tftypes.NewAttributePath().WithAttributeName(attr)))
+		}
+	}
+	return diags
+}
+
+// dataSourceVersionDiagnostics validates the data source type against the version requirements declared in paversion.
+func (p *provider) dataSourceVersionDiagnostics(typeName string) []*tfprotov5.Diagnostic {
+	if p.client == nil {
+		return nil
+	}
+	if constraint := paversion.Unsatisfied(p.client.apiVersion, typeName); constraint != "" {
+		return []*tfprotov5.Diagnostic{versionRequirementDiagnostic(typeName, constraint, p.client.apiVersion.String(), nil)}
+	}
+	return nil
+}
+
+func versionRequirementDiagnostic(subject, constraint, version string, attribute *tftypes.AttributePath) *tfprotov5.Diagnostic {
+	return &tfprotov5.Diagnostic{
+		Severity:  tfprotov5.DiagnosticSeverityError,
+		Summary:   "Unsupported PingAccess version",
+		Detail:    fmt.Sprintf("%s requires PingAccess %s, the server is running %s", subject, constraint, version),
+		Attribute: attribute,
+	}
+}
@@ -0,0 +1,67 @@
+package protocol
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/paversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionRequirementDiagnostics(t *testing.T) {
+	schema := func() *tfprotov5.Schema {
+		return &tfprotov5.Schema{Block: &tfprotov5.SchemaBlock{Attributes: []*tfprotov5.SchemaAttribute{
+			{Name: "name", Type: tftypes.String, Required: true},
+			{Name: "query_param_config", Type: tftypes.String, Optional: true},
+		}}}
+	}
+	planned := func(queryParamConfig interface{}) *tfprotov5.DynamicValue {
+		typ := schema().ValueType()
+		v, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, map[string]tftypes.Value{
+			"name":               tftypes.NewValue(tftypes.String, "test"),
+			"query_param_config": tftypes.NewValue(tftypes.String, queryParamConfig),
+		}))
+		require.NoError(t, err)
+		return &v
+	}
+	client := func(version string) *paClient {
+		v, err := paversion.Parse(version)
+		require.NoError(t, err)
+		return &paClient{apiVersion: v}
+	}
+
+	p := Server().(*provider)
+	p.resources["pingaccess_application_resource"] = resourceRegistration{
+		schema: schema,
+		server: func(*paClient, genericPluginResource) tfprotov5.ResourceServer { return nil },
+	}
+	assert.Empty(t, p.versionRequirementDiagnostics("pingaccess_application_resource", planned("x")), "nothing is checked before the provider is configured")
+
+	p.client = client("6.0.0")
+	diags := p.versionRequirementDiagnostics("pingaccess_application_resource", planned("x"))
+	require.Len(t, diags, 1)
+	assert.Equal(t, `attribute "query_param_config" requires PingAccess >= 6.1, the server is running 6.0.0`, diags[0].Detail)
+	assert.Equal(t, tftypes.NewAttributePath().WithAttributeName("query_param_config"), diags[0].Attribute)
+	assert.Empty(t, p.versionRequirementDiagnostics("pingaccess_application_resource", planned(nil)), "unset attributes are ignored")
+
+	typ := schema().ValueType()
+	destroy, err := tfprotov5.NewDynamicValue(typ, tftypes.NewValue(typ, nil))
+	require.NoError(t, err)
+	assert.Empty(t, p.versionRequirementDiagnostics("pingaccess_application_resource", &destroy))
+
+	resp, err := p.PlanResourceChange(context.Background(), &tfprotov5.PlanResourceChangeRequest{TypeName: "pingaccess_application_resource", ProposedNewState: planned("x")})
+	require.NoError(t, err)
+	assert.Equal(t, diags, resp.Diagnostics)
+
+	p.client = client("6.1.0")
+	assert.Empty(t, p.versionRequirementDiagnostics("pingaccess_application_resource", planned("x")))
+
+	p.client = client("5.3.2")
+	diags = p.dataSourceVersionDiagnostics("pingaccess_pingfederate_runtime_metadata")
+	require.Len(t, diags, 1)
+	assert.Equal(t, "pingaccess_pingfederate_runtime_metadata requires PingAccess >= 6.0, the server is running 5.3.2", diags[0].Detail)
+	assert.Empty(t, p.dataSourceVersionDiagnostics("pingaccess_trusted_certificate_group"))
+}
@@ -0,0 +1,335 @@
+package sdkv2provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/applications"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/paversion"
+)
+
+// applicationInlineResourceSchema is the schema of the resource blocks managed by the application, it mirrors the
+// pingaccess_application_resource schema without the attributes which are implied by the application.
+func applicationInlineResourceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Description: "The resources of the application, excluding the root resource. When set the full list of resources is managed by the application, " +
+			"resources created outside of Terraform (or with `pingaccess_application_resource`) are removed.",
+		Elem: applicationInlineResource(),
+	}
+}
+
+func applicationInlineResource() *schema.Resource {
+	s := resourcePingAccessApplicationResourceSchema()
+	delete(s, "application_id")
+	delete(s, "path_prefixes")
+	delete(s, "root_resource")
+	s["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The ID of the resource.",
+	}
+	return &schema.Resource{Schema: s}
+}
+
+// applicationResourceChanges is the set of API calls required to move the resources of an application from the old to
+// the new resource blocks, resources are matched by name.
+type applicationResourceChanges struct {
+	deletes []*models.ResourceView
+	updates []*models.ResourceView
+	creates []*models.ResourceView
+}
+
+func (c applicationResourceChanges) empty() bool {
+	return len(c.deletes) == 0 && len(c.updates) == 0 && len(c.creates) == 0
+}
+
+// diffApplicationResources compares the old and new resource blocks by name, resources which keep their name but
+// change any other attribute are updated in place using the ID from the old block.
+func diffApplicationResources(o, n []interface{}) applicationResourceChanges {
+	hash := schema.HashResource(applicationInlineResource())
+	old := map[string]map[string]interface{}{}
+	for _, raw := range o {
+		r := raw.(map[string]interface{})
+		old[r["name"].(string)] = r
+	}
+
+	var changes applicationResourceChanges
+	seen := map[string]bool{}
+	for _, raw := range n {
+		r := raw.(map[string]interface{})
+		name := r["name"].(string)
+		seen[name] = true
+		prev, ok := old[name]
+		switch {
+		case !ok:
+			changes.creates = append(changes.creates, expandApplicationInlineResource(r))
+		case hash(prev) != hash(r):
+			rv := expandApplicationInlineResource(r)
+			rv.Id = json.Number(prev["id"].(string))
+			changes.updates = append(changes.updates, rv)
+		}
+	}
+	for _, raw := range o {
+		r := raw.(map[string]interface{})
+		if !seen[r["name"].(string)] {
+			rv := expandApplicationInlineResource(r)
+			rv.Id = json.Number(r["id"].(string))
+			changes.deletes = append(changes.deletes, rv)
+		}
+	}
+	for _, l := range [][]*models.ResourceView{changes.deletes, changes.updates, changes.creates} {
+		sort.Slice(l, func(i, j int) bool { return *l[i].Name < *l[j].Name })
+	}
+	return changes
+}
+
+// applyApplicationResourceChanges applies the changes to the resources of the application, deletes are applied first so
+// names and path patterns can be reused and creates last once any patterns they take over have been released.
+func applyApplicationResourceChanges(client paClient, applicationID string, changes applicationResourceChanges) error {
+	svc := client.Applications
+	id, _ := strconv.Atoi(applicationID)
+	for _, r := range changes.deletes {
+		if _, err := svc.DeleteApplicationResourceCommand(&applications.DeleteApplicationResourceCommandInput{
+			ApplicationId: applicationID,
+			ResourceId:    r.Id.String(),
+		}); err != nil {
+			return fmt.Errorf("unable to delete ApplicationResource %s: %s", *r.Name, err)
+		}
+	}
+	for _, r := range changes.updates {
+		r.ApplicationId = Int(id)
+		if _, _, err := svc.UpdateApplicationResourceCommand(&applications.UpdateApplicationResourceCommandInput{
+			ApplicationId: applicationID,
+			ResourceId:    r.Id.String(),
+			Body:          *r,
+		}); err != nil {
+			return fmt.Errorf("unable to update ApplicationResource %s: %s", *r.Name, err)
+		}
+	}
+	for _, r := range changes.creates {
+		r.ApplicationId = Int(id)
+		if _, _, err := svc.AddApplicationResourceCommand(&applications.AddApplicationResourceCommandInput{
+			Id:   applicationID,
+			Body: *r,
+		}); err != nil {
+			return fmt.Errorf("unable to create ApplicationResource %s: %s", *r.Name, err)
+		}
+	}
+	return nil
+}
+
+// applicationResourcesManaged reports whether the application manages its resources, which is the case as long as any
+// resource blocks are in the state or configuration.
+func applicationResourcesManaged(d *schema.ResourceData) bool {
+	o, n := d.GetChange("resource")
+	return o.(*schema.Set).Len() > 0 || n.(*schema.Set).Len() > 0
+}
+
+// syncApplicationResources applies the changes to the resource blocks, if any call fails the resources are read back
+// so the state records the changes which were applied.
+func syncApplicationResources(d *schema.ResourceData, client paClient, changes applicationResourceChanges) diag.Diagnostics {
+	if changes.empty() {
+		return nil
+	}
+	if err := applyApplicationResourceChanges(client, d.Id(), changes); err != nil {
+		return append(readApplicationResources(d, client), diag.FromErr(err)...)
+	}
+	return nil
+}
+
+// readApplicationResources sets the resource blocks from every resource of the application except the root resource,
+// so resources created outside of Terraform show up as changes to be removed.
+func readApplicationResources(d *schema.ResourceData, client paClient) diag.Diagnostics {
+	result, _, err := client.Applications.GetApplicationResourcesCommand(&applications.GetApplicationResourcesCommandInput{Id: d.Id()})
+	if err != nil {
+		return diag.Errorf("unable to read ApplicationResources: %s", err)
+	}
+	var resources []interface{}
+	for _, rv := range result.Items {
+		if rv == nil || (rv.RootResource != nil && *rv.RootResource) {
+			continue
+		}
+		resources = append(resources, flattenApplicationInlineResource(rv))
+	}
+	if err := d.Set("resource", resources); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// applicationResourcesDiff checks the resource block names are unique, as resources are matched by name.
+func applicationResourcesDiff(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	counts := map[string]int{}
+	for _, raw := range d.Get("resource").(*schema.Set).List() {
+		if name, _ := raw.(map[string]interface{})["name"].(string); name != "" && name != unknownVariableValue {
+			counts[name]++
+		}
+	}
+	var duplicates []string
+	for name, count := range counts {
+		if count > 1 {
+			duplicates = append(duplicates, name)
+		}
+	}
+	if len(duplicates) > 0 {
+		sort.Strings(duplicates)
+		return fmt.Errorf("resource names must be unique, duplicated: %s", strings.Join(duplicates, ", "))
+	}
+	return nil
+}
+
+// applicationResourcesVersionDiff validates the resource blocks against the version requirements of the
+// pingaccess_application_resource attributes, as versionRequirementsDiff does for the standalone resource.
+func applicationResourcesVersionDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(paClient)
+	if !ok {
+		return nil
+	}
+	attrs := paversion.Attributes("pingaccess_application_resource")
+	unsatisfied := map[string]string{}
+	for _, raw := range d.Get("resource").(*schema.Set).List() {
+		r := raw.(map[string]interface{})
+		for _, attr := range attrs {
+			if _, ok := unsatisfied[attr]; ok || !inlineResourceAttributeSet(r[attr]) {
+				continue
+			}
+			if constraint := client.checkVersionRequirement("pingaccess_application_resource." + attr); constraint != "" {
+				unsatisfied[attr] = constraint
+			}
+		}
+	}
+	var errs []string
+	for _, attr := range attrs {
+		if constraint, ok := unsatisfied[attr]; ok {
+			errs = append(errs, fmt.Sprintf("attribute \"resource.%s\" requires PingAccess %s", attr, constraint))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s, the server is running %s", strings.Join(errs, ", "), client.apiVersion)
+	}
+	return nil
+}
+
+// inlineResourceAttributeSet reports whether a resource block attribute is configured, matching GetOk for the
+// standalone resource.
+func inlineResourceAttributeSet(v interface{}) bool {
+	switch v := v.(type) {
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	case *schema.Set:
+		return v.Len() > 0
+	case bool:
+		return v
+	case int:
+		return v != 0
+	}
+	return false
+}
+
+func expandApplicationInlineResource(in map[string]interface{}) *models.ResourceView {
+	methods := expandStringList(in["methods"].(*schema.Set).List())
+	resource := &models.ResourceView{
+		Name:         String(in["name"].(string)),
+		Methods:      &methods,
+		Anonymous:    Bool(in["anonymous"].(bool)),
+		Enabled:      Bool(in["enabled"].(bool)),
+		RootResource: Bool(false),
+		Unprotected:  Bool(in["unprotected"].(bool)),
+	}
+	if v, ok := in["audit_level"].(string); ok && v != "" {
+		resource.AuditLevel = String(v)
+	}
+	if v, ok := in["authentication_challenge_policy_id"].(string); ok && v != "" {
+		resource.AuthenticationChallengePolicyId = String(v)
+	}
+	if v, ok := in["default_auth_type_override"].(string); ok && v != "" {
+		resource.DefaultAuthTypeOverride = String(v)
+	}
+	if v, ok := in["path_patterns"].(*schema.Set); ok {
+		for _, raw := range v.List() {
+			l := raw.(map[string]interface{})
+			resource.PathPatterns = append(resource.PathPatterns, &models.PathPatternView{
+				Pattern: String(l["pattern"].(string)),
+				Type:    String(l["type"].(string)),
+			})
+		}
+	}
+	if v, ok := in["policy"].([]interface{}); ok && len(v) > 0 {
+		resource.Policy = expandPolicy(v)
+	}
+	if v, ok := in["query_param_config"].([]interface{}); ok && len(v) > 0 {
+		resource.QueryParamConfig = expandQueryParamConfig(v)
+	}
+	if v, ok := in["resource_type"].(string); ok && v != "" {
+		resource.ResourceType = String(v)
+	}
+	if v, ok := in["resource_type_configuration"].([]interface{}); ok && len(v) > 0 {
+		resource.ResourceTypeConfiguration = expandResourceTypeConfiguration(v)
+	}
+	return resource
+}
+
+func flattenApplicationInlineResource(in *models.ResourceView) map[string]interface{} {
+	s := map[string]interface{}{
+		"id":                 in.Id.String(),
+		"path_patterns":      flattenPathPatternView(in.PathPatterns),
+		"query_param_config": flattenQueryParamConfig(in.QueryParamConfig),
+	}
+	if in.Name != nil {
+		s["name"] = *in.Name
+	}
+	if in.Methods != nil {
+		s["methods"] = derefStrings(*in.Methods)
+	}
+	if in.Anonymous != nil {
+		s["anonymous"] = *in.Anonymous
+	}
+	if in.AuditLevel != nil {
+		s["audit_level"] = *in.AuditLevel
+	}
+	if in.AuthenticationChallengePolicyId != nil {
+		s["authentication_challenge_policy_id"] = *in.AuthenticationChallengePolicyId
+	}
+	if in.DefaultAuthTypeOverride != nil {
+		s["default_auth_type_override"] = *in.DefaultAuthTypeOverride
+	}
+	if in.Enabled != nil {
+		s["enabled"] = *in.Enabled
+	}
+	if in.Unprotected != nil {
+		s["unprotected"] = *in.Unprotected
+	}
+	if in.ResourceType != nil {
+		s["resource_type"] = *in.ResourceType
+	}
+	if policyHasItems(in.Policy) {
+		s["policy"] = flattenPolicy(in.Policy)
+	}
+	if in.ResourceTypeConfiguration != nil {
+		s["resource_type_configuration"] = flattenResourceTypeConfiguration(in.ResourceTypeConfiguration)
+	}
+	return s
+}
+
+// policyHasItems reports whether any of the policy types have rules or rulesets, PingAccess returns an empty web and api
+// policy when none are configured.
+func policyHasItems(in map[string]*[]*models.PolicyItem) bool {
+	for _, items := range in {
+		if items != nil && len(*items) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package sdkv2provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testApplicationInlineResources(t *testing.T, resources ...*models.ResourceView) []interface{} {
+	var raw []interface{}
+	for _, rv := range resources {
+		raw = append(raw, flattenApplicationInlineResource(rv))
+	}
+	d := schema.TestResourceDataRaw(t, resourcePingAccessApplicationSchema(), map[string]interface{}{})
+	assert.NoError(t, d.Set("resource", raw))
+	return d.Get("resource").(*schema.Set).List()
+}
+
+func testApplicationInlineResourceView(id, name, pattern string) *models.ResourceView {
+	return &models.ResourceView{
+		Id:           json.Number(id),
+		Name:         String(name),
+		Methods:      &[]*string{String("*")},
+		Anonymous:    Bool(false),
+		AuditLevel:   String("ON"),
+		Enabled:      Bool(true),
+		RootResource: Bool(false),
+		Unprotected:  Bool(false),
+		ResourceType: String("Standard"),
+		PathPatterns: []*models.PathPatternView{{Pattern: String(pattern), Type: String("WILDCARD")}},
+	}
+}
+
+func Test_applicationInlineResourceRoundTrip(t *testing.T) {
+	rv := testApplicationInlineResourceView("3", "one", "/one/*")
+	rv.Policy = map[string]*[]*models.PolicyItem{
+		"Web": {{Id: "1", Type: String("Rule")}},
+		"API": {},
+	}
+	rv.QueryParamConfig = &models.QueryParamConfigView{
+		MatchesNoParams: Bool(true),
+		Params:          []*models.QueryParamPairView{},
+	}
+
+	got := expandApplicationInlineResource(testApplicationInlineResources(t, rv)[0].(map[string]interface{}))
+
+	rv.Id = ""
+	rv.Policy["API"] = &[]*models.PolicyItem{}
+	assert.Equal(t, rv, got)
+}
+
+func Test_diffApplicationResources(t *testing.T) {
+	old := testApplicationInlineResources(t,
+		testApplicationInlineResourceView("1", "keep", "/keep/*"),
+		testApplicationInlineResourceView("2", "change", "/change/*"),
+		testApplicationInlineResourceView("3", "orphan", "/orphan/*"),
+	)
+	planned := testApplicationInlineResources(t,
+		testApplicationInlineResourceView("", "keep", "/keep/*"),
+		testApplicationInlineResourceView("", "change", "/changed/*"),
+		testApplicationInlineResourceView("", "new", "/orphan/*"),
+	)
+
+	changes := diffApplicationResources(old, planned)
+
+	assert.Len(t, changes.deletes, 1)
+	assert.Equal(t, "orphan", *changes.deletes[0].Name)
+	assert.Equal(t, json.Number("3"), changes.deletes[0].Id)
+	assert.Len(t, changes.updates, 1)
+	assert.Equal(t, "change", *changes.updates[0].Name)
+	assert.Equal(t, json.Number("2"), changes.updates[0].Id)
+	assert.Equal(t, "/changed/*", *changes.updates[0].PathPatterns[0].Pattern)
+	assert.Len(t, changes.creates, 1)
+	assert.Equal(t, "new", *changes.creates[0].Name)
+	assert.True(t, diffApplicationResources(old, old).empty())
+}
+
+func Test_applicationResourcesVersionDiff(t *testing.T) {
+	resource := func(extra map[string]interface{}) map[string]interface{} {
+		raw := map[string]interface{}{
+			"name":             "app",
+			"application_type": "Web",
+			"context_root":     "/app",
+			"destination":      "Site",
+			"site_id":          "1",
+			"virtual_host_ids": []interface{}{"1"},
+		}
+		block := map[string]interface{}{
+			"name":          "one",
+			"methods":       []interface{}{"*"},
+			"path_patterns": []interface{}{map[string]interface{}{"pattern": "/one/*", "type": "WILDCARD"}},
+		}
+		for k, v := range extra {
+			block[k] = v
+		}
+		raw["resource"] = []interface{}{block}
+		return raw
+	}
+	queryParamConfig := []interface{}{map[string]interface{}{"matches_no_params": true}}
+	tests := []struct {
+		name    string
+		version string
+		raw     map[string]interface{}
+		err     string
+	}{
+		{
+			name:    "unsupported attributes are rejected",
+			version: "6.0.0",
+			raw:     resource(map[string]interface{}{"query_param_config": queryParamConfig, "authentication_challenge_policy_id": "1"}),
+			err:     `attribute "resource.authentication_challenge_policy_id" requires PingAccess >= 6.2, attribute "resource.query_param_config" requires PingAccess >= 6.1, the server is running 6.0.0`,
+		},
+		{
+			name:    "supported attributes are accepted",
+			version: "6.2.0",
+			raw:     resource(map[string]interface{}{"query_param_config": queryParamConfig, "authentication_challenge_policy_id": "1"}),
+		},
+		{
+			name:    "unset attributes are ignored",
+			version: "6.0.0",
+			raw:     resource(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Provider().ResourcesMap["pingaccess_application"]
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.raw), testVersionedClient(t, tt.version))
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
@@ -1,16 +1,21 @@
 package sdkv2provider
 
 import (
+	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"net/url"
 	"os"
-	"regexp"
 	"syscall"
 
+	goversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/descriptors"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/paversion"
 
 	"github.com/iwarapter/pingaccess-sdk-go/v62/services/accessTokenValidators"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/services/acme"
@@ -66,63 +71,70 @@ import (
 )
 
 type cfg struct {
-	Username string
-	Password string
-	Context  string
-	BaseURL  string
+	Username           string
+	Password           string
+	Context            string
+	BaseURL            string
+	DescriptorCacheDir string
+	Offline            bool
+	OfflineVersion     string
+	OfflineDescriptors string
 }
 
 type paClient struct {
-	AccessTokenValidators       accessTokenValidators.AccessTokenValidatorsAPI
-	Acme                        acme.AcmeAPI
-	AdminConfig                 adminConfig.AdminConfigAPI
-	AdminSessionInfo            adminSessionInfo.AdminSessionInfoAPI
-	Agents                      agents.AgentsAPI
-	Applications                applications.ApplicationsAPI
-	Auth                        auth.AuthAPI
-	AuthTokenManagement         authTokenManagement.AuthTokenManagementAPI
-	AuthnReqLists               authnReqLists.AuthnReqListsAPI
-	Backup                      backup.BackupAPI
-	Certificates                certificates.CertificatesAPI
-	Config                      config.ConfigAPI
-	EngineListeners             engineListeners.EngineListenersAPI
-	Engines                     engines.EnginesAPI
-	GlobalUnprotectedResources  globalUnprotectedResources.GlobalUnprotectedResourcesAPI
-	HighAvailability            highAvailability.HighAvailabilityAPI
-	HighAvailabilityDescriptors *models.DescriptorsView
-	HsmProviders                hsmProviders.HsmProvidersAPI
-	HttpConfig                  httpConfig.HttpConfigAPI
-	HttpsListeners              httpsListeners.HttpsListenersAPI
-	IdentityMappings            identityMappings.IdentityMappingsAPI
-	IdentityMappingDescriptors  *models.DescriptorsView
-	KeyPairs                    keyPairs.KeyPairsAPI
-	KeyPairsV60                 keyPairs60.KeyPairsAPI
-	License                     license.LicenseAPI
-	Oauth                       oauth.OauthAPI
-	OauthKeyManagement          oauthKeyManagement.OauthKeyManagementAPI
-	Oidc                        oidc.OidcAPI
-	Pingfederate                pingfederate.PingfederateAPI
-	Pingone                     pingone.PingoneAPI
-	Proxies                     proxies.ProxiesAPI
-	Redirects                   redirects.RedirectsAPI
-	RejectionHandlers           rejectionHandlers.RejectionHandlersAPI
-	Rules                       rules.RulesAPI
-	RuleDescriptions            *models.RuleDescriptorsView
-	Rulesets                    rulesets.RulesetsAPI
-	SharedSecrets               sharedSecrets.SharedSecretsAPI
-	SiteAuthenticators          siteAuthenticators.SiteAuthenticatorsAPI
-	Sites                       sites.SitesAPI
-	ThirdPartyServices          thirdPartyServices.ThirdPartyServicesAPI
-	TokenProvider               tokenProvider.TokenProviderAPI
-	TrustedCertificateGroups    trustedCertificateGroups.TrustedCertificateGroupsAPI
-	UnknownResources            unknownResources.UnknownResourcesAPI
-	Users                       users.UsersAPI
-	Version                     version.VersionAPI
-	Virtualhosts                virtualhosts.VirtualhostsAPI
-	WebSessionManagement        webSessionManagement.WebSessionManagementAPI
-	WebSessions                 webSessions.WebSessionsAPI
-
-	apiVersion string
+	AccessTokenValidators      accessTokenValidators.AccessTokenValidatorsAPI
+	Acme                       acme.AcmeAPI
+	AdminConfig                adminConfig.AdminConfigAPI
+	AdminSessionInfo           adminSessionInfo.AdminSessionInfoAPI
+	Agents                     agents.AgentsAPI
+	Applications               applications.ApplicationsAPI
+	Auth                       auth.AuthAPI
+	AuthTokenManagement        authTokenManagement.AuthTokenManagementAPI
+	AuthnReqLists              authnReqLists.AuthnReqListsAPI
+	Backup                     backup.BackupAPI
+	Certificates               certificates.CertificatesAPI
+	Config                     config.ConfigAPI
+	ConfigDocuments            configDocumentsAPI
+	EngineListeners            engineListeners.EngineListenersAPI
+	Engines                    engines.EnginesAPI
+	GlobalUnprotectedResources globalUnprotectedResources.GlobalUnprotectedResourcesAPI
+	HighAvailability           highAvailability.HighAvailabilityAPI
+	HsmProviderList            hsmProviderListAPI
+	HsmProviders               hsmProviders.HsmProvidersAPI
+	HttpConfig                 httpConfig.HttpConfigAPI
+	HttpsListeners             httpsListeners.HttpsListenersAPI
+	IdentityMappings           identityMappings.IdentityMappingsAPI
+	KeyPairs                   keyPairs.KeyPairsAPI
+	KeyPairsV60                keyPairs60.KeyPairsAPI
+	License                    license.LicenseAPI
+	Oauth                      oauth.OauthAPI
+	OauthKeyManagement         oauthKeyManagement.OauthKeyManagementAPI
+	Oidc                       oidc.OidcAPI
+	Pingfederate               pingfederate.PingfederateAPI
+	Pingone                    pingone.PingoneAPI
+	Proxies                    proxies.ProxiesAPI
+	Redirects                  redirects.RedirectsAPI
+	RejectionHandlers          rejectionHandlers.RejectionHandlersAPI
+	Rules                      rules.RulesAPI
+	Rulesets                   rulesets.RulesetsAPI
+	SharedSecrets              sharedSecrets.SharedSecretsAPI
+	SiteAuthenticators         siteAuthenticators.SiteAuthenticatorsAPI
+	Sites                      sites.SitesAPI
+	ThirdPartyServices         thirdPartyServices.ThirdPartyServicesAPI
+	TokenProvider              tokenProvider.TokenProviderAPI
+	TrustedCertificateGroups   trustedCertificateGroups.TrustedCertificateGroupsAPI
+	UnknownResources           unknownResources.UnknownResourcesAPI
+	Users                      users.UsersAPI
+	V7                         v7API
+	Version                    version.VersionAPI
+	Virtualhosts               virtualhosts.VirtualhostsAPI
+	WebSessionManagement       webSessionManagement.WebSessionManagementAPI
+	WebSessions                webSessions.WebSessionsAPI
+
+	apiVersion  *goversion.Version
+	descriptors *descriptors.Cache
+	// offline is set when the provider is configured offline, reads are skipped rather than sent to PingAccess.
+	offline bool
 }
 
 // Client configures and returns a fully initialized PAClient
@@ -146,6 +158,10 @@ func (c *cfg) Client() (interface{}, diag.Diagnostics) {
 		cfg.WithDebug(true)
 		cfg60.WithDebug(true)
 	}
+	if c.Offline {
+		cfg.HTTPClient = &http.Client{Transport: offlineTransport{}}
+		cfg60.HTTPClient = &http.Client{Transport: offlineTransport{}}
+	}
 
 	client := paClient{
 		AccessTokenValidators:      accessTokenValidators.New(cfg),
@@ -160,10 +176,12 @@ func (c *cfg) Client() (interface{}, diag.Diagnostics) {
 		Backup:                     backup.New(cfg),
 		Certificates:               certificates.New(cfg),
 		Config:                     config.New(cfg),
+		ConfigDocuments:            newConfigDocumentsService(cfg),
 		EngineListeners:            engineListeners.New(cfg),
 		Engines:                    engines.New(cfg),
 		GlobalUnprotectedResources: globalUnprotectedResources.New(cfg),
 		HighAvailability:           highAvailability.New(cfg),
+		HsmProviderList:            newHsmProviderListService(cfg),
 		HsmProviders:               hsmProviders.New(cfg),
 		HttpConfig:                 httpConfig.New(cfg),
 		HttpsListeners:             httpsListeners.New(cfg),
@@ -195,6 +213,10 @@ func (c *cfg) Client() (interface{}, diag.Diagnostics) {
 		WebSessions:                webSessions.New(cfg),
 	}
 
+	if c.Offline {
+		return c.offlineClient(client, cfg)
+	}
+
 	v, _, err := client.Version.VersionCommand()
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
@@ -205,61 +227,178 @@ func (c *cfg) Client() (interface{}, diag.Diagnostics) {
 		return nil, diags
 	}
 
-	client.apiVersion = *v.Version
-
-	client.RuleDescriptions, _, err = client.Rules.GetRuleDescriptorsCommand()
+	client.apiVersion, err = parsePingAccessVersion(*v.Version)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Connection Error",
-			Detail:   fmt.Sprintf("Unable to connect to PingAccess: %s", checkErr(err)),
+			Summary:  "Unsupported Version",
+			Detail:   fmt.Sprintf("Unable to parse PingAccess version '%s': %s", *v.Version, err),
 		})
 		return nil, diags
 	}
-	client.IdentityMappingDescriptors, _, err = client.IdentityMappings.GetIdentityMappingDescriptorsCommand()
+	if client.versionAtLeast("7.0") {
+		client.V7 = newV7Service(cfg)
+	}
+
+	client.descriptors = descriptors.Shared(*cfg.Endpoint, client.apiVersion.String(), c.DescriptorCacheDir)
+
+	return client, nil
+}
+
+// offlineClient completes the client configuration without a connection to PingAccess, the version is taken from the
+// provider configuration and descriptors are loaded locally.
+func (c *cfg) offlineClient(client paClient, cfg *paCfg.Config) (interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if c.OfflineVersion == "" {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "Missing Version",
+			Detail:   "offline_version must be set when the provider is configured offline",
+		})
+		return nil, diags
+	}
+	var err error
+	client.offline = true
+	client.apiVersion, err = parsePingAccessVersion(c.OfflineVersion)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Connection Error",
-			Detail:   fmt.Sprintf("Unable to connect to PingAccess: %s", checkErr(err)),
+			Summary:  "Unsupported Version",
+			Detail:   fmt.Sprintf("Unable to parse PingAccess version '%s': %s", c.OfflineVersion, err),
 		})
 		return nil, diags
 	}
-	client.HighAvailabilityDescriptors, _, err = client.HighAvailability.GetAvailabilityProfileDescriptorsCommand()
+	if client.versionAtLeast("7.0") {
+		client.V7 = newV7Service(cfg)
+	}
+	client.descriptors, err = descriptors.NewOfflineCache(client.apiVersion.String(), c.DescriptorCacheDir, c.OfflineDescriptors)
 	if err != nil {
 		diags = append(diags, diag.Diagnostic{
 			Severity: diag.Error,
-			Summary:  "Connection Error",
-			Detail:   fmt.Sprintf("Unable to connect to PingAccess: %s", checkErr(err)),
+			Summary:  "Invalid Descriptors",
+			Detail:   err.Error(),
 		})
 		return nil, diags
 	}
-
 	return client, nil
 }
 
 // Checks whether we are running against PingAccess 6.1 or above and can track password changes
 func (c paClient) CanMaskPasswords() bool {
-	re := regexp.MustCompile(`^(6\.0)`)
-	return c.Is60OrAbove() && !re.MatchString(c.apiVersion)
+	return c.supports(paversion.FeatureMaskedPasswords)
 }
 
-// Checks whether we are running against PingAccess 6.2 or above
-func (c paClient) Is62OrAbove() bool {
-	re := regexp.MustCompile(`^(6\.[0-1])`)
-	return c.Is60OrAbove() && !re.MatchString(c.apiVersion)
+// Returns the rule descriptors, these are retrieved from PingAccess on first use
+func (c paClient) ruleDescriptors() (*models.RuleDescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "rules", func() (*models.RuleDescriptorsView, error) {
+		desc, _, err := c.Rules.GetRuleDescriptorsCommand()
+		return desc, err
+	})
 }
 
-// Checks whether we are running against PingAccess 6.2 or above
-func (c paClient) Is61OrAbove() bool {
-	re := regexp.MustCompile(`^(6\.[0])`)
-	return c.Is60OrAbove() && !re.MatchString(c.apiVersion)
+// Returns the identity mapping descriptors, these are retrieved from PingAccess on first use
+func (c paClient) identityMappingDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "identity_mappings", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.IdentityMappings.GetIdentityMappingDescriptorsCommand()
+		return desc, err
+	})
 }
 
-// Checks whether we are running against PingAccess 6.0 or above
-func (c paClient) Is60OrAbove() bool {
-	re := regexp.MustCompile(`^([6-9]\.[0-9])`)
-	return re.MatchString(c.apiVersion)
+// Returns the availability profile descriptors, these are retrieved from PingAccess on first use
+func (c paClient) availabilityProfileDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "availability_profiles", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.HighAvailability.GetAvailabilityProfileDescriptorsCommand()
+		return desc, err
+	})
+}
+
+// Returns the load balancing strategy descriptors, these are retrieved from PingAccess on first use
+func (c paClient) loadBalancingStrategyDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "load_balancing_strategies", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.HighAvailability.GetLoadBalancingStrategyDescriptorsCommand()
+		return desc, err
+	})
+}
+
+// Returns the HSM provider descriptors, these are retrieved from PingAccess on first use
+func (c paClient) hsmProviderDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "hsm_providers", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.HsmProviders.GetHsmProviderDescriptorsCommand()
+		return desc, err
+	})
+}
+
+// Returns the access token validator descriptors, these are retrieved from PingAccess on first use
+func (c paClient) accessTokenValidatorDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "access_token_validators", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.AccessTokenValidators.GetAccessTokenValidatorDescriptorsCommand()
+		return desc, err
+	})
+}
+
+// Returns the rejection handler descriptors, these are retrieved from PingAccess on first use
+func (c paClient) rejectionHandlerDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "rejection_handlers", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.RejectionHandlers.GetRejectionHandlerDescriptorsCommand()
+		return desc, err
+	})
+}
+
+// Returns the site authenticator descriptors, these are retrieved from PingAccess on first use
+func (c paClient) siteAuthenticatorDescriptors() (*models.DescriptorsView, error) {
+	return descriptors.Load(c.descriptors, "site_authenticators", func() (*models.DescriptorsView, error) {
+		desc, _, err := c.SiteAuthenticators.GetSiteAuthenticatorDescriptorsCommand()
+		return desc, err
+	})
+}
+
+// errOffline is returned for any request to the PingAccess API made while the provider is configured offline.
+var errOffline = errors.New("the provider is configured offline, this operation requires a connection to the PingAccess admin API")
+
+// offlineTransport fails every request, so operations needing the API only report an error when they are performed.
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errOffline
+}
+
+// withOfflineReads limits the offline mode to plan time validation. Resources are not refreshed, the prior state is
+// kept with a warning so plans against existing state still validate the configuration, and data sources report that
+// they require a connection to PingAccess.
+func withOfflineReads(p *schema.Provider) *schema.Provider {
+	for name, r := range p.ResourcesMap {
+		if r.ReadContext == nil {
+			continue
+		}
+		name, read := name, r.ReadContext
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if client, ok := m.(paClient); ok && client.offline {
+				return diag.Diagnostics{offlineReadDiagnostic(name, d.Id())}
+			}
+			return read(ctx, d, m)
+		}
+	}
+	for name, r := range p.DataSourcesMap {
+		if r.ReadContext == nil {
+			continue
+		}
+		name, read := name, r.ReadContext
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if client, ok := m.(paClient); ok && client.offline {
+				return diag.Errorf("%s cannot be read while the provider is configured offline, it requires a connection to the PingAccess admin API", name)
+			}
+			return read(ctx, d, m)
+		}
+	}
+	return p
+}
+
+func offlineReadDiagnostic(name, id string) diag.Diagnostic {
+	return diag.Diagnostic{
+		Severity: diag.Warning,
+		Summary:  "Resource not refreshed",
+		Detail:   fmt.Sprintf("The provider is configured offline, the state of %s (%s) was not refreshed from PingAccess.", name, id),
+	}
 }
 
 func checkErr(err error) string {
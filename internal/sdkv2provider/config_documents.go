@@ -0,0 +1,202 @@
+package sdkv2provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/client"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/client/metadata"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/request"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+
+	paCfg "github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+)
+
+// configDocumentsAPI sends and receives the configuration export documents as raw JSON. The SDK ExportData model
+// cannot hold the master keys returned by PingAccess, so the documents are passed through unchanged rather than
+// decoded.
+type configDocumentsAPI interface {
+	ExportData(id string) (string, *http.Response, error)
+	StartImport(document string, failFast bool) (string, *http.Response, error)
+}
+
+type configDocumentsService struct {
+	*client.Client
+}
+
+func newConfigDocumentsService(cfg *paCfg.Config) *configDocumentsService {
+	return &configDocumentsService{Client: client.New(
+		*cfg,
+		metadata.ClientInfo{
+			ServiceName: "ConfigDocuments",
+			Endpoint:    *cfg.Endpoint,
+			APIVersion:  pingaccess.SDKVersion,
+		},
+	)}
+}
+
+// ExportData returns the export document of a completed export workflow.
+func (s *configDocumentsService) ExportData(id string) (string, *http.Response, error) {
+	op := &request.Operation{
+		Name:        "GetConfigExportWorkflowDataCommand",
+		HTTPMethod:  http.MethodGet,
+		HTTPPath:    fmt.Sprintf("/config/export/workflows/%s/data", id),
+		QueryParams: map[string]string{},
+	}
+	var output string
+	req := s.NewRequest(op, nil, &output)
+	if req.Send() != nil {
+		return "", req.HTTPResponse, req.Error
+	}
+	return output, req.HTTPResponse, nil
+}
+
+// StartImport starts an import workflow of the document and returns the id of the workflow. The id is taken from the
+// Location header of the response, or the most recent import workflow when the header is not returned.
+func (s *configDocumentsService) StartImport(document string, failFast bool) (string, *http.Response, error) {
+	op := &request.Operation{
+		Name:       "AddConfigImportWorkflowCommand",
+		HTTPMethod: http.MethodPost,
+		HTTPPath:   "/config/import/workflows",
+		QueryParams: map[string]string{
+			"failFast": strconv.FormatBool(failFast),
+		},
+	}
+	if !json.Valid([]byte(document)) {
+		return "", nil, fmt.Errorf("the import document is not valid JSON")
+	}
+	req := s.NewRequest(op, json.RawMessage(document), nil)
+	if req.Send() != nil {
+		return "", req.HTTPResponse, req.Error
+	}
+	if loc := req.HTTPResponse.Header.Get("Location"); loc != "" {
+		return path.Base(loc), req.HTTPResponse, nil
+	}
+
+	op = &request.Operation{
+		Name:        "GetConfigImportWorkflowsCommand",
+		HTTPMethod:  http.MethodGet,
+		HTTPPath:    "/config/import/workflows",
+		QueryParams: map[string]string{},
+	}
+	var workflows models.ConfigStatusesView
+	req = s.NewRequest(op, nil, &workflows)
+	if req.Send() != nil {
+		return "", req.HTTPResponse, req.Error
+	}
+	latest := 0
+	for _, w := range workflows.Items {
+		if w.Id != nil && *w.Id > latest {
+			latest = *w.Id
+		}
+	}
+	if latest == 0 {
+		return "", req.HTTPResponse, fmt.Errorf("the import workflow was not found")
+	}
+	return strconv.Itoa(latest), req.HTTPResponse, nil
+}
+
+// Statuses of the configuration export and import workflows.
+const (
+	configWorkflowInProgress = "In Progress"
+	configWorkflowComplete   = "Complete"
+	configWorkflowFailed     = "Failed"
+)
+
+// waitForConfigWorkflow polls the workflow until it is no longer in progress, returning the final status of the
+// workflow.
+func waitForConfigWorkflow(ctx context.Context, timeout time.Duration, get func() (*models.ConfigStatusView, error)) (*models.ConfigStatusView, error) {
+	var status *models.ConfigStatusView
+	err := resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		var err error
+		status, err = get()
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if status.Status != nil && *status.Status == configWorkflowInProgress {
+			return resource.RetryableError(fmt.Errorf("workflow %d is still in progress", configWorkflowID(status)))
+		}
+		return nil
+	})
+	return status, err
+}
+
+// configWorkflowDiags returns the failures of a workflow as error diagnostics, naming the entity being processed when
+// the workflow failed, and any warnings as warning diagnostics.
+func configWorkflowDiags(summary string, status *models.ConfigStatusView) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if status.Status != nil && *status.Status == configWorkflowFailed {
+		entity := ""
+		if len(status.CurrentEntity) > 0 {
+			var keys []string
+			for k := range status.CurrentEntity {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			var parts []string
+			for _, k := range keys {
+				parts = append(parts, fmt.Sprintf("%s=%v", k, status.CurrentEntity[k]))
+			}
+			entity = fmt.Sprintf(" (%s)", strings.Join(parts, ", "))
+		}
+		var details []string
+		if status.ApiErrorView != nil {
+			if status.ApiErrorView.Flash != nil {
+				for _, msg := range *status.ApiErrorView.Flash {
+					details = append(details, *msg)
+				}
+			}
+			var fields []string
+			for field := range status.ApiErrorView.Form {
+				fields = append(fields, field)
+			}
+			sort.Strings(fields)
+			for _, field := range fields {
+				if status.ApiErrorView.Form[field] == nil {
+					continue
+				}
+				for _, msg := range *status.ApiErrorView.Form[field] {
+					details = append(details, fmt.Sprintf("%s: %s", field, *msg))
+				}
+			}
+		}
+		if len(details) == 0 {
+			details = append(details, "no reason was given")
+		}
+		for _, detail := range details {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("%s, workflow %d failed%s", summary, configWorkflowID(status), entity),
+				Detail:   detail,
+			})
+		}
+	}
+	if status.Warnings != nil {
+		for _, w := range *status.Warnings {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("%s, workflow %d reported a warning", summary, configWorkflowID(status)),
+				Detail:   *w,
+			})
+		}
+	}
+	return diags
+}
+
+func configWorkflowID(status *models.ConfigStatusView) int {
+	if status.Id == nil {
+		return 0
+	}
+	return *status.Id
+}
@@ -1,14 +1,21 @@
 package sdkv2provider
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
+	"sync/atomic"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/rules"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
@@ -88,13 +95,13 @@ func TestConfig_Client(t *testing.T) {
 	}
 }
 
-func TestIs60OrAbove(t *testing.T) {
+func Test_CanMaskPasswords(t *testing.T) {
 	cli := paClient{}
 	tests := []struct {
 		version string
 		expect  bool
 	}{
-		{"6.0", true},
+		{"6.0", false},
 		{"6.1", true},
 		{"6.2", true},
 		{"6.3", true},
@@ -106,92 +113,110 @@ func TestIs60OrAbove(t *testing.T) {
 		{"8.1", true},
 		{"8.2", true},
 		{"8.3", true},
+		{"10.0", true},
 	}
 	for _, tt := range tests {
 		t.Run(fmt.Sprintf("we handle %s", tt.version), func(t *testing.T) {
-			cli.apiVersion = tt.version
-			assert.Equal(t, tt.expect, cli.Is60OrAbove())
+			v, err := parsePingAccessVersion(tt.version)
+			assert.NoError(t, err)
+			cli.apiVersion = v
+			assert.Equal(t, tt.expect, cli.CanMaskPasswords())
 		})
 	}
 }
 
-func TestIs61OrAbove(t *testing.T) {
-	cli := paClient{}
-	tests := []struct {
-		version string
-		expect  bool
-	}{
-		{"6.0", false},
-		{"6.1", true},
-		{"6.2", true},
-		{"6.3", true},
-		{"7.0", true},
-		{"7.1", true},
-		{"7.2", true},
-		{"7.3", true},
-		{"8.0", true},
-		{"8.1", true},
-		{"8.2", true},
-		{"8.3", true},
+func TestConfig_ClientLoadsDescriptorsLazily(t *testing.T) {
+	var descriptorCalls int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json;charset=utf-8")
+		switch req.URL.Path {
+		case "/pa-admin-api/v3/version":
+			_, _ = rw.Write([]byte(`{"version":"6.2.0"}`))
+		case "/pa-admin-api/v3/rules/descriptors":
+			atomic.AddInt32(&descriptorCalls, 1)
+			_, _ = rw.Write([]byte(`{"items":[{"className":"com.pingidentity.pa.policy.CIDRPolicyInterceptor","type":"CIDR"}]}`))
+		default:
+			rw.WriteHeader(http.StatusInternalServerError)
+			_, _ = rw.Write([]byte(`{"flash":["unavailable"]}`))
+		}
+	}))
+	defer server.Close()
+
+	c := &cfg{
+		Username: "Administrator",
+		Password: "2Access",
+		BaseURL:  server.URL,
+		Context:  "/pa-admin-api/v3",
 	}
-	for _, tt := range tests {
-		t.Run(fmt.Sprintf("we handle %s", tt.version), func(t *testing.T) {
-			cli.apiVersion = tt.version
-			assert.Equal(t, tt.expect, cli.Is61OrAbove())
-		})
+	m, diags := c.Client()
+	assert.Nil(t, diags)
+	assert.Equal(t, int32(0), descriptorCalls)
+
+	client := m.(paClient)
+	for i := 0; i < 3; i++ {
+		desc, err := client.ruleDescriptors()
+		assert.NoError(t, err)
+		assert.Len(t, desc.Items, 1)
 	}
+	assert.Equal(t, int32(1), descriptorCalls)
+
+	_, err := client.identityMappingDescriptors()
+	assert.EqualError(t, err, "unavailable")
 }
 
-func TestIs62OrAbove(t *testing.T) {
-	cli := paClient{}
-	tests := []struct {
-		version string
-		expect  bool
-	}{
-		{"6.0", false},
-		{"6.1", false},
-		{"6.2", true},
-		{"6.3", true},
-		{"7.0", true},
-		{"7.1", true},
-		{"7.2", true},
-		{"7.3", true},
-		{"8.0", true},
-		{"8.1", true},
-		{"8.2", true},
-		{"8.3", true},
-	}
-	for _, tt := range tests {
-		t.Run(fmt.Sprintf("we handle %s", tt.version), func(t *testing.T) {
-			cli.apiVersion = tt.version
-			assert.Equal(t, tt.expect, cli.Is62OrAbove())
-		})
+func TestConfig_ClientOffline(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "descriptors.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"hsm_providers":{"items":[{"className":"com.pingidentity.pa.hsm.cloudhsm.plugin.AwsCloudHsmProvider","configurationFields":[{"name":"user","type":"TEXT","required":true}]}]}}`), 0600))
+
+	t.Setenv("PINGACCESS_OFFLINE", "true")
+	t.Setenv("PINGACCESS_OFFLINE_VERSION", "6.2.1")
+	t.Setenv("PINGACCESS_OFFLINE_DESCRIPTORS", file)
+	t.Setenv("PINGACCESS_BASEURL", "https://unreachable.invalid:9000")
+	p := Provider()
+	diags := p.Configure(context.Background(), terraform.NewResourceConfigRaw(map[string]interface{}{}))
+	require.False(t, diags.HasError(), "%v", diags)
+
+	client := p.Meta().(paClient)
+	assert.True(t, client.versionAtLeast("6.2"))
+	assert.False(t, client.versionAtLeast("6.3"))
+
+	r := p.ResourcesMap["pingaccess_hsm_provider"]
+	raw := map[string]interface{}{
+		"name":          "test",
+		"class_name":    "com.pingidentity.pa.hsm.cloudhsm.plugin.AwsCloudHsmProvider",
+		"configuration": `{"partition":"foo"}`,
 	}
+	_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), client)
+	assert.EqualError(t, err, "configuration validation failed against the class descriptor definition\nconfiguration.user: the field 'user' is required for the class_name 'com.pingidentity.pa.hsm.cloudhsm.plugin.AwsCloudHsmProvider'")
+
+	raw["configuration"] = `{"user":"foo"}`
+	_, err = r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), client)
+	assert.NoError(t, err)
+
+	mappings, err := client.identityMappingDescriptors()
+	require.NoError(t, err, "descriptors missing from the file are loaded from those bundled for 6.2")
+	assert.NotEmpty(t, mappings.Items)
+
+	_, _, err = client.Rules.GetRulesCommand(&rules.GetRulesCommandInput{})
+	assert.ErrorIs(t, err, errOffline)
+
+	// existing state is kept rather than refreshed
+	state := &terraform.InstanceState{ID: "1", Attributes: map[string]string{"id": "1", "name": "test"}}
+	refreshed, diags := r.RefreshWithoutUpgrade(context.Background(), state, client)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Resource not refreshed", diags[0].Summary)
+	assert.Equal(t, "test", refreshed.Attributes["name"])
+
+	ds := p.DataSourcesMap["pingaccess_engines"]
+	diags = ds.ReadContext(context.Background(), ds.TestResourceData(), client)
+	require.True(t, diags.HasError())
+	assert.Equal(t, "pingaccess_engines cannot be read while the provider is configured offline, it requires a connection to the PingAccess admin API", diags[0].Summary)
 }
 
-func Test_CanMaskPasswords(t *testing.T) {
-	cli := paClient{}
-	tests := []struct {
-		version string
-		expect  bool
-	}{
-		{"6.0", false},
-		{"6.1", true},
-		{"6.2", true},
-		{"6.3", true},
-		{"7.0", true},
-		{"7.1", true},
-		{"7.2", true},
-		{"7.3", true},
-		{"8.0", true},
-		{"8.1", true},
-		{"8.2", true},
-		{"8.3", true},
-	}
-	for _, tt := range tests {
-		t.Run(fmt.Sprintf("we handle %s", tt.version), func(t *testing.T) {
-			cli.apiVersion = tt.version
-			assert.Equal(t, tt.expect, cli.CanMaskPasswords())
-		})
-	}
+func TestConfig_ClientOfflineRequiresVersion(t *testing.T) {
+	c := &cfg{BaseURL: "https://localhost:9000", Context: "/pa-admin-api/v3", Offline: true}
+	_, diags := c.Client()
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Missing Version", diags[0].Summary)
 }
@@ -7,7 +7,7 @@ import (
 )
 
 func TestAccPingAccessAcmeDefaultDataSource(t *testing.T) {
-	if !(paClient{apiVersion: paVersion}).Is60OrAbove() {
+	if !paVersionAtLeast("6.0") {
 		t.Skipf("This test only runs against PingAccess 6.0 and above, not: %s", paVersion)
 	}
 	resourceName := "data.pingaccess_acme_default.test"
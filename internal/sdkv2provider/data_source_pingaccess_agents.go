@@ -0,0 +1,108 @@
+package sdkv2provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/agents"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePingAccessAgents() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePingAccessAgentsRead,
+		Schema:      dataSourcePingAccessAgentsSchema(),
+		Description: "Use this data source to get the agents configured in PingAccess. The admin API does not report when an agent last connected, so only the agent configuration and certificate hash are available.",
+	}
+}
+
+func dataSourcePingAccessAgentsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"agents": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The agents ordered by name.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the agent.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the agent.",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The description of the agent.",
+					},
+					"hostname": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The host name of the PingAccess engine listener the agent connects to.",
+					},
+					"port": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The port of the PingAccess engine listener the agent connects to.",
+					},
+					"certificate_hash": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The hex encoded hash of the certificate the agent trusts.",
+					},
+					"certificate_hash_algorithm": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The algorithm of the certificate hash.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePingAccessAgentsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	list, _, err := m.(paClient).Agents.GetAgentsCommand(&agents.GetAgentsCommandInput{})
+	if err != nil {
+		return diag.Errorf("unable to read Agents: %s", err)
+	}
+	result := []interface{}{}
+	for _, a := range list.Items {
+		s := map[string]interface{}{
+			"id":          a.Id.String(),
+			"name":        "",
+			"description": "",
+			"hostname":    "",
+			"port":        0,
+		}
+		if a.Name != nil {
+			s["name"] = *a.Name
+		}
+		if a.Description != nil {
+			s["description"] = *a.Description
+		}
+		if a.Hostname != nil {
+			s["hostname"] = *a.Hostname
+		}
+		if a.Port != nil {
+			s["port"] = *a.Port
+		}
+		flattenCertificateHash(a.CertificateHash, s)
+		result = append(result, s)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].(map[string]interface{})["name"].(string) < result[j].(map[string]interface{})["name"].(string)
+	})
+
+	d.SetId("agents")
+	if err := d.Set("agents", result); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package sdkv2provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/agents"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccPingAccessAgentsDataSource(t *testing.T) {
+	resourceName := "data.pingaccess_agents.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pingaccess_agents" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "agents"),
+					resource.TestCheckResourceAttrSet(resourceName, "agents.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAgentsDataSourceRead(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	_, _, err := c.Agents.AddAgentCommand(&agents.AddAgentCommandInput{Body: models.AgentView{
+		Name:     pingaccess.String("apache"),
+		Hostname: pingaccess.String("pingaccess.example.com"),
+		Port:     pingaccess.Int(3030),
+	}})
+	require.NoError(t, err)
+
+	d := schema.TestResourceDataRaw(t, dataSourcePingAccessAgentsSchema(), map[string]interface{}{})
+	diags := dataSourcePingAccessAgentsRead(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	require.Equal(t, 1, d.Get("agents.#"))
+	assert.Equal(t, "1", d.Get("agents.0.id"))
+	assert.Equal(t, "apache", d.Get("agents.0.name"))
+	assert.Equal(t, "pingaccess.example.com", d.Get("agents.0.hostname"))
+	assert.Equal(t, 3030, d.Get("agents.0.port"))
+	assert.Equal(t, "SHA1", d.Get("agents.0.certificate_hash_algorithm"))
+	assert.NotEmpty(t, d.Get("agents.0.certificate_hash"))
+}
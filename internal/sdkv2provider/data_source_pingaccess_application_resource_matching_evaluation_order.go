@@ -0,0 +1,125 @@
+package sdkv2provider
+
+import (
+	"context"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/applications"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePingAccessApplicationResourceMatchingEvaluationOrder() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePingAccessApplicationResourceMatchingEvaluationOrderRead,
+		Schema:      dataSourcePingAccessApplicationResourceMatchingEvaluationOrderSchema(),
+		Description: "Use this data source to get the order in which PingAccess evaluates the resource path patterns of an application, including any regex patterns which may overlap.",
+	}
+}
+
+func dataSourcePingAccessApplicationResourceMatchingEvaluationOrderSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"application_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The id of the application.",
+		},
+		"entries": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The resource matching entries in the order they are evaluated.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the resource.",
+					},
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The type of the entry.",
+					},
+					"pattern": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The path pattern matched by the entry.",
+					},
+					"pattern_type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The pattern syntax type, e.g. `WILDCARD` or `REGEX`.",
+					},
+					"methods": {
+						Type:        schema.TypeList,
+						Computed:    true,
+						Description: "The HTTP methods matched by the entry.",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"link_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the object the entry belongs to.",
+					},
+					"link_location": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The location of the object the entry belongs to.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePingAccessApplicationResourceMatchingEvaluationOrderRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	svc := m.(paClient).Applications
+	applicationID := d.Get("application_id").(string)
+	result, _, err := svc.GetResourceMatchingEvaluationOrderCommand(&applications.GetResourceMatchingEvaluationOrderCommandInput{Id: applicationID})
+	if err != nil {
+		return diag.Errorf("unable to read ResourceMatchingEvaluationOrder: %s", err)
+	}
+	d.SetId(applicationID)
+	if err := d.Set("entries", flattenResourceMatchingEntries(result.Entries)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func flattenResourceMatchingEntries(in []*models.ResourceMatchingEntryView) []interface{} {
+	m := []interface{}{}
+	for _, e := range in {
+		if e == nil {
+			continue
+		}
+		s := make(map[string]interface{})
+		if e.Name != nil {
+			s["name"] = *e.Name
+		}
+		if e.Type != nil {
+			s["type"] = *e.Type
+		}
+		if e.Pattern != nil {
+			s["pattern"] = *e.Pattern
+		}
+		if e.PatternType != nil {
+			s["pattern_type"] = *e.PatternType
+		}
+		if e.Methods != nil {
+			s["methods"] = derefStrings(*e.Methods)
+		}
+		if e.Link != nil {
+			if e.Link.Id != nil {
+				s["link_id"] = *e.Link.Id
+			}
+			if e.Link.Location != nil {
+				s["link_location"] = *e.Link.Location
+			}
+		}
+		m = append(m, s)
+	}
+	return m
+}
@@ -0,0 +1,107 @@
+package sdkv2provider
+
+import (
+	"context"
+	"crypto/md5" // #nosec G501
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePingAccessBackup() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePingAccessBackupRead,
+		Schema:      dataSourcePingAccessBackupSchema(),
+		Description: `Use this data source to take a backup of the PingAccess configuration database.
+
+The backup is taken each time the data source is read, which is during plan, so it captures the configuration before any changes in the plan are applied. The archive is written to ` + "`output_path`" + ` when set, otherwise it is returned base64 encoded in ` + "`content_base64`" + `.`,
+	}
+}
+
+func dataSourcePingAccessBackupSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"output_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The path of the file to write the backup archive to, the file is replaced if it exists.",
+		},
+		"content_base64": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The base64 encoded backup archive, only set when `output_path` is not.",
+		},
+		"filename": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The file name of the backup archive given by PingAccess.",
+		},
+		"size": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The size of the backup archive in bytes.",
+		},
+		"sha256": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The hex encoded SHA-256 checksum of the backup archive.",
+		},
+		"md5": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The hex encoded MD5 checksum of the backup archive.",
+		},
+	}
+}
+
+func dataSourcePingAccessBackupRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	resp, err := m.(paClient).Backup.BackupCommand()
+	if err != nil {
+		return diag.Errorf("unable to read the backup: %s", err)
+	}
+	defer resp.Body.Close()
+	archive, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return diag.Errorf("unable to read the backup: %s", err)
+	}
+
+	var diags diag.Diagnostics
+	if path := d.Get("output_path").(string); path != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return diag.Errorf("unable to write the backup to %s: %s", path, err)
+		}
+		if err := os.WriteFile(path, archive, 0600); err != nil {
+			return diag.Errorf("unable to write the backup to %s: %s", path, err)
+		}
+		if err := d.Set("content_base64", ""); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	} else {
+		content := base64.StdEncoding.EncodeToString(archive)
+		setResourceDataStringWithDiagnostic(d, "content_base64", &content, &diags)
+	}
+
+	filename := ""
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		filename = params["filename"]
+	}
+	size := len(archive)
+	sha := sha256.Sum256(archive)
+	checksum := hex.EncodeToString(sha[:])
+	md := md5.Sum(archive) // #nosec G401
+	md5sum := hex.EncodeToString(md[:])
+
+	d.SetId(checksum)
+	setResourceDataStringWithDiagnostic(d, "filename", &filename, &diags)
+	setResourceDataIntWithDiagnostic(d, "size", &size, &diags)
+	setResourceDataStringWithDiagnostic(d, "sha256", &checksum, &diags)
+	setResourceDataStringWithDiagnostic(d, "md5", &md5sum, &diags)
+	return diags
+}
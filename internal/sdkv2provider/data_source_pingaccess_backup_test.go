@@ -0,0 +1,68 @@
+package sdkv2provider
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccPingAccessBackupDataSource(t *testing.T) {
+	resourceName := "data.pingaccess_backup.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pingaccess_backup" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "content_base64"),
+					resource.TestCheckResourceAttrSet(resourceName, "size"),
+					resource.TestMatchResourceAttr(resourceName, "sha256", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestMatchResourceAttr(resourceName, "md5", regexp.MustCompile(`^[0-9a-f]{32}$`)),
+					resource.TestMatchResourceAttr(resourceName, "filename", regexp.MustCompile(`\.zip$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestBackupDataSourceRead(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	t.Run("content", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, dataSourcePingAccessBackupSchema(), map[string]interface{}{})
+		require.False(t, dataSourcePingAccessBackupRead(context.Background(), d, c).HasError())
+		archive, err := base64.StdEncoding.DecodeString(d.Get("content_base64").(string))
+		require.NoError(t, err)
+		zr, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+		require.NoError(t, err)
+		require.Len(t, zr.File, 1)
+		assert.Equal(t, "data.json", zr.File[0].Name)
+		assert.Equal(t, len(archive), d.Get("size"))
+		assert.Equal(t, "pa-data-1.zip", d.Get("filename"))
+		assert.Equal(t, d.Id(), d.Get("sha256"))
+	})
+
+	t.Run("output path", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "backups", "pre-change.zip")
+		d := schema.TestResourceDataRaw(t, dataSourcePingAccessBackupSchema(), map[string]interface{}{"output_path": path})
+		require.False(t, dataSourcePingAccessBackupRead(context.Background(), d, c).HasError())
+		archive, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, len(archive), d.Get("size"))
+		assert.Empty(t, d.Get("content_base64"))
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	})
+}
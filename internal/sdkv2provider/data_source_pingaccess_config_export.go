@@ -0,0 +1,93 @@
+package sdkv2provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/config"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePingAccessConfigExport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePingAccessConfigExportRead,
+		Schema:      dataSourcePingAccessConfigExportSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Description: "Use this data source to export the PingAccess configuration. The export document includes the encrypted master keys so it can be imported into another cluster with the `pingaccess_config_import` resource.",
+	}
+}
+
+func dataSourcePingAccessConfigExportSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"data": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The JSON export document.",
+		},
+		"version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The version of PingAccess the configuration was exported from.",
+		},
+		"sha256": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The hex encoded SHA-256 checksum of the export document.",
+		},
+		"total_entities": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of entities in the export.",
+		},
+	}
+}
+
+func dataSourcePingAccessConfigExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(paClient)
+	started, _, err := client.Config.AddConfigExportWorkflowCommand()
+	if err != nil {
+		return diag.Errorf("unable to start the configuration export: %s", err)
+	}
+	id := strconv.Itoa(configWorkflowID(started))
+	status, err := waitForConfigWorkflow(ctx, d.Timeout(schema.TimeoutRead), func() (*models.ConfigStatusView, error) {
+		status, _, err := client.Config.GetConfigExportWorkflowCommand(&config.GetConfigExportWorkflowCommandInput{Id: id})
+		return status, err
+	})
+	if err != nil {
+		return diag.Errorf("unable to read the configuration export workflow %s: %s", id, err)
+	}
+	diags := configWorkflowDiags("Unable to export the configuration", status)
+	if diags.HasError() {
+		return diags
+	}
+
+	data, _, err := client.ConfigDocuments.ExportData(id)
+	if err != nil {
+		return append(diags, diag.Errorf("unable to read the configuration export %s: %s", id, err)...)
+	}
+	var document struct {
+		Version string `json:"version"`
+	}
+	if err := json.Unmarshal([]byte(data), &document); err != nil {
+		return append(diags, diag.Errorf("unable to parse the configuration export %s: %s", id, err)...)
+	}
+	sum := sha256.Sum256([]byte(data))
+	checksum := hex.EncodeToString(sum[:])
+
+	d.SetId(id)
+	setResourceDataStringWithDiagnostic(d, "data", &data, &diags)
+	setResourceDataStringWithDiagnostic(d, "version", &document.Version, &diags)
+	setResourceDataStringWithDiagnostic(d, "sha256", &checksum, &diags)
+	setResourceDataIntWithDiagnostic(d, "total_entities", status.TotalEntities, &diags)
+	return diags
+}
@@ -0,0 +1,27 @@
+package sdkv2provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPingAccessConfigExportDataSource(t *testing.T) {
+	resourceName := "data.pingaccess_config_export.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pingaccess_config_export" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "data"),
+					resource.TestCheckResourceAttr(resourceName, "version", paVersion),
+					resource.TestMatchResourceAttr(resourceName, "sha256", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+				),
+			},
+		},
+	})
+}
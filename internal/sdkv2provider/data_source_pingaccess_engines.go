@@ -0,0 +1,143 @@
+package sdkv2provider
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/engines"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePingAccessEngines() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePingAccessEnginesRead,
+		Schema:      dataSourcePingAccessEnginesSchema(),
+		Description: "Use this data source to get the engines of the PingAccess cluster and when each last polled the administrative node for its configuration.",
+	}
+}
+
+func dataSourcePingAccessEnginesSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"current_server_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The time of the administrative node in RFC 3339 format.",
+		},
+		"engines": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The engines ordered by name.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the engine.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the engine.",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The description of the engine.",
+					},
+					"config_replication_enabled": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether configuration replication is enabled for the engine.",
+					},
+					"certificate_hash": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The hex encoded hash of the certificate the engine uses to authenticate to the administrative node.",
+					},
+					"certificate_hash_algorithm": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The algorithm of the certificate hash.",
+					},
+					"last_updated": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "When the engine last polled the administrative node in RFC 3339 format, empty if it has never polled.",
+					},
+					"seconds_since_last_updated": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The number of seconds since the engine last polled the administrative node by the time of the administrative node, `-1` if it has never polled.",
+					},
+					"polling_delay": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The polling delay reported for the engine.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePingAccessEnginesRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	svc := m.(paClient).Engines
+	list, _, err := svc.GetEnginesCommand(&engines.GetEnginesCommandInput{})
+	if err != nil {
+		return diag.Errorf("unable to read Engines: %s", err)
+	}
+	status, _, err := svc.GetEngineStatusCommand()
+	if err != nil {
+		return diag.Errorf("unable to read EngineStatus: %s", err)
+	}
+	now := time.Now()
+	if status.CurrentServerTime != nil {
+		now = time.UnixMilli(int64(*status.CurrentServerTime))
+	}
+
+	result := []interface{}{}
+	for _, e := range list.Items {
+		s := map[string]interface{}{
+			"id":                         e.Id.String(),
+			"name":                       "",
+			"description":                "",
+			"config_replication_enabled": e.ConfigReplicationEnabled == nil || *e.ConfigReplicationEnabled,
+			"last_updated":               "",
+			"seconds_since_last_updated": -1,
+			"polling_delay":              0,
+		}
+		if e.Name != nil {
+			s["name"] = *e.Name
+		}
+		if e.Description != nil {
+			s["description"] = *e.Description
+		}
+		flattenCertificateHash(e.CertificateHash, s)
+		if info, ok := status.EnginesStatus[e.Id.String()]; ok && info != nil {
+			if info.LastUpdated != nil {
+				lastUpdated := time.UnixMilli(int64(*info.LastUpdated))
+				s["last_updated"] = lastUpdated.UTC().Format(time.RFC3339Nano)
+				s["seconds_since_last_updated"] = int(now.Sub(lastUpdated) / time.Second)
+			}
+			if info.PollingDelay != nil {
+				s["polling_delay"] = *info.PollingDelay
+			}
+		}
+		result = append(result, s)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].(map[string]interface{})["name"].(string) < result[j].(map[string]interface{})["name"].(string)
+	})
+
+	var diags diag.Diagnostics
+	d.SetId("engines")
+	serverTime := now.UTC().Format(time.RFC3339Nano)
+	setResourceDataStringWithDiagnostic(d, "current_server_time", &serverTime, &diags)
+	if err := d.Set("engines", result); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
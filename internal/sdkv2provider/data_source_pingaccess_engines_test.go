@@ -0,0 +1,60 @@
+package sdkv2provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/engines"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccPingAccessEnginesDataSource(t *testing.T) {
+	resourceName := "data.pingaccess_engines.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pingaccess_engines" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "current_server_time"),
+					resource.TestCheckResourceAttrSet(resourceName, "engines.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestEnginesDataSourceRead(t *testing.T) {
+	c, s := newFakeClient(t)
+
+	for _, name := range []string{"engine-b", "engine-a"} {
+		_, _, err := c.Engines.AddEngineCommand(&engines.AddEngineCommandInput{Body: models.EngineView{Name: pingaccess.String(name), Description: pingaccess.String(name + " description")}})
+		require.NoError(t, err)
+	}
+	s.PollEngine("1")
+
+	d := schema.TestResourceDataRaw(t, dataSourcePingAccessEnginesSchema(), map[string]interface{}{})
+	diags := dataSourcePingAccessEnginesRead(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	assert.NotEmpty(t, d.Get("current_server_time"))
+	require.Equal(t, 2, d.Get("engines.#"))
+	assert.Equal(t, "engine-a", d.Get("engines.0.name"))
+	assert.Equal(t, "2", d.Get("engines.0.id"))
+	assert.Equal(t, "", d.Get("engines.0.last_updated"))
+	assert.Equal(t, -1, d.Get("engines.0.seconds_since_last_updated"))
+	assert.Equal(t, "engine-b", d.Get("engines.1.name"))
+	assert.Equal(t, "engine-b description", d.Get("engines.1.description"))
+	assert.Equal(t, true, d.Get("engines.1.config_replication_enabled"))
+	assert.NotEmpty(t, d.Get("engines.1.last_updated"))
+	assert.Equal(t, 0, d.Get("engines.1.seconds_since_last_updated"))
+	assert.Equal(t, "SHA1", d.Get("engines.1.certificate_hash_algorithm"))
+	assert.Regexp(t, `^[0-9a-f]{40}$`, d.Get("engines.1.certificate_hash"))
+}
@@ -9,7 +9,7 @@ import (
 )
 
 func TestAccPingAccessPingFederateRuntimeMetadataDataSource(t *testing.T) {
-	if !(paClient{apiVersion: paVersion}).Is60OrAbove() {
+	if !paVersionAtLeast("6.0") {
 		t.Skipf("This test only runs against PingAccess 6.0 and above, not: %s", paVersion)
 	}
 
@@ -0,0 +1,53 @@
+package sdkv2provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePingAccessPluginDescriptor() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePingAccessPluginDescriptorRead,
+		Schema:      dataSourcePingAccessPluginDescriptorSchema(),
+		Description: "Use this data source to get the descriptor of a plugin available in the PingAccess instance.",
+	}
+}
+
+func dataSourcePingAccessPluginDescriptorSchema() map[string]*schema.Schema {
+	sch := descriptorSchema(false)
+	// the descriptor type is replaced by the plugin type argument used to look up the descriptor
+	sch["type"] = pluginDescriptorTypeSchema()
+	sch["class_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The plugin class name.",
+	}
+	return sch
+}
+
+func dataSourcePingAccessPluginDescriptorRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pluginType := d.Get("type").(string)
+	desc, err := pluginDescriptorTypes[pluginType](m.(paClient))
+	if err != nil {
+		return diag.Errorf("unable to read %s descriptors: %s", pluginType, err)
+	}
+	className := d.Get("class_name").(string)
+	var classes []string
+	for _, item := range desc.Items {
+		if item.ClassName == nil {
+			continue
+		}
+		if *item.ClassName == className {
+			d.SetId(fmt.Sprintf("%s/%s", pluginType, className))
+			descriptor := flattenDescriptorView(item)
+			delete(descriptor, "type")
+			return setDescriptorAttributes(d, descriptor)
+		}
+		classes = append(classes, *item.ClassName)
+	}
+	return diag.Errorf("unable to find a %s descriptor with the class_name '%s', available classes: %s", pluginType, className, strings.Join(classes, ", "))
+}
@@ -0,0 +1,91 @@
+package sdkv2provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// pluginDescriptorTypes maps the plugin families accepted by the plugin descriptor data sources to the descriptors
+// available for them, rules are exposed separately as their descriptors include additional details.
+var pluginDescriptorTypes = map[string]func(paClient) (*models.DescriptorsView, error){
+	"access_token_validator":  paClient.accessTokenValidatorDescriptors,
+	"availability_profile":    paClient.availabilityProfileDescriptors,
+	"hsm_provider":            paClient.hsmProviderDescriptors,
+	"identity_mapping":        paClient.identityMappingDescriptors,
+	"load_balancing_strategy": paClient.loadBalancingStrategyDescriptors,
+	"rejection_handler":       paClient.rejectionHandlerDescriptors,
+	"site_authenticator":      paClient.siteAuthenticatorDescriptors,
+}
+
+func pluginDescriptorTypeNames() []string {
+	var names []string
+	for k := range pluginDescriptorTypes {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func dataSourcePingAccessPluginDescriptors() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePingAccessPluginDescriptorsRead,
+		Schema:      dataSourcePingAccessPluginDescriptorsSchema(),
+		Description: "Use this data source to get the descriptors of the plugins of a given type available in the PingAccess instance.",
+	}
+}
+
+func dataSourcePingAccessPluginDescriptorsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": pluginDescriptorTypeSchema(),
+		"descriptors": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The plugin descriptors.",
+			Elem: &schema.Resource{
+				Schema: descriptorSchema(false),
+			},
+		},
+	}
+}
+
+func pluginDescriptorTypeSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Required:         true,
+		ValidateDiagFunc: validatePluginDescriptorType,
+		Description:      "The plugin type, one of `access_token_validator`, `availability_profile`, `hsm_provider`, `identity_mapping`, `load_balancing_strategy`, `rejection_handler` or `site_authenticator`.",
+	}
+}
+
+func dataSourcePingAccessPluginDescriptorsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	pluginType := d.Get("type").(string)
+	desc, err := pluginDescriptorTypes[pluginType](m.(paClient))
+	if err != nil {
+		return diag.Errorf("unable to read %s descriptors: %s", pluginType, err)
+	}
+	var items []interface{}
+	for _, item := range desc.Items {
+		items = append(items, flattenDescriptorView(item))
+	}
+	d.SetId(pluginType)
+	if err := d.Set("descriptors", items); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// setDescriptorAttributes sets the attributes of a flattened descriptor on the data source.
+func setDescriptorAttributes(d *schema.ResourceData, descriptor map[string]interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for k, v := range descriptor {
+		if err := d.Set(k, v); err != nil {
+			diags = append(diags, diag.FromErr(err)...)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,44 @@
+package sdkv2provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPingAccessPluginDescriptorsDataSource(t *testing.T) {
+	resourceName := "data.pingaccess_plugin_descriptors.test"
+	descriptorName := "data.pingaccess_plugin_descriptor.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPingAccessPluginDescriptorsConfig("com.pingidentity.pa.ha.lb.roundrobin.CookieBasedRoundRobinPlugin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "descriptors.0.class_name"),
+					resource.TestCheckResourceAttr(descriptorName, "class_name", "com.pingidentity.pa.ha.lb.roundrobin.CookieBasedRoundRobinPlugin"),
+					resource.TestCheckResourceAttrSet(descriptorName, "configuration_fields.0.name"),
+				),
+			},
+			{
+				Config:      testAccPingAccessPluginDescriptorsConfig("com.example.Unknown"),
+				ExpectError: regexp.MustCompile(`unable to find a load_balancing_strategy descriptor with the class_name 'com.example.Unknown'`),
+			},
+		},
+	})
+}
+
+func testAccPingAccessPluginDescriptorsConfig(className string) string {
+	return `
+data "pingaccess_plugin_descriptors" "test" {
+  type = "load_balancing_strategy"
+}
+
+data "pingaccess_plugin_descriptor" "test" {
+  type       = "load_balancing_strategy"
+  class_name = "` + className + `"
+}`
+}
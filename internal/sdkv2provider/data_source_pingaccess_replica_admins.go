@@ -0,0 +1,103 @@
+package sdkv2provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePingAccessReplicaAdmins() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePingAccessReplicaAdminsRead,
+		Schema:      dataSourcePingAccessReplicaAdminsSchema(),
+		Description: "Use this data source to get the replica administrative nodes of the PingAccess cluster. The admin API does not report when a replica last polled for its configuration, so only the replica configuration and certificate hash are available.",
+	}
+}
+
+func dataSourcePingAccessReplicaAdminsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"replica_admins": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The replica administrative nodes ordered by name.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the replica admin.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the replica admin.",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The description of the replica admin.",
+					},
+					"host_port": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The host and port of the replica admin.",
+					},
+					"config_replication_enabled": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether configuration replication is enabled for the replica admin.",
+					},
+					"certificate_hash": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The hex encoded hash of the certificate the replica admin uses to authenticate to the administrative node.",
+					},
+					"certificate_hash_algorithm": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The algorithm of the certificate hash.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePingAccessReplicaAdminsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	list, _, err := m.(paClient).AdminConfig.GetReplicaAdminsCommand()
+	if err != nil {
+		return diag.Errorf("unable to read ReplicaAdmins: %s", err)
+	}
+	result := []interface{}{}
+	for _, r := range list.Items {
+		s := map[string]interface{}{
+			"id":                         r.Id.String(),
+			"name":                       "",
+			"description":                "",
+			"host_port":                  "",
+			"config_replication_enabled": r.ConfigReplicationEnabled == nil || *r.ConfigReplicationEnabled,
+		}
+		if r.Name != nil {
+			s["name"] = *r.Name
+		}
+		if r.Description != nil {
+			s["description"] = *r.Description
+		}
+		if r.HostPort != nil {
+			s["host_port"] = *r.HostPort
+		}
+		flattenCertificateHash(r.CertificateHash, s)
+		result = append(result, s)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].(map[string]interface{})["name"].(string) < result[j].(map[string]interface{})["name"].(string)
+	})
+
+	d.SetId("replica_admins")
+	if err := d.Set("replica_admins", result); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package sdkv2provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/adminConfig"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccPingAccessReplicaAdminsDataSource(t *testing.T) {
+	resourceName := "data.pingaccess_replica_admins.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "pingaccess_replica_admins" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", "replica_admins"),
+					resource.TestCheckResourceAttrSet(resourceName, "replica_admins.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestReplicaAdminsDataSourceRead(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	_, _, err := c.AdminConfig.AddReplicaAdminCommand(&adminConfig.AddReplicaAdminCommandInput{Body: models.ReplicaAdminView{
+		Name:                     pingaccess.String("replica"),
+		HostPort:                 pingaccess.String("replica.example.com:9000"),
+		ConfigReplicationEnabled: pingaccess.Bool(false),
+	}})
+	require.NoError(t, err)
+
+	d := schema.TestResourceDataRaw(t, dataSourcePingAccessReplicaAdminsSchema(), map[string]interface{}{})
+	diags := dataSourcePingAccessReplicaAdminsRead(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	require.Equal(t, 1, d.Get("replica_admins.#"))
+	assert.Equal(t, "replica", d.Get("replica_admins.0.name"))
+	assert.Equal(t, "replica.example.com:9000", d.Get("replica_admins.0.host_port"))
+	assert.Equal(t, false, d.Get("replica_admins.0.config_replication_enabled"))
+	assert.NotEmpty(t, d.Get("replica_admins.0.certificate_hash"))
+}
@@ -0,0 +1,47 @@
+package sdkv2provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePingAccessRuleDescriptor() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePingAccessRuleDescriptorRead,
+		Schema:      dataSourcePingAccessRuleDescriptorSchema(),
+		Description: "Use this data source to get the descriptor of a rule plugin available in the PingAccess instance.",
+	}
+}
+
+func dataSourcePingAccessRuleDescriptorSchema() map[string]*schema.Schema {
+	sch := descriptorSchema(true)
+	sch["class_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "The rule class name.",
+	}
+	return sch
+}
+
+func dataSourcePingAccessRuleDescriptorRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	desc, err := m.(paClient).ruleDescriptors()
+	if err != nil {
+		return diag.Errorf("unable to read RuleDescriptors: %s", err)
+	}
+	className := d.Get("class_name").(string)
+	var classes []string
+	for _, item := range desc.Items {
+		if item.ClassName == nil {
+			continue
+		}
+		if *item.ClassName == className {
+			d.SetId(className)
+			return setDescriptorAttributes(d, flattenRuleDescriptorView(item))
+		}
+		classes = append(classes, *item.ClassName)
+	}
+	return diag.Errorf("unable to find a rule descriptor with the class_name '%s', available classes: %s", className, strings.Join(classes, ", "))
+}
@@ -0,0 +1,45 @@
+package sdkv2provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePingAccessRuleDescriptors() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePingAccessRuleDescriptorsRead,
+		Schema:      dataSourcePingAccessRuleDescriptorsSchema(),
+		Description: "Use this data source to get the descriptors of the rule plugins available in the PingAccess instance.",
+	}
+}
+
+func dataSourcePingAccessRuleDescriptorsSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"descriptors": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The rule descriptors.",
+			Elem: &schema.Resource{
+				Schema: descriptorSchema(true),
+			},
+		},
+	}
+}
+
+func dataSourcePingAccessRuleDescriptorsRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	desc, err := m.(paClient).ruleDescriptors()
+	if err != nil {
+		return diag.Errorf("unable to read RuleDescriptors: %s", err)
+	}
+	var items []interface{}
+	for _, item := range desc.Items {
+		items = append(items, flattenRuleDescriptorView(item))
+	}
+	d.SetId("rule_descriptors")
+	if err := d.Set("descriptors", items); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
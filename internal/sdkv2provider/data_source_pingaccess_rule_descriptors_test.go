@@ -0,0 +1,38 @@
+package sdkv2provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPingAccessRuleDescriptorsDataSource(t *testing.T) {
+	resourceName := "data.pingaccess_rule_descriptors.test"
+	descriptorName := "data.pingaccess_rule_descriptor.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPingAccessRuleDescriptorsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "descriptors.0.class_name"),
+					resource.TestCheckResourceAttr(descriptorName, "label", "Network Range"),
+					resource.TestCheckResourceAttr(descriptorName, "configuration_fields.0.name", "cidrNotation"),
+					resource.TestCheckResourceAttr(descriptorName, "configuration_fields.0.required", "true"),
+					resource.TestCheckResourceAttrSet(descriptorName, "modes.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPingAccessRuleDescriptorsConfig() string {
+	return `
+data "pingaccess_rule_descriptors" "test" {}
+
+data "pingaccess_rule_descriptor" "test" {
+  class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
+}`
+}
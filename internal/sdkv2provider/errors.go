@@ -0,0 +1,112 @@
+package sdkv2provider
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/apierror"
+)
+
+// isNotFound checks whether the PingAccess API responded that the requested object does not exist.
+func isNotFound(resp *http.Response) bool {
+	return resp != nil && resp.StatusCode == http.StatusNotFound
+}
+
+// readErrorDiags handles an error returned when refreshing a resource, if the object has been deleted outside of
+// terraform it is removed from the state so that terraform will plan to recreate it instead of failing.
+func readErrorDiags(d *schema.ResourceData, resp *http.Response, err error, kind string) diag.Diagnostics {
+	if isNotFound(resp) {
+		log.Printf("[WARN] %s (%s) not found, removing from state", kind, d.Id())
+		d.SetId("")
+		return nil
+	}
+	return diag.Errorf("unable to read %s: %s", kind, err)
+}
+
+// apiErrorDiags translates an error returned by the PingAccess API into diagnostics, each validation failure is
+// reported against the attribute in the resource schema matching the field path returned by the API so terraform can
+// highlight the offending configuration. Any other error is returned as a single diagnostic.
+func apiErrorDiags(err error, summary string, sch map[string]*schema.Schema) diag.Diagnostics {
+	v, ok := apierror.Parse(err, summary)
+	if !ok {
+		return diag.Errorf("%s: %s", summary, err)
+	}
+	var diags diag.Diagnostics
+	for _, f := range v.Failures {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       v.Summary,
+			Detail:        fmt.Sprintf("%s: %s", f.Field, f.Message),
+			AttributePath: apiFieldAttributePath(f.Field, sch),
+		})
+	}
+	return diags
+}
+
+var apiFieldIndexRegex = regexp.MustCompile(`\[(\d+)]`)
+
+// apiFieldAttributePath maps a field path returned by the PingAccess API (e.g. `clientCredentials.clientId` or
+// `targets[0]`) to the matching attribute path in the resource schema. Where the API path descends into an attribute
+// terraform treats as a single value, such as a json configuration string, the path to that attribute is returned.
+func apiFieldAttributePath(field string, sch map[string]*schema.Schema) cty.Path {
+	path := cty.Path{}
+	current := sch
+	for _, segment := range strings.Split(field, ".") {
+		name := segment
+		var indexes []int
+		if i := strings.Index(segment, "["); i > 0 {
+			name = segment[:i]
+			for _, m := range apiFieldIndexRegex.FindAllStringSubmatch(segment[i:], -1) {
+				idx, _ := strconv.Atoi(m[1])
+				indexes = append(indexes, idx)
+			}
+		}
+		attr := toSnakeCase(name)
+		s, ok := current[attr]
+		if !ok {
+			return path
+		}
+		path = path.GetAttr(attr)
+		if s.Type != schema.TypeList {
+			return path
+		}
+		if len(indexes) > 0 {
+			path = path.IndexInt(indexes[0])
+		} else if s.MaxItems == 1 {
+			path = path.IndexInt(0)
+		}
+		res, isResource := s.Elem.(*schema.Resource)
+		if !isResource || (len(indexes) == 0 && s.MaxItems != 1) {
+			return path
+		}
+		current = res.Schema
+	}
+	return path
+}
+
+// toSnakeCase converts the camelCase field names used by the PingAccess API to the snake_case attribute names used in
+// the schema.
+func toSnakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,122 @@
+package sdkv2provider
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/request"
+)
+
+func Test_readErrorDiags(t *testing.T) {
+	tests := []struct {
+		name       string
+		resp       *http.Response
+		expectID   string
+		expectDiag bool
+	}{
+		{
+			name:       "not found is removed from state",
+			resp:       &http.Response{StatusCode: http.StatusNotFound},
+			expectID:   "",
+			expectDiag: false,
+		},
+		{
+			name:       "server errors are reported",
+			resp:       &http.Response{StatusCode: http.StatusInternalServerError},
+			expectID:   "1",
+			expectDiag: true,
+		},
+		{
+			name:       "connection errors are reported",
+			resp:       nil,
+			expectID:   "1",
+			expectDiag: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourcePingAccessSiteSchema(), map[string]interface{}{})
+			d.SetId("1")
+			diags := readErrorDiags(d, tc.resp, fmt.Errorf("boom"), "Site")
+			equals(t, tc.expectDiag, diags.HasError())
+			equals(t, tc.expectID, d.Id())
+		})
+	}
+}
+
+func Test_apiFieldAttributePath(t *testing.T) {
+	tests := []struct {
+		field    string
+		schema   map[string]*schema.Schema
+		expected cty.Path
+	}{
+		{
+			field:    "name",
+			schema:   resourcePingAccessHsmProviderSchema(),
+			expected: cty.GetAttrPath("name"),
+		},
+		{
+			field:    "className",
+			schema:   resourcePingAccessHsmProviderSchema(),
+			expected: cty.GetAttrPath("class_name"),
+		},
+		{
+			field:    "configuration.path",
+			schema:   resourcePingAccessHsmProviderSchema(),
+			expected: cty.GetAttrPath("configuration"),
+		},
+		{
+			field:    "targets[1]",
+			schema:   resourcePingAccessSiteSchema(),
+			expected: cty.GetAttrPath("targets"),
+		},
+		{
+			field:    "clientCredentials.clientId",
+			schema:   resourcePingAccessOAuthServerSchema(),
+			expected: cty.GetAttrPath("client_credentials").IndexInt(0).GetAttr("client_id"),
+		},
+		{
+			field:    "policy.Web[0].id",
+			schema:   resourcePingAccessApplicationSchema(),
+			expected: cty.GetAttrPath("policy").IndexInt(0).GetAttr("web").IndexInt(0).GetAttr("id"),
+		},
+		{
+			field:    "unknownField",
+			schema:   resourcePingAccessHsmProviderSchema(),
+			expected: cty.Path{},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.field, func(t *testing.T) {
+			equals(t, tc.expected, apiFieldAttributePath(tc.field, tc.schema))
+		})
+	}
+}
+
+func Test_apiErrorDiags(t *testing.T) {
+	err := &request.PingAccessError{ApiErrorView: models.ApiErrorView{
+		Flash: &[]*string{String("Save Failed")},
+		Form: map[string]*[]*string{
+			"name":          {String("Name is required")},
+			"policy.Web[0]": {String("Invalid rule")},
+			"destination":   {String("Must be Site or Agent"), String("Cannot be empty")},
+		},
+	}}
+
+	diags := apiErrorDiags(err, "unable to create Application", resourcePingAccessApplicationSchema())
+
+	equals(t, 4, len(diags))
+	equals(t, "unable to create Application: Save Failed", diags[0].Summary)
+	equals(t, "destination: Must be Site or Agent", diags[0].Detail)
+	equals(t, cty.GetAttrPath("destination"), diags[0].AttributePath)
+	equals(t, "name: Name is required", diags[2].Detail)
+	equals(t, cty.GetAttrPath("policy").IndexInt(0).GetAttr("web").IndexInt(0), diags[3].AttributePath)
+
+	diags = apiErrorDiags(fmt.Errorf("boom"), "unable to create Site", resourcePingAccessSiteSchema())
+	equals(t, 1, len(diags))
+	equals(t, "unable to create Site: boom", diags[0].Summary)
+}
@@ -0,0 +1,63 @@
+package sdkv2provider
+
+import (
+	"net/http"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/client"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/client/metadata"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/request"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/hsmProviders"
+
+	paCfg "github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+)
+
+// hsmProvidersView is the collection of HSM providers returned by PingAccess.
+type hsmProvidersView struct {
+	Items []*models.HsmProviderView `json:"items"`
+}
+
+// hsmProviderListAPI lists the HSM providers. The SDK decodes the collection as a single HsmProviderView, which drops
+// the items, so the collection is requested and decoded here instead.
+type hsmProviderListAPI interface {
+	GetHsmProvidersCommand(input *hsmProviders.GetHsmProvidersCommandInput) (*hsmProvidersView, *http.Response, error)
+}
+
+type hsmProviderListService struct {
+	*client.Client
+}
+
+func newHsmProviderListService(cfg *paCfg.Config) *hsmProviderListService {
+	return &hsmProviderListService{Client: client.New(
+		*cfg,
+		metadata.ClientInfo{
+			ServiceName: "HsmProviderList",
+			Endpoint:    *cfg.Endpoint,
+			APIVersion:  pingaccess.SDKVersion,
+		},
+	)}
+}
+
+// GetHsmProvidersCommand - Get all HSM Providers
+func (s *hsmProviderListService) GetHsmProvidersCommand(input *hsmProviders.GetHsmProvidersCommandInput) (*hsmProvidersView, *http.Response, error) {
+	op := &request.Operation{
+		Name:       "GetHsmProvidersCommand",
+		HTTPMethod: http.MethodGet,
+		HTTPPath:   "/hsmProviders",
+		QueryParams: map[string]string{
+			"page":          input.Page,
+			"numberPerPage": input.NumberPerPage,
+			"filter":        input.Filter,
+			"name":          input.Name,
+			"sortKey":       input.SortKey,
+			"order":         input.Order,
+		},
+	}
+	output := &hsmProvidersView{}
+	req := s.NewRequest(op, nil, output)
+	if req.Send() != nil {
+		return nil, req.HTTPResponse, req.Error
+	}
+	return output, req.HTTPResponse, nil
+}
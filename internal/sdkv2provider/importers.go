@@ -0,0 +1,343 @@
+package sdkv2provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/acme"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/applications"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/authnReqLists"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/certificates"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/engineListeners"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/highAvailability"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/hsmProviders"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/httpsListeners"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/keyPairs"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/rulesets"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/sites"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/thirdPartyServices"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/trustedCertificateGroups"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/virtualhosts"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/webSessions"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/importid"
+)
+
+// importByKey returns an importer which accepts the natural keys of the object in place of its id, the import ID is
+// resolved to the id of the object before importing it with next.
+func importByKey(next schema.StateContextFunc, keys ...func(c paClient) importid.Key) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		for _, key := range keys {
+			id, err := key(m.(paClient)).Resolve(d.Id())
+			if err != nil {
+				return nil, err
+			}
+			d.SetId(id)
+		}
+		return next(ctx, d, m)
+	}
+}
+
+func acmeServerNameKey(c paClient) importid.Key {
+	return importid.Name("acme server", func(name string) ([]importid.Match, error) {
+		result, _, err := c.Acme.GetAcmeServersCommand(&acme.GetAcmeServersCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: *item.Id, Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+func applicationNameKey(c paClient) importid.Key {
+	return importid.Name("application", func(name string) ([]importid.Match, error) {
+		return applicationMatches(c, &applications.GetApplicationsCommandInput{Name: name}, func(name, _ string) string { return name })
+	})
+}
+
+// applicationContextRootKey identifies an application by its context root and virtual host, e.g. /app@example.com:443.
+func applicationContextRootKey(c paClient) importid.Key {
+	return importid.Key{
+		Kind: "application",
+		Name: "context root and virtual host",
+		Parse: func(id string) (string, bool) {
+			i := strings.LastIndex(id, "@")
+			if i <= 0 {
+				return "", false
+			}
+			_, ok := importid.HostPort(id[i+1:])
+			return id, ok
+		},
+		List: func(key string) ([]importid.Match, error) {
+			i := strings.LastIndex(key, "@")
+			vh, err := virtualHostKey(c).Resolve(key[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			return applicationMatches(c, &applications.GetApplicationsCommandInput{VirtualHostId: vh}, func(_, contextRoot string) string {
+				return contextRoot + key[i:]
+			})
+		},
+	}
+}
+
+func applicationMatches(c paClient, input *applications.GetApplicationsCommandInput, key func(name, contextRoot string) string) ([]importid.Match, error) {
+	result, _, err := c.Applications.GetApplicationsCommand(input)
+	if err != nil {
+		return nil, err
+	}
+	var matches []importid.Match
+	for _, item := range result.Items {
+		matches = append(matches, importid.Match{ID: item.Id.String(), Key: key(*item.Name, *item.ContextRoot)})
+	}
+	return matches, nil
+}
+
+// applicationResourceNameKey identifies an application resource by the application name and resource name, resolving
+// to the <application_id>/<resource_id> import ID. The key is split on the last "/" as application names may contain
+// one while resource names may not.
+func applicationResourceNameKey(c paClient) importid.Key {
+	return importid.Key{
+		Kind: "application resource",
+		Name: "application name and resource name",
+		Parse: func(id string) (string, bool) {
+			parts, ok := splitApplicationResourceKey(id)
+			if !ok || parts[0] == "" || parts[1] == "" || importid.IsID(parts[0]) && importid.IsID(parts[1]) {
+				return "", false
+			}
+			return id, true
+		},
+		List: func(key string) ([]importid.Match, error) {
+			parts, _ := splitApplicationResourceKey(key)
+			app, err := applicationNameKey(c).Resolve("name=" + parts[0])
+			if err != nil {
+				return nil, err
+			}
+			result, _, err := c.Applications.GetApplicationResourcesCommand(&applications.GetApplicationResourcesCommandInput{Id: app, Name: parts[1]})
+			if err != nil {
+				return nil, err
+			}
+			var matches []importid.Match
+			for _, item := range result.Items {
+				matches = append(matches, importid.Match{ID: app + "/" + item.Id.String(), Key: parts[0] + "/" + *item.Name})
+			}
+			return matches, nil
+		},
+	}
+}
+
+func splitApplicationResourceKey(key string) ([2]string, bool) {
+	i := strings.LastIndex(key, "/")
+	if i < 0 {
+		return [2]string{}, false
+	}
+	return [2]string{key[:i], key[i+1:]}, true
+}
+
+func authnReqListNameKey(c paClient) importid.Key {
+	return importid.Name("authn req list", func(name string) ([]importid.Match, error) {
+		result, _, err := c.AuthnReqLists.GetAuthnReqListsCommand(&authnReqLists.GetAuthnReqListsCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+func availabilityProfileNameKey(c paClient) importid.Key {
+	return importid.Name("availability profile", func(name string) ([]importid.Match, error) {
+		result, _, err := c.HighAvailability.GetAvailabilityProfilesCommand(&highAvailability.GetAvailabilityProfilesCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+func certificateAliasKey(c paClient) importid.Key {
+	return importid.Alias("certificate", func(alias string) ([]importid.Match, error) {
+		result, _, err := c.Certificates.GetTrustedCerts(&certificates.GetTrustedCertsInput{Alias: alias})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: strconv.Itoa(*item.Id), Key: *item.Alias})
+		}
+		return matches, nil
+	})
+}
+
+func engineListenerNameKey(c paClient) importid.Key {
+	return importid.Name("engine listener", func(name string) ([]importid.Match, error) {
+		result, _, err := c.EngineListeners.GetEngineListenersCommand(&engineListeners.GetEngineListenersCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+// httpsListenerNameKey lists all of the listeners, the listeners endpoint has no filter parameters.
+func httpsListenerNameKey(c paClient) importid.Key {
+	return importid.Name("https listener", func(string) ([]importid.Match, error) {
+		result, _, err := c.HttpsListeners.GetHttpsListenersCommand(&httpsListeners.GetHttpsListenersCommandInput{})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+func hsmProviderNameKey(c paClient) importid.Key {
+	return importid.Name("hsm provider", func(name string) ([]importid.Match, error) {
+		result, _, err := c.HsmProviderList.GetHsmProvidersCommand(&hsmProviders.GetHsmProvidersCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+func keyPairAliasKey(c paClient) importid.Key {
+	return importid.Alias("keypair", func(alias string) ([]importid.Match, error) {
+		result, _, err := c.KeyPairs.GetKeyPairsCommand(&keyPairs.GetKeyPairsCommandInput{Alias: alias})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: strconv.Itoa(*item.Id), Key: *item.Alias})
+		}
+		return matches, nil
+	})
+}
+
+func loadBalancingStrategyNameKey(c paClient) importid.Key {
+	return importid.Name("load balancing strategy", func(name string) ([]importid.Match, error) {
+		result, _, err := c.HighAvailability.GetLoadBalancingStrategiesCommand(&highAvailability.GetLoadBalancingStrategiesCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+func rulesetNameKey(c paClient) importid.Key {
+	return importid.Name("ruleset", func(name string) ([]importid.Match, error) {
+		result, _, err := c.Rulesets.GetRuleSetsCommand(&rulesets.GetRuleSetsCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+func siteNameKey(c paClient) importid.Key {
+	return importid.Name("site", func(name string) ([]importid.Match, error) {
+		result, _, err := c.Sites.GetSitesCommand(&sites.GetSitesCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+func thirdPartyServiceNameKey(c paClient) importid.Key {
+	return importid.Name("third party service", func(name string) ([]importid.Match, error) {
+		result, _, err := c.ThirdPartyServices.GetThirdPartyServicesCommand(&thirdPartyServices.GetThirdPartyServicesCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: *item.Id, Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+func trustedCertificateGroupNameKey(c paClient) importid.Key {
+	return importid.Name("trusted certificate group", func(name string) ([]importid.Match, error) {
+		result, _, err := c.TrustedCertificateGroups.GetTrustedCertificateGroupsCommand(&trustedCertificateGroups.GetTrustedCertificateGroupsCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
+
+// virtualHostKey identifies a virtual host by its host and port, e.g. example.com:443.
+func virtualHostKey(c paClient) importid.Key {
+	return importid.Key{
+		Kind:  "virtual host",
+		Name:  "host and port",
+		Parse: importid.HostPort,
+		List: func(key string) ([]importid.Match, error) {
+			result, _, err := c.Virtualhosts.GetVirtualHostsCommand(&virtualhosts.GetVirtualHostsCommandInput{VirtualHost: key})
+			if err != nil {
+				return nil, err
+			}
+			var matches []importid.Match
+			for _, item := range result.Items {
+				matches = append(matches, importid.Match{ID: item.Id.String(), Key: fmt.Sprintf("%s:%d", *item.Host, *item.Port)})
+			}
+			return matches, nil
+		},
+	}
+}
+
+func webSessionNameKey(c paClient) importid.Key {
+	return importid.Name("web session", func(name string) ([]importid.Match, error) {
+		result, _, err := c.WebSessions.GetWebSessionsCommand(&webSessions.GetWebSessionsCommandInput{Name: name})
+		if err != nil {
+			return nil, err
+		}
+		var matches []importid.Match
+		for _, item := range result.Items {
+			matches = append(matches, importid.Match{ID: item.Id.String(), Key: *item.Name})
+		}
+		return matches, nil
+	})
+}
@@ -0,0 +1,117 @@
+package sdkv2provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/applications"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/hsmProviders"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/sites"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/virtualhosts"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/importid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportByKey(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	for _, port := range []int{443, 4443} {
+		_, _, err := c.Virtualhosts.AddVirtualHostCommand(&virtualhosts.AddVirtualHostCommandInput{Body: models.VirtualHostView{Host: pingaccess.String("example.com"), Port: pingaccess.Int(port)}})
+		require.NoError(t, err)
+	}
+	site, _, err := c.Sites.AddSiteCommand(&sites.AddSiteCommandInput{Body: models.SiteView{Name: pingaccess.String("backend"), Targets: &[]*string{pingaccess.String("localhost:4567")}}})
+	require.NoError(t, err)
+	siteID, _ := site.Id.Int64()
+	for _, app := range []struct {
+		name string
+		vh   int
+	}{{"app one", 1}, {"app two", 2}} {
+		_, _, err = c.Applications.AddApplicationCommand(&applications.AddApplicationCommandInput{Body: models.ApplicationView{
+			Name:           pingaccess.String(app.name),
+			ContextRoot:    pingaccess.String("/app"),
+			Destination:    pingaccess.String("Site"),
+			SiteId:         pingaccess.Int(int(siteID)),
+			VirtualHostIds: &[]*int{pingaccess.Int(app.vh)},
+		}})
+		require.NoError(t, err)
+	}
+	_, _, err = c.Applications.AddApplicationResourceCommand(&applications.AddApplicationResourceCommandInput{Id: "2", Body: models.ResourceView{
+		Name:         pingaccess.String("api"),
+		PathPrefixes: &[]*string{pingaccess.String("/api/*")},
+		Methods:      &[]*string{pingaccess.String("*")},
+	}})
+	require.NoError(t, err)
+	_, _, err = c.Applications.AddApplicationCommand(&applications.AddApplicationCommandInput{Body: models.ApplicationView{
+		Name:           pingaccess.String("team/app"),
+		ContextRoot:    pingaccess.String("/team"),
+		Destination:    pingaccess.String("Site"),
+		SiteId:         pingaccess.Int(int(siteID)),
+		VirtualHostIds: &[]*int{pingaccess.Int(1)},
+	}})
+	require.NoError(t, err)
+	_, _, err = c.Applications.AddApplicationResourceCommand(&applications.AddApplicationResourceCommandInput{Id: "3", Body: models.ResourceView{
+		Name:         pingaccess.String("api"),
+		PathPrefixes: &[]*string{pingaccess.String("/api/*")},
+		Methods:      &[]*string{pingaccess.String("*")},
+	}})
+	require.NoError(t, err)
+	_, _, err = c.HsmProviders.AddHsmProviderCommand(&hsmProviders.AddHsmProviderCommandInput{Body: models.HsmProviderView{
+		Name:          pingaccess.String("cloudhsm"),
+		ClassName:     pingaccess.String("com.pingidentity.pa.hsm.cloudhsm.plugin.AwsCloudHsmProvider"),
+		Configuration: map[string]interface{}{"user": "admin", "password": "secret", "partition": "p1"},
+	}})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		key    func(c paClient) importid.Key
+		id     string
+		expect string
+		err    string
+	}{
+		{name: "site by id", key: siteNameKey, id: "1", expect: "1"},
+		{name: "site by name", key: siteNameKey, id: "name=backend", expect: "1"},
+		{name: "site by partial name", key: siteNameKey, id: "name=back", err: `no site found with name "back"`},
+		{name: "keypair by alias", key: keyPairAliasKey, id: "alias=Generated: ADMIN", expect: "1"},
+		{name: "virtual host", key: virtualHostKey, id: "example.com:4443", expect: "2"},
+		{name: "virtual host missing", key: virtualHostKey, id: "example.com:80", err: `no virtual host found with host and port "example.com:80"`},
+		{name: "application by name", key: applicationNameKey, id: "name=app two", expect: "2"},
+		{name: "application by context root", key: applicationContextRootKey, id: "/app@example.com:4443", expect: "2"},
+		{name: "application by context root missing", key: applicationContextRootKey, id: "/other@example.com:443", err: `no application found with context root and virtual host "/other@example.com:443"`},
+		{name: "application resource by name", key: applicationResourceNameKey, id: "app two/api", expect: "2/3"},
+		{name: "application resource by id", key: applicationResourceNameKey, id: "2/3", expect: "2/3"},
+		{name: "application resource of an application named with a slash", key: applicationResourceNameKey, id: "team/app/api", expect: "3/5"},
+		{name: "hsm provider by name", key: hsmProviderNameKey, id: "name=cloudhsm", expect: "1"},
+		{name: "hsm provider missing", key: hsmProviderNameKey, id: "name=luna", err: `no hsm provider found with name "luna"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := tc.key(c).Resolve(tc.id)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expect, id)
+		})
+	}
+
+	t.Run("importer", func(t *testing.T) {
+		d := resourcePingAccessApplicationResource().Data(nil)
+		d.SetId("app two/api")
+		result, err := importByKey(resourcePingAccessApplicationResourceImport, applicationResourceNameKey)(context.Background(), d, c)
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, "3", result[0].Id())
+		assert.Equal(t, "2", result[0].Get("application_id"))
+
+		d = resourcePingAccessSite().Data(nil)
+		d.SetId("name=missing")
+		_, err = importByKey(schema.ImportStatePassthroughContext, siteNameKey)(context.Background(), d, c)
+		assert.EqualError(t, err, `no site found with name "missing"`)
+	})
+}
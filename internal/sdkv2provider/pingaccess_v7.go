@@ -0,0 +1,72 @@
+package sdkv2provider
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pav7"
+
+	paCfg "github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+)
+
+// applicationViewV7 is the PingAccess 7.x application, the 6.2 model extended with the attributes added in 7.0.
+type applicationViewV7 struct {
+	models.ApplicationView
+	RiskPolicyId *int `json:"riskPolicyId,omitempty"`
+}
+
+// v7API provides access to the PingAccess 7.x admin API. It is configured whenever the server reports 7.0 or above, so
+// updates always send every attribute the server knows about. Applications declare the attributes added in 7.0, sites
+// and web sessions are updated with the 6.2 models and keep the 7.x attributes the provider does not manage. Key pairs
+// are replaced from the PKCS#12 file on update, so they have no 7.x attributes to keep and use the 6.2 SDK.
+type v7API interface {
+	AddApplicationCommand(body *applicationViewV7) (*applicationViewV7, *http.Response, error)
+	GetApplicationCommand(id string) (*applicationViewV7, *http.Response, error)
+	UpdateApplicationCommand(id string, body *applicationViewV7) (*applicationViewV7, *http.Response, error)
+	UpdateSiteCommand(id string, body *models.SiteView) (*models.SiteView, *http.Response, error)
+	UpdateWebSessionCommand(id string, body *models.WebSessionView) (*models.WebSessionView, *http.Response, error)
+}
+
+type v7Service struct {
+	*pav7.Client
+}
+
+func newV7Service(cfg *paCfg.Config) *v7Service {
+	return &v7Service{Client: pav7.New(cfg)}
+}
+
+// AddApplicationCommand - Add an Application
+func (s *v7Service) AddApplicationCommand(body *applicationViewV7) (*applicationViewV7, *http.Response, error) {
+	output := &applicationViewV7{}
+	resp, err := s.Create("/applications", body, output)
+	return output, resp, err
+}
+
+// GetApplicationCommand - Get an Application
+func (s *v7Service) GetApplicationCommand(id string) (*applicationViewV7, *http.Response, error) {
+	output := &applicationViewV7{}
+	resp, err := s.Get(fmt.Sprintf("/applications/%s", id), output)
+	return output, resp, err
+}
+
+// UpdateApplicationCommand - Update an Application
+func (s *v7Service) UpdateApplicationCommand(id string, body *applicationViewV7) (*applicationViewV7, *http.Response, error) {
+	output := &applicationViewV7{}
+	resp, err := s.Update(fmt.Sprintf("/applications/%s", id), body, output)
+	return output, resp, err
+}
+
+// UpdateSiteCommand - Update a Site
+func (s *v7Service) UpdateSiteCommand(id string, body *models.SiteView) (*models.SiteView, *http.Response, error) {
+	output := &models.SiteView{}
+	resp, err := s.Update(fmt.Sprintf("/sites/%s", id), body, output)
+	return output, resp, err
+}
+
+// UpdateWebSessionCommand - Update a WebSession
+func (s *v7Service) UpdateWebSessionCommand(id string, body *models.WebSessionView) (*models.WebSessionView, *http.Response, error) {
+	output := &models.WebSessionView{}
+	resp, err := s.Update(fmt.Sprintf("/webSessions/%s", id), body, output)
+	return output, resp, err
+}
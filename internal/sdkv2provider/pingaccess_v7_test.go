@@ -0,0 +1,101 @@
+package sdkv2provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/sites"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/virtualhosts"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pav7"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pingaccesstest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	paCfg "github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/config"
+)
+
+func TestApplicationV7Attributes(t *testing.T) {
+	c, _ := newFakeClientVersion(t, "7.0.3")
+
+	site, _, err := c.Sites.AddSiteCommand(&sites.AddSiteCommandInput{Body: models.SiteView{Name: String("site"), Targets: &[]*string{String("localhost:443")}}})
+	require.NoError(t, err)
+	vh, _, err := c.Virtualhosts.AddVirtualHostCommand(&virtualhosts.AddVirtualHostCommandInput{Body: models.VirtualHostView{Host: String("localhost"), Port: Int(443)}})
+	require.NoError(t, err)
+
+	d := schema.TestResourceDataRaw(t, resourcePingAccessApplicationSchema(), map[string]interface{}{
+		"name":             "app",
+		"application_type": "Web",
+		"context_root":     "/app",
+		"destination":      "Site",
+		"site_id":          site.Id.String(),
+		"virtual_host_ids": []interface{}{vh.Id.String()},
+		"risk_policy_id":   5,
+	})
+	diags := resourcePingAccessApplicationCreate(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	app, _, err := c.V7.GetApplicationCommand(d.Id())
+	require.NoError(t, err)
+	require.NotNil(t, app.RiskPolicyId)
+	assert.Equal(t, 5, *app.RiskPolicyId)
+
+	// a change made outside of terraform is read back as the server returns it
+	app.RiskPolicyId = Int(7)
+	_, _, err = c.V7.UpdateApplicationCommand(d.Id(), app)
+	require.NoError(t, err)
+	diags = resourcePingAccessApplicationRead(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 7, d.Get("risk_policy_id"))
+
+	// updating the resource order keeps the 7.x attributes
+	_, err = setApplicationResourceOrder(c, d.Id(), &app.ApplicationView, nil)
+	require.NoError(t, err)
+	app, _, err = c.V7.GetApplicationCommand(d.Id())
+	require.NoError(t, err)
+	require.NotNil(t, app.RiskPolicyId)
+	assert.Equal(t, 7, *app.RiskPolicyId)
+
+	// removing the attribute clears it on the server
+	require.NoError(t, d.Set("risk_policy_id", 0))
+	diags = resourcePingAccessApplicationUpdate(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	app, _, err = c.V7.GetApplicationCommand(d.Id())
+	require.NoError(t, err)
+	assert.Nil(t, app.RiskPolicyId)
+	assert.Equal(t, 0, d.Get("risk_policy_id"))
+}
+
+func TestSiteV7KeepsUnmanagedAttributes(t *testing.T) {
+	c, s := newFakeClientVersion(t, "7.0.3")
+
+	site, _, err := c.Sites.AddSiteCommand(&sites.AddSiteCommandInput{Body: models.SiteView{Name: String("site"), Targets: &[]*string{String("localhost:443")}}})
+	require.NoError(t, err)
+	// an attribute added after 6.2, which the provider does not manage, is set outside of terraform
+	cfg := paCfg.NewConfig().WithUsername(pingaccesstest.Username).WithPassword(pingaccesstest.Password).WithEndpoint(s.Endpoint())
+	raw := pav7.New(cfg)
+	path := "/sites/" + site.Id.String()
+	current := map[string]interface{}{}
+	_, err = raw.Get(path, &current)
+	require.NoError(t, err)
+	current["keyPairId"] = 5
+	_, err = raw.Send(http.MethodPut, path, current, nil)
+	require.NoError(t, err)
+
+	d := resourcePingAccessSite().Data(&terraform.InstanceState{ID: site.Id.String()})
+	diags := resourcePingAccessSiteRead(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	require.NoError(t, d.Set("name", "renamed"))
+	diags = resourcePingAccessSiteUpdate(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	updated := map[string]json.RawMessage{}
+	_, err = raw.Get(path, &updated)
+	require.NoError(t, err)
+	assert.JSONEq(t, `"renamed"`, string(updated["name"]))
+	assert.JSONEq(t, `5`, string(updated["keyPairId"]))
+}
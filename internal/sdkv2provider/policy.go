@@ -0,0 +1,470 @@
+package sdkv2provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/applications"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/rules"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/rulesets"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// policyValidator checks the rules and rulesets referenced by the policy of an application or application resource,
+// lookups return nil when the referenced object does not exist.
+type policyValidator struct {
+	rule    func(id string) (*models.RuleView, error)
+	ruleSet func(id string) (*models.RuleSetView, error)
+	// descriptor returns the rule descriptor of the class name, nil when no descriptor matches
+	descriptor func(className string) (*models.RuleDescriptorView, error)
+
+	rules    map[string]*models.RuleView
+	ruleSets map[string]*models.RuleSetView
+	walked   map[string]bool
+	errs     []string
+}
+
+func newPolicyValidator(client paClient) *policyValidator {
+	return &policyValidator{
+		rule: func(id string) (*models.RuleView, error) {
+			result, resp, err := client.Rules.GetRuleCommand(&rules.GetRuleCommandInput{Id: id})
+			if isNotFound(resp) {
+				return nil, nil
+			}
+			return result, err
+		},
+		ruleSet: func(id string) (*models.RuleSetView, error) {
+			result, resp, err := client.Rulesets.GetRuleSetCommand(&rulesets.GetRuleSetCommandInput{Id: id})
+			if isNotFound(resp) {
+				return nil, nil
+			}
+			return result, err
+		},
+		descriptor: func(className string) (*models.RuleDescriptorView, error) {
+			desc, err := client.ruleDescriptors()
+			if err != nil {
+				return nil, err
+			}
+			for _, item := range desc.Items {
+				if item.ClassName != nil && *item.ClassName == className {
+					return item, nil
+				}
+			}
+			return nil, nil
+		},
+		rules:    map[string]*models.RuleView{},
+		ruleSets: map[string]*models.RuleSetView{},
+		walked:   map[string]bool{},
+	}
+}
+
+// validate checks the policy items of each type exist, apply to the application type, can be used in the web or api
+// policy holding them and support the destination of the application. Items without an ID, the application type and
+// destination are skipped when they are not yet known.
+func (v *policyValidator) validate(policy map[string][]policyReference, applicationType, destination string) error {
+	for _, key := range []string{"web", "api"} {
+		items := policy[key]
+		if len(items) > 0 && !policyAppliesTo(key, applicationType) {
+			v.errs = append(v.errs, fmt.Sprintf("the %s policy is never evaluated for %s applications, use the %s policy instead", key, applicationType, strings.ToLower(applicationType)))
+		}
+		for _, item := range items {
+			if item.ID == "" {
+				continue
+			}
+			path := fmt.Sprintf("policy.0.%s.%d", key, item.Index)
+			if err := v.item(path, key, item.Type, item.ID, destination, map[string]bool{}); err != nil {
+				return err
+			}
+		}
+	}
+	return v.err()
+}
+
+func (v *policyValidator) err() error {
+	if len(v.errs) > 0 {
+		return fmt.Errorf("invalid policy:\n  %s", strings.Join(v.errs, "\n  "))
+	}
+	return nil
+}
+
+func (v *policyValidator) item(path, key, itemType, id, destination string, visited map[string]bool) error {
+	switch itemType {
+	case "Rule":
+		rule, err := v.lookupRule(id)
+		if err != nil {
+			return err
+		}
+		if rule == nil {
+			v.errs = append(v.errs, fmt.Sprintf("%s: the Rule %s does not exist", path, id))
+			return nil
+		}
+		desc, err := v.ruleDescriptor(rule)
+		if err != nil {
+			return err
+		}
+		v.policyType(path, key, rule, desc)
+		v.destination(path, rule, desc, destination)
+	case "RuleSet":
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+		ruleSet, err := v.lookupRuleSet(id)
+		if err != nil {
+			return err
+		}
+		if ruleSet == nil {
+			v.errs = append(v.errs, fmt.Sprintf("%s: the RuleSet %s does not exist", path, id))
+			return nil
+		}
+		if ruleSet.Policy == nil {
+			return nil
+		}
+		elementType := "Rule"
+		if ruleSet.ElementType != nil {
+			elementType = *ruleSet.ElementType
+		}
+		for _, child := range *ruleSet.Policy {
+			if child == nil {
+				continue
+			}
+			childPath := fmt.Sprintf("%s (RuleSet %s)", path, id)
+			if err := v.item(childPath, key, elementType, strconv.Itoa(*child), destination, visited); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (v *policyValidator) ruleDescriptor(rule *models.RuleView) (*models.RuleDescriptorView, error) {
+	if rule.ClassName == nil {
+		return nil, nil
+	}
+	return v.descriptor(*rule.ClassName)
+}
+
+// policyType checks the rule can be used in the web or api policy. Rules limited to one type of application are
+// identified by the type of their descriptor, Web or API, the descriptors of other rules apply to both.
+func (v *policyValidator) policyType(path, key string, rule *models.RuleView, desc *models.RuleDescriptorView) {
+	if desc == nil || desc.Type == nil {
+		return
+	}
+	var limited string
+	switch *desc.Type {
+	case "Web":
+		limited = "web"
+	case "API":
+		limited = "api"
+	default:
+		return
+	}
+	if limited != key {
+		v.errs = append(v.errs, fmt.Sprintf("%s: the Rule %s can only be used in the %s policy", path, ruleName(rule), limited))
+	}
+}
+
+// destination checks the rule supports the application destination, taken from the rule itself or otherwise the
+// modes of its descriptor.
+func (v *policyValidator) destination(path string, rule *models.RuleView, desc *models.RuleDescriptorView, destination string) {
+	if destination == "" {
+		return
+	}
+	var supported []string
+	if rule.SupportedDestinations != nil {
+		supported = derefStrings(*rule.SupportedDestinations)
+	}
+	if len(supported) == 0 && desc != nil {
+		supported = derefStrings(desc.Modes)
+	}
+	if len(supported) == 0 {
+		return
+	}
+	for _, s := range supported {
+		if s == destination {
+			return
+		}
+	}
+	v.errs = append(v.errs, fmt.Sprintf("%s: the Rule %s does not support the %s destination, supported destinations: %s", path, ruleName(rule), destination, strings.Join(supported, ", ")))
+}
+
+func ruleName(rule *models.RuleView) string {
+	if rule.Name != nil {
+		return fmt.Sprintf("%s (%s)", *rule.Name, rule.Id)
+	}
+	return rule.Id.String()
+}
+
+// validateRuleSet checks the policy of a ruleset, the referenced rules or rulesets must exist with the element_type of
+// the ruleset and nested rulesets must not lead back to a ruleset already in the chain. The id is empty for a ruleset
+// which is being created.
+func (v *policyValidator) validateRuleSet(id, elementType string, policy []policyReference) error {
+	self := "this RuleSet"
+	if id != "" {
+		self = "RuleSet " + id
+	}
+	for _, item := range policy {
+		if item.ID == "" {
+			continue
+		}
+		path := fmt.Sprintf("policy.%d", item.Index)
+		if err := v.ruleSetElement(path, []string{self}, elementType, item.ID); err != nil {
+			return err
+		}
+	}
+	return v.err()
+}
+
+func (v *policyValidator) ruleSetElement(path string, chain []string, elementType, id string) error {
+	var exists bool
+	var ruleSet *models.RuleSetView
+	switch elementType {
+	case "Rule":
+		rule, err := v.lookupRule(id)
+		if err != nil {
+			return err
+		}
+		exists = rule != nil
+	case "RuleSet":
+		var err error
+		if ruleSet, err = v.lookupRuleSet(id); err != nil {
+			return err
+		}
+		exists = ruleSet != nil
+	default:
+		return nil
+	}
+	link := elementType + " " + id
+	if !exists {
+		return v.missingElement(path, chain, elementType, id)
+	}
+	if ruleSet == nil {
+		return nil
+	}
+	next := append(append([]string{}, chain...), link)
+	for _, c := range chain {
+		if c == link {
+			v.errs = append(v.errs, fmt.Sprintf("%s: the RuleSet references itself: %s", path, strings.Join(next, " -> ")))
+			return nil
+		}
+	}
+	if v.walked[id] || ruleSet.Policy == nil {
+		return nil
+	}
+	v.walked[id] = true
+	childType := "Rule"
+	if ruleSet.ElementType != nil {
+		childType = *ruleSet.ElementType
+	}
+	for _, child := range *ruleSet.Policy {
+		if child == nil {
+			continue
+		}
+		if err := v.ruleSetElement(path, next, childType, strconv.Itoa(*child)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// missingElement reports an element of a ruleset which does not exist, or which is an object of the other type than
+// the element_type of the ruleset.
+func (v *policyValidator) missingElement(path string, chain []string, elementType, id string) error {
+	where := path
+	if len(chain) > 1 {
+		where = fmt.Sprintf("%s (%s)", path, strings.Join(chain, " -> "))
+	}
+	other := "RuleSet"
+	if elementType == "RuleSet" {
+		other = "Rule"
+	}
+	var exists bool
+	if other == "Rule" {
+		rule, err := v.lookupRule(id)
+		if err != nil {
+			return err
+		}
+		exists = rule != nil
+	} else {
+		ruleSet, err := v.lookupRuleSet(id)
+		if err != nil {
+			return err
+		}
+		exists = ruleSet != nil
+	}
+	if exists {
+		v.errs = append(v.errs, fmt.Sprintf("%s: the element_type is %s but %s is the id of a %s", where, elementType, id, other))
+	} else {
+		v.errs = append(v.errs, fmt.Sprintf("%s: the %s %s does not exist", where, elementType, id))
+	}
+	return nil
+}
+
+func (v *policyValidator) lookupRule(id string) (*models.RuleView, error) {
+	if rule, ok := v.rules[id]; ok {
+		return rule, nil
+	}
+	rule, err := v.rule(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read Rule %s: %w", id, err)
+	}
+	v.rules[id] = rule
+	return rule, nil
+}
+
+func (v *policyValidator) lookupRuleSet(id string) (*models.RuleSetView, error) {
+	if ruleSet, ok := v.ruleSets[id]; ok {
+		return ruleSet, nil
+	}
+	ruleSet, err := v.ruleSet(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read RuleSet %s: %w", id, err)
+	}
+	v.ruleSets[id] = ruleSet
+	return ruleSet, nil
+}
+
+// policyAppliesTo checks whether the web or api policy is evaluated for the application type, an unknown type is
+// assumed to apply.
+func policyAppliesTo(key, applicationType string) bool {
+	switch applicationType {
+	case "Web":
+		return key == "web"
+	case "API":
+		return key == "api"
+	}
+	return true
+}
+
+// unknownVariableValue is the placeholder the SDK uses for values which are not known until apply.
+const unknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+type policyReference struct {
+	Index int
+	Type  string
+	ID    string
+}
+
+func derefStrings(in []*string) []string {
+	var out []string
+	for _, s := range in {
+		if s != nil {
+			out = append(out, *s)
+		}
+	}
+	return out
+}
+
+// plannedPolicy returns the policy references from the planned policy, the ID is left empty for references which are
+// not yet known (e.g. the ID of a rule being created).
+func plannedPolicy(d *schema.ResourceDiff) map[string][]policyReference {
+	policy := map[string][]policyReference{}
+	v, ok := d.GetOk("policy")
+	if !ok {
+		return policy
+	}
+	for _, p := range v.([]interface{}) {
+		m, ok := p.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"web", "api"} {
+			items, _ := m[key].([]interface{})
+			for idx, item := range items {
+				i, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				ref := policyReference{Index: idx, Type: i["type"].(string), ID: i["id"].(string)}
+				if ref.ID == unknownVariableValue {
+					ref.ID = ""
+				}
+				policy[key] = append(policy[key], ref)
+			}
+		}
+	}
+	return policy
+}
+
+// policyDiff validates the policy of an application or application resource during plan, nothing is checked while
+// the provider is offline as the rules cannot be retrieved.
+func policyDiff(_ context.Context, d *schema.ResourceDiff, client paClient, applicationType, destination string) error {
+	policy := plannedPolicy(d)
+	if len(policy) == 0 {
+		return nil
+	}
+	err := newPolicyValidator(client).validate(policy, applicationType, destination)
+	if errors.Is(err, errOffline) {
+		return nil
+	}
+	return err
+}
+
+// ruleSetPolicyDiff validates the policy of a ruleset during plan, nothing is checked while the element_type is not
+// known or the provider is offline.
+func ruleSetPolicyDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(paClient)
+	if !ok || !d.NewValueKnown("element_type") {
+		return nil
+	}
+	var policy []policyReference
+	for idx, id := range d.Get("policy").([]interface{}) {
+		ref := policyReference{Index: idx}
+		if s, ok := id.(string); ok && s != unknownVariableValue {
+			ref.ID = s
+		}
+		policy = append(policy, ref)
+	}
+	err := newPolicyValidator(client).validateRuleSet(d.Id(), d.Get("element_type").(string), policy)
+	if errors.Is(err, errOffline) {
+		return nil
+	}
+	return err
+}
+
+// applicationPolicyDiff validates the policy of an application against the planned application type and destination.
+func applicationPolicyDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(paClient)
+	if !ok {
+		return nil
+	}
+	var applicationType, destination string
+	if d.NewValueKnown("application_type") {
+		applicationType = d.Get("application_type").(string)
+	}
+	if d.NewValueKnown("destination") {
+		destination = d.Get("destination").(string)
+	}
+	return policyDiff(ctx, d, client, applicationType, destination)
+}
+
+// applicationResourcePolicyDiff validates the policy of an application resource against the type and destination of
+// its application, when the application already exists.
+func applicationResourcePolicyDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(paClient)
+	if !ok {
+		return nil
+	}
+	var applicationType, destination string
+	if d.NewValueKnown("application_id") && d.Get("application_id").(string) != "" {
+		app, resp, err := client.Applications.GetApplicationCommand(&applications.GetApplicationCommandInput{Id: d.Get("application_id").(string)})
+		switch {
+		case err == nil:
+			if app.ApplicationType != nil {
+				applicationType = *app.ApplicationType
+			}
+			if app.Destination != nil {
+				destination = *app.Destination
+			}
+		case errors.Is(err, errOffline) || isNotFound(resp):
+		default:
+			return fmt.Errorf("unable to read Application %s: %s", d.Get("application_id"), err)
+		}
+	}
+	return policyDiff(ctx, d, client, applicationType, destination)
+}
@@ -0,0 +1,210 @@
+package sdkv2provider
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func testPolicyValidator() *policyValidator {
+	ruleViews := map[string]*models.RuleView{
+		"1": {Id: json.Number("1"), Name: String("site"), ClassName: String("site.Rule"), SupportedDestinations: &[]*string{String("Site")}},
+		"2": {Id: json.Number("2"), Name: String("agent"), ClassName: String("agent.Rule")},
+		"3": {Id: json.Number("3"), Name: String("both"), ClassName: String("both.Rule"), SupportedDestinations: &[]*string{String("Site"), String("Agent")}},
+		"4": {Id: json.Number("4"), Name: String("web"), ClassName: String("web.Rule"), SupportedDestinations: &[]*string{String("Site")}},
+		"6": {Id: json.Number("6"), Name: String("api"), ClassName: String("api.Rule"), SupportedDestinations: &[]*string{String("Site")}},
+	}
+	ruleSetViews := map[string]*models.RuleSetView{
+		"10": {Id: json.Number("10"), ElementType: String("Rule"), Policy: &[]*int{Int(1), Int(3)}},
+		"11": {Id: json.Number("11"), ElementType: String("RuleSet"), Policy: &[]*int{Int(10)}},
+		"12": {Id: json.Number("12"), ElementType: String("RuleSet"), Policy: &[]*int{Int(13)}},
+		"13": {Id: json.Number("13"), ElementType: String("RuleSet"), Policy: &[]*int{Int(14)}},
+		"14": {Id: json.Number("14"), ElementType: String("RuleSet"), Policy: &[]*int{Int(12)}},
+		"15": {Id: json.Number("15"), ElementType: String("Rule"), Policy: &[]*int{Int(7)}},
+		"16": {Id: json.Number("16"), ElementType: String("Rule"), Policy: &[]*int{Int(3), Int(6)}},
+	}
+	descriptors := map[string]*models.RuleDescriptorView{
+		"agent.Rule": {Type: String("Rule"), Modes: []*string{String("Agent")}},
+		"web.Rule":   {Type: String("Web"), Modes: []*string{String("Site")}},
+		"api.Rule":   {Type: String("API"), Modes: []*string{String("Site")}},
+	}
+	return &policyValidator{
+		rule: func(id string) (*models.RuleView, error) {
+			if id == "99" {
+				return nil, errors.New("boom")
+			}
+			return ruleViews[id], nil
+		},
+		ruleSet: func(id string) (*models.RuleSetView, error) {
+			return ruleSetViews[id], nil
+		},
+		descriptor: func(className string) (*models.RuleDescriptorView, error) {
+			return descriptors[className], nil
+		},
+		rules:    map[string]*models.RuleView{},
+		ruleSets: map[string]*models.RuleSetView{},
+		walked:   map[string]bool{},
+	}
+}
+
+func Test_policyValidator(t *testing.T) {
+	tests := []struct {
+		name            string
+		policy          map[string][]policyReference
+		applicationType string
+		destination     string
+		expected        string
+	}{
+		{
+			name:            "valid rules and rulesets",
+			policy:          map[string][]policyReference{"web": {{Index: 0, Type: "Rule", ID: "1"}, {Index: 1, Type: "RuleSet", ID: "11"}}},
+			applicationType: "Web",
+			destination:     "Site",
+		},
+		{
+			name:            "missing rule and ruleset",
+			policy:          map[string][]policyReference{"api": {{Index: 0, Type: "Rule", ID: "5"}, {Index: 1, Type: "RuleSet", ID: "50"}}},
+			applicationType: "API",
+			destination:     "Site",
+			expected:        "invalid policy:\n  policy.0.api.0: the Rule 5 does not exist\n  policy.0.api.1: the RuleSet 50 does not exist",
+		},
+		{
+			name:            "rule destination from the rule",
+			policy:          map[string][]policyReference{"web": {{Index: 2, Type: "Rule", ID: "1"}}},
+			applicationType: "Web",
+			destination:     "Agent",
+			expected:        "invalid policy:\n  policy.0.web.2: the Rule site (1) does not support the Agent destination, supported destinations: Site",
+		},
+		{
+			name:            "rule destination from the descriptor modes",
+			policy:          map[string][]policyReference{"web": {{Index: 0, Type: "Rule", ID: "2"}}},
+			applicationType: "Web",
+			destination:     "Site",
+			expected:        "invalid policy:\n  policy.0.web.0: the Rule agent (2) does not support the Site destination, supported destinations: Agent",
+		},
+		{
+			name:            "rule destination within a nested ruleset",
+			policy:          map[string][]policyReference{"web": {{Index: 0, Type: "RuleSet", ID: "11"}}},
+			applicationType: "Web",
+			destination:     "Agent",
+			expected:        "invalid policy:\n  policy.0.web.0 (RuleSet 11) (RuleSet 10): the Rule site (1) does not support the Agent destination, supported destinations: Site",
+		},
+		{
+			name:        "web and api rules in their own policies",
+			policy:      map[string][]policyReference{"web": {{Index: 0, Type: "Rule", ID: "4"}}, "api": {{Index: 0, Type: "Rule", ID: "6"}}},
+			destination: "Site",
+		},
+		{
+			name:            "web rule in the api policy",
+			policy:          map[string][]policyReference{"api": {{Index: 1, Type: "Rule", ID: "4"}}},
+			applicationType: "API",
+			destination:     "Site",
+			expected:        "invalid policy:\n  policy.0.api.1: the Rule web (4) can only be used in the web policy",
+		},
+		{
+			name:            "api rule in the web policy",
+			policy:          map[string][]policyReference{"web": {{Index: 0, Type: "RuleSet", ID: "16"}}},
+			applicationType: "Web",
+			destination:     "Site",
+			expected:        "invalid policy:\n  policy.0.web.0 (RuleSet 16): the Rule api (6) can only be used in the api policy",
+		},
+		{
+			name:            "api policy on a web application",
+			policy:          map[string][]policyReference{"api": {{Index: 0, Type: "Rule", ID: ""}}},
+			applicationType: "Web",
+			destination:     "Site",
+			expected:        "invalid policy:\n  the api policy is never evaluated for Web applications, use the web policy instead",
+		},
+		{
+			name:   "dynamic and unknown application details",
+			policy: map[string][]policyReference{"web": {{Index: 0, Type: "Rule", ID: "1"}}, "api": {{Index: 0, Type: "Rule", ID: "2"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testPolicyValidator().validate(tt.policy, tt.applicationType, tt.destination)
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_policyValidatorLookupError(t *testing.T) {
+	err := testPolicyValidator().validate(map[string][]policyReference{"web": {{Type: "Rule", ID: "99"}}}, "Web", "Site")
+	assert.EqualError(t, err, "unable to read Rule 99: boom")
+}
+
+func Test_policyValidatorRuleSet(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          string
+		elementType string
+		policy      []policyReference
+		expected    string
+	}{
+		{
+			name:        "rules",
+			elementType: "Rule",
+			policy:      []policyReference{{Index: 0, ID: "1"}, {Index: 1, ID: "2"}, {Index: 2, ID: ""}},
+		},
+		{
+			name:        "nested rulesets",
+			elementType: "RuleSet",
+			policy:      []policyReference{{Index: 0, ID: "10"}, {Index: 1, ID: "11"}},
+		},
+		{
+			name:        "missing rule",
+			elementType: "Rule",
+			policy:      []policyReference{{Index: 0, ID: "1"}, {Index: 1, ID: "5"}},
+			expected:    "invalid policy:\n  policy.1: the Rule 5 does not exist",
+		},
+		{
+			name:        "element type mismatch",
+			elementType: "Rule",
+			policy:      []policyReference{{Index: 0, ID: "10"}},
+			expected:    "invalid policy:\n  policy.0: the element_type is Rule but 10 is the id of a RuleSet",
+		},
+		{
+			name:        "self reference",
+			id:          "11",
+			elementType: "RuleSet",
+			policy:      []policyReference{{Index: 0, ID: "10"}, {Index: 1, ID: "11"}},
+			expected:    "invalid policy:\n  policy.1: the RuleSet references itself: RuleSet 11 -> RuleSet 11",
+		},
+		{
+			name:        "cycle through nested rulesets",
+			id:          "14",
+			elementType: "RuleSet",
+			policy:      []policyReference{{Index: 0, ID: "12"}},
+			expected:    "invalid policy:\n  policy.0: the RuleSet references itself: RuleSet 14 -> RuleSet 12 -> RuleSet 13 -> RuleSet 14",
+		},
+		{
+			name:        "cycle within nested rulesets",
+			elementType: "RuleSet",
+			policy:      []policyReference{{Index: 0, ID: "12"}},
+			expected:    "invalid policy:\n  policy.0: the RuleSet references itself: this RuleSet -> RuleSet 12 -> RuleSet 13 -> RuleSet 14 -> RuleSet 12",
+		},
+		{
+			name:        "missing rule within a nested ruleset",
+			elementType: "RuleSet",
+			policy:      []policyReference{{Index: 0, ID: "15"}},
+			expected:    "invalid policy:\n  policy.0 (this RuleSet -> RuleSet 15): the Rule 7 does not exist",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testPolicyValidator().validateRuleSet(tt.id, tt.elementType, tt.policy)
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expected)
+			}
+		})
+	}
+}
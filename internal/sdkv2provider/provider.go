@@ -9,7 +9,7 @@ import (
 
 // Provider does stuff
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	return withOfflineReads(withVersionRequirements(&schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"username": {
 				Type:        schema.TypeString,
@@ -36,14 +36,48 @@ func Provider() *schema.Provider {
 				Description: descriptions["base_url"],
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"PINGACCESS_BASEURL"}, "https://localhost:9000"),
 			},
+			"descriptor_cache_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["descriptor_cache_dir"],
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"PINGACCESS_DESCRIPTOR_CACHE_DIR"}, ""),
+			},
+			"offline": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: descriptions["offline"],
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"PINGACCESS_OFFLINE"}, false),
+			},
+			"offline_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["offline_version"],
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"PINGACCESS_OFFLINE_VERSION"}, ""),
+			},
+			"offline_descriptors": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: descriptions["offline_descriptors"],
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{"PINGACCESS_OFFLINE_DESCRIPTORS"}, ""),
+			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"pingaccess_acme_default":                  dataSourcePingAccessAcmeDefault(),
-			"pingaccess_certificate":                   dataSourcePingAccessCertificate(),
-			"pingaccess_keypair":                       dataSourcePingAccessKeyPair(),
-			"pingaccess_keypair_csr":                   dataSourcePingAccessKeyPairCsr(),
-			"pingaccess_pingfederate_runtime_metadata": dataSourcePingAccessPingFederateRuntimeMetadata(),
-			"pingaccess_version":                       dataSourcePingAccessVersion(),
+			"pingaccess_acme_default":                                   dataSourcePingAccessAcmeDefault(),
+			"pingaccess_application_resource_matching_evaluation_order": dataSourcePingAccessApplicationResourceMatchingEvaluationOrder(),
+			"pingaccess_agents":                                         dataSourcePingAccessAgents(),
+			"pingaccess_backup":                                         dataSourcePingAccessBackup(),
+			"pingaccess_certificate":                                    dataSourcePingAccessCertificate(),
+			"pingaccess_config_export":                                  dataSourcePingAccessConfigExport(),
+			"pingaccess_engines":                                        dataSourcePingAccessEngines(),
+			"pingaccess_keypair":                                        dataSourcePingAccessKeyPair(),
+			"pingaccess_keypair_csr":                                    dataSourcePingAccessKeyPairCsr(),
+			"pingaccess_pingfederate_runtime_metadata":                  dataSourcePingAccessPingFederateRuntimeMetadata(),
+			"pingaccess_plugin_descriptor":                              dataSourcePingAccessPluginDescriptor(),
+			"pingaccess_plugin_descriptors":                             dataSourcePingAccessPluginDescriptors(),
+			"pingaccess_replica_admins":                                 dataSourcePingAccessReplicaAdmins(),
+			"pingaccess_rule_descriptor":                                dataSourcePingAccessRuleDescriptor(),
+			"pingaccess_rule_descriptors":                               dataSourcePingAccessRuleDescriptors(),
+			"pingaccess_version":                                        dataSourcePingAccessVersion(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"pingaccess_acme_server":                     resourcePingAccessAcmeServer(),
@@ -51,19 +85,20 @@ func Provider() *schema.Provider {
 			"pingaccess_authn_req_list":                  resourcePingAccessAuthnReqList(),
 			"pingaccess_availability_profile":            resourcePingAccessAvailabilityProfile(),
 			"pingaccess_certificate":                     resourcePingAccessCertificate(),
+			"pingaccess_config_import":                   resourcePingAccessConfigImport(),
 			"pingaccess_engine_listener":                 resourcePingAccessEngineListener(),
+			"pingaccess_engine_replication_barrier":      resourcePingAccessEngineReplicationBarrier(),
 			"pingaccess_hsm_provider":                    resourcePingAccessHsmProvider(),
 			"pingaccess_https_listener":                  resourcePingAccessHTTPSListener(),
-			"pingaccess_identity_mapping":                resourcePingAccessIdentityMapping(),
 			"pingaccess_keypair":                         resourcePingAccessKeyPair(),
 			"pingaccess_keypair_csr":                     resourcePingAccessKeyPairCsr(),
 			"pingaccess_load_balancing_strategy":         resourcePingAccessLoadBalancingStrategy(),
-			"pingaccess_rule":                            resourcePingAccessRule(),
 			"pingaccess_ruleset":                         resourcePingAccessRuleSet(),
 			"pingaccess_virtualhost":                     resourcePingAccessVirtualHost(),
 			"pingaccess_site":                            resourcePingAccessSite(),
 			"pingaccess_application":                     resourcePingAccessApplication(),
 			"pingaccess_application_resource":            resourcePingAccessApplicationResource(),
+			"pingaccess_application_resource_order":      resourcePingAccessApplicationResourceOrder(),
 			"pingaccess_websession":                      resourcePingAccessWebSession(),
 			"pingaccess_third_party_service":             resourcePingAccessThirdPartyService(),
 			"pingaccess_trusted_certificate_group":       resourcePingAccessTrustedCertificateGroups(),
@@ -74,27 +109,35 @@ func Provider() *schema.Provider {
 			"pingaccess_http_config_request_host_source": resourcePingAccessHTTPConfigRequestHostSource(),
 		},
 		ConfigureContextFunc: providerConfigure,
-	}
+	}))
 }
 
 var descriptions map[string]string
 
 func init() {
 	descriptions = map[string]string{
-		"username": "The username for pingaccess API.",
-		"password": "The password for pingaccess API.",
-		"base_url": "The base url of the pingaccess API.",
-		"context":  "The context path of the pingaccess API.",
+		"username":             "The username for pingaccess API.",
+		"password":             "The password for pingaccess API.",
+		"base_url":             "The base url of the pingaccess API.",
+		"context":              "The context path of the pingaccess API.",
+		"descriptor_cache_dir": "A directory to cache plugin descriptors retrieved from the pingaccess API, these are stored per PingAccess version.",
+		"offline":              "Configure the provider without connecting to the pingaccess API, plugin configuration is validated using local descriptors and any operation requiring the API will fail.",
+		"offline_version":      "The PingAccess version to validate against when the provider is offline.",
+		"offline_descriptors":  "A JSON file of plugin descriptors to use when the provider is offline, these take precedence over the descriptor cache and bundled descriptors.",
 	}
 }
 
 func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 
 	config := &cfg{
-		Username: d.Get("username").(string),
-		Password: d.Get("password").(string),
-		BaseURL:  d.Get("base_url").(string),
-		Context:  d.Get("context").(string),
+		Username:           d.Get("username").(string),
+		Password:           d.Get("password").(string),
+		BaseURL:            d.Get("base_url").(string),
+		Context:            d.Get("context").(string),
+		DescriptorCacheDir: d.Get("descriptor_cache_dir").(string),
+		Offline:            d.Get("offline").(bool),
+		OfflineVersion:     d.Get("offline_version").(string),
+		OfflineDescriptors: d.Get("offline_descriptors").(string),
 	}
 
 	return config.Client()
@@ -13,10 +13,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pingaccesstest"
 	protocol "github.com/iwarapter/terraform-provider-pingaccess/internal/protocolprovider"
 
-	"github.com/iwarapter/pingaccess-sdk-go/v62/services/version"
-
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	tfmux "github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -69,20 +68,61 @@ func TestMain(m *testing.M) {
 	l, _ := net.Listen("tcp", ":0")
 	server.Listener = l //for CI tests as host.docker.internal is window/macosx
 	server.StartTLS()
-	// Close the server when test finishes
-	defer server.Close()
 
 	host, _ := os.Hostname() //for CI tests as host.docker.internal is window/macosx
 	os.Setenv("PINGFEDERATE_TEST_IP", strings.Replace(server.URL, "[::]", host, -1))
 
-	conf = paCfg.NewConfig().WithUsername("administrator").WithPassword("2Access").WithEndpoint("https://localhost:9000/pa-admin-api/v3")
-	vs := version.New(conf)
-	v, _, err := vs.VersionCommand()
+	resource.TestMain(pingaccesstest.MainFunc(func() int {
+		// Close the servers when the tests finish
+		defer server.Close()
+		target, err := pingaccesstest.Setup("testdata/cassettes", map[string]string{os.Getenv("PINGFEDERATE_TEST_IP"): "https://pingfederate.test"})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		os.Setenv("PINGACCESS_BASEURL", target.BaseURL)
+		conf = target.Config()
+		paVersion = target.Version
+		code := m.Run()
+		if err := target.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to close the PingAccess test target: %s\n", err)
+			return 1
+		}
+		return code
+	}))
+}
+
+// paVersionAtLeast checks whether the acceptance tests are running against the given PingAccess version or above.
+func paVersionAtLeast(v string) bool {
+	parsed, err := parsePingAccessVersion(paVersion)
 	if err != nil {
-		os.Exit(1)
+		return false
+	}
+	return paClient{apiVersion: parsed}.versionAtLeast(v)
+}
+
+// newFakeClient returns a client configured against a fake PingAccess admin API of the default version, the server is
+// closed when the test finishes.
+func newFakeClient(t *testing.T) (paClient, *pingaccesstest.Server) {
+	t.Helper()
+	return newFakeClientVersion(t, pingaccesstest.DefaultVersion)
+}
+
+// newFakeClientVersion returns a client configured against a fake PingAccess admin API of the given version.
+func newFakeClientVersion(t *testing.T, version string) (paClient, *pingaccesstest.Server) {
+	t.Helper()
+	s := pingaccesstest.NewServer(version)
+	t.Cleanup(s.Close)
+	client, diags := (&cfg{
+		Username: pingaccesstest.Username,
+		Password: pingaccesstest.Password,
+		Context:  pingaccesstest.Context,
+		BaseURL:  s.URL,
+	}).Client()
+	if diags.HasError() {
+		t.Fatalf("unable to configure the client: %v", diags)
 	}
-	paVersion = *v.Version
-	resource.TestMain(m)
+	return client.(paClient), s
 }
 
 var testAccProvider *schema.Provider
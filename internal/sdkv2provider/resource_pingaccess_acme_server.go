@@ -16,7 +16,7 @@ func resourcePingAccessAcmeServer() *schema.Resource {
 		ReadContext:   resourcePingAccessAcmeServerRead,
 		DeleteContext: resourcePingAccessAcmeServerDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, acmeServerNameKey),
 		},
 		Schema:      resourcePingAccessAcmeServerSchema(),
 		Description: `Provides configuration for ACME Server within PingAccess.`,
@@ -49,7 +49,7 @@ func resourcePingAccessAcmeServerCreate(_ context.Context, d *schema.ResourceDat
 
 	result, _, err := svc.AddAcmeServerCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create AcmeServer: %s", err)
+		return apiErrorDiags(err, "unable to create AcmeServer", resourcePingAccessAcmeServerSchema())
 	}
 	d.SetId(*result.Id)
 	return resourcePingAccessAcmeServerReadResult(d, &input.Body)
@@ -60,9 +60,9 @@ func resourcePingAccessAcmeServerRead(_ context.Context, d *schema.ResourceData,
 	input := &acme.GetAcmeServerCommandInput{
 		AcmeServerId: d.Id(),
 	}
-	result, _, err := svc.GetAcmeServerCommand(input)
+	result, resp, err := svc.GetAcmeServerCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read AcmeServer: %s", err)
+		return readErrorDiags(d, resp, err, "AcmeServer")
 	}
 	return resourcePingAccessAcmeServerReadResult(d, result)
 }
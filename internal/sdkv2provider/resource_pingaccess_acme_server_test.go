@@ -17,7 +17,7 @@ func init() {
 	resource.AddTestSweepers("acme_server", &resource.Sweeper{
 		Name: "acme_server",
 		F: func(r string) error {
-			if !(paClient{apiVersion: paVersion}).Is60OrAbove() {
+			if !paVersionAtLeast("6.0") {
 				return nil
 			}
 			svc := acme.New(conf)
@@ -38,7 +38,7 @@ func init() {
 
 func TestAccPingAccessAcmeServer(t *testing.T) {
 	resourceName := "pingaccess_acme_server.acc_test"
-	if !(paClient{apiVersion: paVersion}).Is60OrAbove() {
+	if !paVersionAtLeast("6.0") {
 		t.Skipf("This test only runs against PingAccess 6.0 and above, not: %s", paVersion)
 	}
 	resource.Test(t, resource.TestCase{
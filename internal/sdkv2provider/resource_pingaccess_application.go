@@ -19,10 +19,13 @@ func resourcePingAccessApplication() *schema.Resource {
 		UpdateContext: resourcePingAccessApplicationUpdate,
 		DeleteContext: resourcePingAccessApplicationDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, applicationNameKey, applicationContextRootKey),
 		},
-		Schema:      resourcePingAccessApplicationSchema(),
-		Description: `Provides configuration for Applications within PingAccess.`,
+		Schema:        resourcePingAccessApplicationSchema(),
+		CustomizeDiff: resourcePingAccessApplicationDiff,
+		Description: `Provides configuration for Applications within PingAccess.
+
+-> The resources of an application can be managed either with ` + "`resource`" + ` blocks or with ` + "`pingaccess_application_resource`" + `, but not both. Once any ` + "`resource`" + ` blocks are set the application manages every resource except the root resource and removes any resources which are not configured.`,
 	}
 }
 
@@ -130,10 +133,16 @@ func resourcePingAccessApplicationSchema() map[string]*schema.Schema {
 			Optional:    true,
 			Description: "True if the application requires HTTPS connections.",
 		},
+		"resource": applicationInlineResourceSchema(),
+		"risk_policy_id": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Description: "The ID of the risk policy applied to the application, or zero if none. Requires PingAccess 7.0 or above.",
+		},
 		"resource_order": {
 			Type:        schema.TypeList,
 			Computed:    true,
-			Description: "The explicit resource order defined when manual ordering is enabled. Each existing resource ID must be represented.",
+			Description: "The explicit resource order defined when manual ordering is enabled. Each existing resource ID must be represented. The order is read-only, manage it with `pingaccess_application_resource_order`.",
 			Elem: &schema.Schema{
 				Type: schema.TypeInt,
 			},
@@ -166,43 +175,119 @@ func resourcePingAccessApplicationSchema() map[string]*schema.Schema {
 	}
 }
 
-func resourcePingAccessApplicationCreate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	svc := m.(paClient).Applications
+func resourcePingAccessApplicationDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if err := applicationResourcesDiff(ctx, d, m); err != nil {
+		return err
+	}
+	if err := applicationResourcesVersionDiff(ctx, d, m); err != nil {
+		return err
+	}
+	return applicationPolicyDiff(ctx, d, m)
+}
+
+func resourcePingAccessApplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(paClient)
 	input := &applications.AddApplicationCommandInput{
 		Body: *resourcePingAccessApplicationReadData(d),
 	}
-	result, _, err := svc.AddApplicationCommand(input)
-	if err != nil {
-		return diag.Errorf("unable to create Application: %s", err)
+	var diags diag.Diagnostics
+	if client.V7 != nil {
+		result, _, err := client.V7.AddApplicationCommand(resourcePingAccessApplicationReadDataV7(d, &input.Body))
+		if err != nil {
+			return apiErrorDiags(err, "unable to create Application", resourcePingAccessApplicationSchema())
+		}
+		d.SetId(result.Id.String())
+		diags = resourcePingAccessApplicationReadResultV7(d, result)
+	} else {
+		result, _, err := client.Applications.AddApplicationCommand(input)
+		if err != nil {
+			return apiErrorDiags(err, "unable to create Application", resourcePingAccessApplicationSchema())
+		}
+		d.SetId(result.Id.String())
+		diags = resourcePingAccessApplicationReadResult(d, &input.Body)
 	}
 
-	d.SetId(result.Id.String())
-	return resourcePingAccessApplicationReadResult(d, &input.Body)
+	changes := diffApplicationResources(nil, d.Get("resource").(*schema.Set).List())
+	if changes.empty() {
+		return diags
+	}
+	if diags := syncApplicationResources(d, client, changes); diags.HasError() {
+		return diags
+	}
+	return resourcePingAccessApplicationRead(ctx, d, m)
 }
 
 func resourcePingAccessApplicationRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	svc := m.(paClient).Applications
-	input := &applications.GetApplicationCommandInput{
-		Id: d.Id(),
+	client := m.(paClient)
+	var diags diag.Diagnostics
+	if client.V7 != nil {
+		result, resp, err := client.V7.GetApplicationCommand(d.Id())
+		if err != nil {
+			return readErrorDiags(d, resp, err, "Application")
+		}
+		diags = resourcePingAccessApplicationReadResultV7(d, result)
+	} else {
+		result, resp, err := client.Applications.GetApplicationCommand(&applications.GetApplicationCommandInput{Id: d.Id()})
+		if err != nil {
+			return readErrorDiags(d, resp, err, "Application")
+		}
+		diags = resourcePingAccessApplicationReadResult(d, result)
 	}
-	result, _, err := svc.GetApplicationCommand(input)
-	if err != nil {
-		return diag.Errorf("unable to read Application: %s", err)
+	if applicationResourcesManaged(d) {
+		diags = append(diags, readApplicationResources(d, m.(paClient))...)
 	}
-	return resourcePingAccessApplicationReadResult(d, result)
+	return diags
 }
 
-func resourcePingAccessApplicationUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	svc := m.(paClient).Applications
+func resourcePingAccessApplicationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(paClient)
+	var changes applicationResourceChanges
+	if d.HasChange("resource") {
+		o, n := d.GetChange("resource")
+		changes = diffApplicationResources(o.(*schema.Set).List(), n.(*schema.Set).List())
+	}
 	input := applications.UpdateApplicationCommandInput{
 		Body: *resourcePingAccessApplicationReadData(d),
 		Id:   d.Id(),
 	}
-	result, _, err := svc.UpdateApplicationCommand(&input)
+	order, diags := currentResourceOrder(client, d.Id())
+	if diags.HasError() {
+		return diags
+	}
+	input.Body.ResourceOrder = order
+	if client.V7 != nil {
+		result, _, err := client.V7.UpdateApplicationCommand(d.Id(), resourcePingAccessApplicationReadDataV7(d, &input.Body))
+		if err != nil {
+			return apiErrorDiags(err, "unable to update Application", resourcePingAccessApplicationSchema())
+		}
+		diags = resourcePingAccessApplicationReadResultV7(d, result)
+	} else {
+		result, _, err := client.Applications.UpdateApplicationCommand(&input)
+		if err != nil {
+			return apiErrorDiags(err, "unable to update Application", resourcePingAccessApplicationSchema())
+		}
+		diags = resourcePingAccessApplicationReadResult(d, result)
+	}
+	if changes.empty() {
+		return diags
+	}
+	// the application is updated before its resources so they are applied against the new application settings, the
+	// current resource order still lists the resources being deleted and PingAccess drops them from it on delete.
+	if diags := syncApplicationResources(d, client, changes); diags.HasError() {
+		return diags
+	}
+	return resourcePingAccessApplicationRead(ctx, d, m)
+}
+
+// currentResourceOrder returns the resource order PingAccess holds for the application. The order is read-only on
+// pingaccess_application, so updates send the current order and keep any order applied by
+// pingaccess_application_resource_order rather than the order last read into the state.
+func currentResourceOrder(client paClient, id string) (*[]*int, diag.Diagnostics) {
+	app, _, err := client.Applications.GetApplicationCommand(&applications.GetApplicationCommandInput{Id: id})
 	if err != nil {
-		return diag.Errorf("unable to update Application: %s", err)
+		return nil, diag.Errorf("unable to read Application %s: %s", id, err)
 	}
-	return resourcePingAccessApplicationReadResult(d, result)
+	return app.ResourceOrder, nil
 }
 
 func resourcePingAccessApplicationDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -281,6 +366,27 @@ func policyStateHasData(d *schema.ResourceData) bool {
 	return false
 }
 
+// resourcePingAccessApplicationReadResultV7 sets the attributes of a PingAccess 7.x application, including those added
+// in 7.0.
+func resourcePingAccessApplicationReadResultV7(d *schema.ResourceData, rv *applicationViewV7) diag.Diagnostics {
+	diags := resourcePingAccessApplicationReadResult(d, &rv.ApplicationView)
+	riskPolicyID := 0
+	if rv.RiskPolicyId != nil {
+		riskPolicyID = *rv.RiskPolicyId
+	}
+	setResourceDataIntWithDiagnostic(d, "risk_policy_id", &riskPolicyID, &diags)
+	return diags
+}
+
+// resourcePingAccessApplicationReadDataV7 adds the attributes added in PingAccess 7.0 to the application.
+func resourcePingAccessApplicationReadDataV7(d *schema.ResourceData, app *models.ApplicationView) *applicationViewV7 {
+	v7 := &applicationViewV7{ApplicationView: *app}
+	if v, ok := d.GetOk("risk_policy_id"); ok {
+		v7.RiskPolicyId = Int(v.(int))
+	}
+	return v7
+}
+
 func resourcePingAccessApplicationReadData(d *schema.ResourceData) *models.ApplicationView {
 	siteID, _ := strconv.Atoi(d.Get("site_id").(string))
 	virtualHostIds := expandStringList(d.Get("virtual_host_ids").(*schema.Set).List())
@@ -353,10 +459,5 @@ func resourcePingAccessApplicationReadData(d *schema.ResourceData) *models.Appli
 		application.Policy = expandPolicy(val.([]interface{}))
 	}
 
-	if val, ok := d.GetOk("resource_order"); ok {
-		ids := expandIntList(val.([]interface{}))
-		application.ResourceOrder = &ids
-	}
-
 	return application
 }
@@ -20,10 +20,11 @@ func resourcePingAccessApplicationResource() *schema.Resource {
 		UpdateContext: resourcePingAccessApplicationResourceUpdate,
 		DeleteContext: resourcePingAccessApplicationResourceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: resourcePingAccessApplicationResourceImport,
+			StateContext: importByKey(resourcePingAccessApplicationResourceImport, applicationResourceNameKey),
 		},
-		Schema:      resourcePingAccessApplicationResourceSchema(),
-		Description: "Provides configuration for Application Resources within PingAccess.",
+		Schema:        resourcePingAccessApplicationResourceSchema(),
+		CustomizeDiff: applicationResourcePolicyDiff,
+		Description:   "Provides configuration for Application Resources within PingAccess.",
 	}
 }
 
@@ -41,6 +42,11 @@ func resourcePingAccessApplicationResourceSchema() map[string]*schema.Schema {
 			ForceNew:    true,
 			Description: "The id of the associated application. This field is read-only.",
 		},
+		"authentication_challenge_policy_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The ID of the authentication challenge policy used for the resource, when not set the authentication challenge policy of the application is used.",
+		},
 		"audit_level": {
 			Type:             schema.TypeString,
 			Optional:         true,
@@ -114,6 +120,77 @@ func resourcePingAccessApplicationResourceSchema() map[string]*schema.Schema {
 			Default:     false,
 			Description: "True if the resource is unprotected.",
 		},
+		"query_param_config": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Query parameter configuration settings to match requests to URLs with query parameters.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"matches_no_params": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     false,
+						Description: "True if the resource matches requests which do not have any query parameters.",
+					},
+					"params": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "The query parameter name and value matchers, a request matches when any of the query parameters match.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:        schema.TypeList,
+									Required:    true,
+									MaxItems:    1,
+									Description: "The query parameter name matcher.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"pattern": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "The query parameter name pattern.",
+											},
+											"type": {
+												Type:        schema.TypeString,
+												Required:    true,
+												Description: "The pattern syntax type.",
+											},
+										},
+									},
+								},
+								"value": {
+									Type:        schema.TypeList,
+									Required:    true,
+									MaxItems:    1,
+									Description: "The query parameter value matcher.",
+									Elem: &schema.Resource{
+										Schema: map[string]*schema.Schema{
+											"match_any": {
+												Type:        schema.TypeBool,
+												Optional:    true,
+												Default:     false,
+												Description: "True if any value of the query parameter matches, the pattern is ignored.",
+											},
+											"pattern": {
+												Type:        schema.TypeString,
+												Optional:    true,
+												Description: "The query parameter value pattern.",
+											},
+											"type": {
+												Type:        schema.TypeString,
+												Optional:    true,
+												Description: "The pattern syntax type.",
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
 		"resource_type": {
 			Type:             schema.TypeString,
 			Optional:         true,
@@ -167,7 +244,7 @@ func resourcePingAccessApplicationResourceCreate(ctx context.Context, d *schema.
 		}
 		result, _, err := svc.GetApplicationResourcesCommand(&input)
 		if err != nil {
-			return diag.Errorf("unable to create ApplicationResource: %s", err)
+			return apiErrorDiags(err, "unable to create ApplicationResource", resourcePingAccessApplicationResourceSchema())
 		}
 		rv := result.Items[0]
 		d.SetId(rv.Id.String())
@@ -182,7 +259,7 @@ func resourcePingAccessApplicationResourceCreate(ctx context.Context, d *schema.
 
 	result, _, err := svc.AddApplicationResourceCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create ApplicationResource: %s", err)
+		return apiErrorDiags(err, "unable to create ApplicationResource", resourcePingAccessApplicationResourceSchema())
 	}
 
 	d.SetId(result.Id.String())
@@ -196,9 +273,9 @@ func resourcePingAccessApplicationResourceRead(_ context.Context, d *schema.Reso
 		ResourceId:    d.Id(),
 	}
 
-	result, _, err := svc.GetApplicationResourceCommand(input)
+	result, resp, err := svc.GetApplicationResourceCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read ApplicationResource: %s", err)
+		return readErrorDiags(d, resp, err, "ApplicationResource")
 	}
 
 	return resourcePingAccessApplicationResourceReadResult(d, result)
@@ -214,7 +291,7 @@ func resourcePingAccessApplicationResourceUpdate(_ context.Context, d *schema.Re
 
 	result, _, err := svc.UpdateApplicationResourceCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update ApplicationResource: %s", err)
+		return apiErrorDiags(err, "unable to update ApplicationResource", resourcePingAccessApplicationResourceSchema())
 	}
 	return resourcePingAccessApplicationResourceReadResult(d, result)
 }
@@ -253,6 +330,9 @@ func resourcePingAccessApplicationResourceReadResult(d *schema.ResourceData, rv
 	setResourceDataBoolWithDiagnostic(d, "anonymous", rv.Anonymous, &diags)
 	setResourceDataStringWithDiagnostic(d, "application_id", String(strconv.Itoa(*rv.ApplicationId)), &diags)
 	setResourceDataStringWithDiagnostic(d, "audit_level", rv.AuditLevel, &diags)
+	if err := d.Set("authentication_challenge_policy_id", rv.AuthenticationChallengePolicyId); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
 	setResourceDataStringWithDiagnostic(d, "default_auth_type_override", rv.DefaultAuthTypeOverride, &diags)
 	setResourceDataBoolWithDiagnostic(d, "enabled", rv.Enabled, &diags)
 	if err := d.Set("methods", *rv.Methods); err != nil {
@@ -271,6 +351,14 @@ func resourcePingAccessApplicationResourceReadResult(d *schema.ResourceData, rv
 			diags = append(diags, diag.FromErr(err)...)
 		}
 	}
+	queryParamConfig := flattenQueryParamConfig(rv.QueryParamConfig)
+	if len(queryParamConfig) == 0 && rv.QueryParamConfig != nil && len(d.Get("query_param_config").([]interface{})) > 0 {
+		// an empty query_param_config block in the configuration is kept
+		queryParamConfig = []interface{}{map[string]interface{}{"matches_no_params": false, "params": []interface{}{}}}
+	}
+	if err := d.Set("query_param_config", queryParamConfig); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
 	setResourceDataBoolWithDiagnostic(d, "root_resource", rv.RootResource, &diags)
 	setResourceDataBoolWithDiagnostic(d, "unprotected", rv.Unprotected, &diags)
 	diags = append(diags, flattenPolicies(d, rv.Policy)...)
@@ -305,6 +393,10 @@ func resourcePingAccessApplicationResourceReadData(d *schema.ResourceData) *mode
 		resource.AuditLevel = String(v.(string))
 	}
 
+	if v, ok := d.GetOk("authentication_challenge_policy_id"); ok {
+		resource.AuthenticationChallengePolicyId = String(v.(string))
+	}
+
 	if v, ok := d.GetOk("default_auth_type_override"); ok {
 		resource.DefaultAuthTypeOverride = String(v.(string))
 	}
@@ -330,6 +422,10 @@ func resourcePingAccessApplicationResourceReadData(d *schema.ResourceData) *mode
 
 	}
 
+	if v, ok := d.GetOk("query_param_config"); ok {
+		resource.QueryParamConfig = expandQueryParamConfig(v.([]interface{}))
+	}
+
 	if v, ok := d.GetOk("resource_type"); ok {
 		resource.ResourceType = String(v.(string))
 	}
@@ -0,0 +1,204 @@
+package sdkv2provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/applications"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePingAccessApplicationResourceOrder() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePingAccessApplicationResourceOrderCreate,
+		ReadContext:   resourcePingAccessApplicationResourceOrderRead,
+		UpdateContext: resourcePingAccessApplicationResourceOrderUpdate,
+		DeleteContext: resourcePingAccessApplicationResourceOrderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: importByKey(resourcePingAccessApplicationResourceOrderImport, applicationNameKey, applicationContextRootKey),
+		},
+		Schema:        resourcePingAccessApplicationResourceOrderSchema(),
+		CustomizeDiff: resourcePingAccessApplicationResourceOrderDiff,
+		Description: `Manages the evaluation order of the resources of an Application within PingAccess.
+
+-> The application must have ` + "`manual_ordering_enabled`" + ` set and every resource of the application must be included in the order, use ` + "`depends_on`" + ` to apply the order once all of the ` + "`pingaccess_application_resource`" + ` resources exist. Deleting this resource leaves the current order in place.
+
+-> The ` + "`resource_order`" + ` of ` + "`pingaccess_application`" + ` is read-only, updates to the application keep the order applied by this resource.`,
+	}
+}
+
+func resourcePingAccessApplicationResourceOrderSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"application_id": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "The id of the application to order the resources of.",
+		},
+		"resource_ids": {
+			Type:          schema.TypeList,
+			Optional:      true,
+			Computed:      true,
+			ConflictsWith: []string{"use_auto_order"},
+			Description:   "The ids of the application resources in the order they are evaluated, every resource of the application must be included.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"use_auto_order": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Apply the order suggested by PingAccess for the resources of the application instead of `resource_ids`, the order is reapplied whenever the suggested order changes.",
+		},
+	}
+}
+
+func resourcePingAccessApplicationResourceOrderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	d.SetId(d.Get("application_id").(string))
+	return resourcePingAccessApplicationResourceOrderUpdate(ctx, d, m)
+}
+
+func resourcePingAccessApplicationResourceOrderRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	app, resp, err := m.(paClient).Applications.GetApplicationCommand(&applications.GetApplicationCommandInput{Id: d.Id()})
+	if err != nil {
+		return readErrorDiags(d, resp, err, "ApplicationResourceOrder")
+	}
+	return resourcePingAccessApplicationResourceOrderReadResult(d, app)
+}
+
+func resourcePingAccessApplicationResourceOrderUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(paClient)
+	app, resp, err := client.Applications.GetApplicationCommand(&applications.GetApplicationCommandInput{Id: d.Id()})
+	if err != nil {
+		if d.IsNewResource() {
+			return diag.Errorf("unable to read Application %s: %s", d.Id(), err)
+		}
+		return readErrorDiags(d, resp, err, "Application")
+	}
+	if app.ManualOrderingEnabled == nil || !*app.ManualOrderingEnabled {
+		return diag.Errorf("manual_ordering_enabled must be set on the Application %s to order its resources", d.Id())
+	}
+
+	var order []int
+	if d.Get("use_auto_order").(bool) {
+		if order, err = autoResourceOrder(client, d.Id()); err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		for _, id := range d.Get("resource_ids").([]interface{}) {
+			i, err := strconv.Atoi(id.(string))
+			if err != nil {
+				return diag.Errorf("invalid resource id '%s', must be a number", id)
+			}
+			order = append(order, i)
+		}
+	}
+
+	result, err := setApplicationResourceOrder(client, d.Id(), app, order)
+	if err != nil {
+		return apiErrorDiags(err, "unable to update ApplicationResourceOrder", resourcePingAccessApplicationResourceOrderSchema())
+	}
+	return resourcePingAccessApplicationResourceOrderReadResult(d, result)
+}
+
+func resourcePingAccessApplicationResourceOrderDelete(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	return nil
+}
+
+func resourcePingAccessApplicationResourceOrderImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	_ = d.Set("application_id", d.Id())
+	_ = d.Set("use_auto_order", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourcePingAccessApplicationResourceOrderReadResult(d *schema.ResourceData, app *models.ApplicationView) diag.Diagnostics {
+	var diags diag.Diagnostics
+	setResourceDataStringWithDiagnostic(d, "application_id", String(d.Id()), &diags)
+	ids := []string{}
+	if app.ResourceOrder != nil {
+		for _, id := range *app.ResourceOrder {
+			if id != nil {
+				ids = append(ids, strconv.Itoa(*id))
+			}
+		}
+	}
+	if err := d.Set("resource_ids", ids); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+// resourcePingAccessApplicationResourceOrderDiff plans the resource_ids to change when using the auto order and the
+// order suggested by PingAccess no longer matches the current order.
+func resourcePingAccessApplicationResourceOrderDiff(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(paClient)
+	if !ok || client.offline || d.Id() == "" || !d.Get("use_auto_order").(bool) {
+		return nil
+	}
+	if d.HasChange("use_auto_order") {
+		return d.SetNewComputed("resource_ids")
+	}
+	order, err := autoResourceOrder(client, d.Id())
+	if err != nil {
+		return err
+	}
+	current := d.Get("resource_ids").([]interface{})
+	if len(current) != len(order) {
+		return d.SetNewComputed("resource_ids")
+	}
+	for i, id := range order {
+		if current[i].(string) != strconv.Itoa(id) {
+			return d.SetNewComputed("resource_ids")
+		}
+	}
+	return nil
+}
+
+// autoResourceOrder returns the order PingAccess suggests for the resources of the application.
+func autoResourceOrder(client paClient, applicationID string) ([]int, error) {
+	result, _, err := client.Applications.GetResourceAutoOrderCommand(&applications.GetResourceAutoOrderCommandInput{Id: applicationID})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the suggested resource order of Application %s: %s", applicationID, err)
+	}
+	var order []int
+	if result.ResourceIds != nil {
+		for _, id := range *result.ResourceIds {
+			if id != nil {
+				order = append(order, *id)
+			}
+		}
+	}
+	return order, nil
+}
+
+// setApplicationResourceOrder updates the resource order of the application, with PingAccess 7.x the application is
+// read and updated with the 7.x client so that the attributes added in 7.0 are kept.
+func setApplicationResourceOrder(client paClient, applicationID string, app *models.ApplicationView, order []int) (*models.ApplicationView, error) {
+	ids := make([]*int, 0, len(order))
+	for i := range order {
+		ids = append(ids, &order[i])
+	}
+	if client.V7 != nil {
+		current, _, err := client.V7.GetApplicationCommand(applicationID)
+		if err != nil {
+			return nil, err
+		}
+		current.ResourceOrder = &ids
+		result, _, err := client.V7.UpdateApplicationCommand(applicationID, current)
+		if err != nil {
+			return nil, err
+		}
+		return &result.ApplicationView, nil
+	}
+	app.ResourceOrder = &ids
+	result, _, err := client.Applications.UpdateApplicationCommand(&applications.UpdateApplicationCommandInput{
+		Id:   applicationID,
+		Body: *app,
+	})
+	return result, err
+}
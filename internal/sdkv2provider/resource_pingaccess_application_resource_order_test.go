@@ -0,0 +1,152 @@
+package sdkv2provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccPingAccessApplicationResourceOrder(t *testing.T) {
+	resourceName := "pingaccess_application_resource_order.acc_test"
+	dataSourceName := "data.pingaccess_application_resource_matching_evaluation_order.acc_test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPingAccessApplicationResourceOrderConfig(`resource_ids = [
+    pingaccess_application_resource.acc_test_two.id,
+    pingaccess_application_resource.acc_test_one.id,
+    pingaccess_application_resource.acc_test_root.id,
+  ]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "resource_ids.0", "pingaccess_application_resource.acc_test_two", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "resource_ids.1", "pingaccess_application_resource.acc_test_one", "id"),
+					resource.TestCheckResourceAttr(dataSourceName, "entries.#", "3"),
+					resource.TestCheckResourceAttr(dataSourceName, "entries.0.pattern_type", "REGEX"),
+				),
+			},
+			{
+				Config: testAccPingAccessApplicationResourceOrderConfig(`use_auto_order = true`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "resource_ids.#", "3"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"use_auto_order"},
+			},
+		},
+	})
+}
+
+func testAccPingAccessApplicationResourceOrderConfig(order string) string {
+	return fmt.Sprintf(`
+resource "pingaccess_site" "acc_test" {
+  name    = "acctest_resource_order"
+  targets = ["localhost:4321"]
+}
+
+resource "pingaccess_virtualhost" "acc_test" {
+  host = "acctest-resource-order"
+  port = 4000
+}
+
+resource "pingaccess_application" "acc_test" {
+  application_type        = "API"
+  name                    = "acctest_resource_order"
+  context_root            = "/order"
+  destination             = "Site"
+  manual_ordering_enabled = true
+  site_id                 = pingaccess_site.acc_test.id
+  virtual_host_ids        = [pingaccess_virtualhost.acc_test.id]
+}
+
+resource "pingaccess_application_resource" "acc_test_one" {
+  name           = "acctest_one"
+  methods        = ["*"]
+  application_id = pingaccess_application.acc_test.id
+
+  path_patterns {
+    pattern = "/one/.*"
+    type    = "REGEX"
+  }
+}
+
+resource "pingaccess_application_resource" "acc_test_two" {
+  name           = "acctest_two"
+  methods        = ["*"]
+  application_id = pingaccess_application.acc_test.id
+
+  path_patterns {
+    pattern = "/one/two/.*"
+    type    = "REGEX"
+  }
+}
+
+resource "pingaccess_application_resource" "acc_test_root" {
+  name           = "Root Resource"
+  methods        = ["*"]
+  root_resource  = true
+  application_id = pingaccess_application.acc_test.id
+
+  path_patterns {
+    pattern = "/*"
+    type    = "WILDCARD"
+  }
+}
+
+resource "pingaccess_application_resource_order" "acc_test" {
+  application_id = pingaccess_application.acc_test.id
+  %s
+
+  depends_on = [
+    pingaccess_application_resource.acc_test_one,
+    pingaccess_application_resource.acc_test_two,
+    pingaccess_application_resource.acc_test_root,
+  ]
+}
+
+data "pingaccess_application_resource_matching_evaluation_order" "acc_test" {
+  application_id = pingaccess_application_resource_order.acc_test.application_id
+}`, order)
+}
+
+func TestApplicationResourceOrderDiffReportsAutoOrderErrors(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	r := resourcePingAccessApplicationResourceOrder()
+	state := &terraform.InstanceState{ID: "999", Attributes: map[string]string{
+		"application_id": "999",
+		"use_auto_order": "true",
+		"resource_ids.#": "1",
+		"resource_ids.0": "1",
+	}}
+	conf := terraform.NewResourceConfigRaw(map[string]interface{}{"application_id": "999", "use_auto_order": true})
+	_, err := r.Diff(context.Background(), state, conf, c)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to read the suggested resource order of Application 999")
+
+	c.offline = true
+	_, err = r.Diff(context.Background(), state, conf, c)
+	assert.NoError(t, err)
+}
+
+func TestApplicationResourceOrderUpdateClearsMissingApplication(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	d := schema.TestResourceDataRaw(t, resourcePingAccessApplicationResourceOrderSchema(), map[string]interface{}{"application_id": "999"})
+	d.SetId("999")
+	diags := resourcePingAccessApplicationResourceOrderUpdate(context.Background(), d, c)
+	assert.False(t, diags.HasError())
+	assert.Empty(t, d.Id())
+}
@@ -1,6 +1,7 @@
 package sdkv2provider
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -73,6 +74,95 @@ func TestAccPingAccessApplicationResource(t *testing.T) {
 	})
 }
 
+func TestAccPingAccessApplicationResource_queryParamConfig(t *testing.T) {
+	if !paVersionAtLeast("6.1") {
+		t.Skipf("This test only runs against PingAccess 6.1 and above, not: %s", paVersion)
+	}
+	resourceName := "pingaccess_application_resource.app_res_query_params"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		CheckDestroy:             testAccCheckPingAccessApplicationResourceDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPingAccessApplicationResourceQueryParamConfig("v1*"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPingAccessApplicationResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "query_param_config.0.matches_no_params", "false"),
+					resource.TestCheckResourceAttr(resourceName, "query_param_config.0.params.0.name.0.pattern", "version"),
+					resource.TestCheckResourceAttr(resourceName, "query_param_config.0.params.0.value.0.pattern", "v1*"),
+				),
+			},
+			{
+				Config: testAccPingAccessApplicationResourceQueryParamConfig("v2*"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPingAccessApplicationResourceExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "query_param_config.0.params.0.value.0.pattern", "v2*"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(d *terraform.State) (string, error) {
+					rs, ok := d.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("unable to find resource %s", resourceName)
+					}
+					return fmt.Sprintf("%s/%s", rs.Primary.Attributes["application_id"], rs.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
+
+func testAccPingAccessApplicationResourceQueryParamConfig(version string) string {
+	return fmt.Sprintf(`
+resource "pingaccess_site" "app_res_query_params" {
+  name    = "acctest_app_res_query_params"
+  targets = ["localhost:4321"]
+}
+
+resource "pingaccess_virtualhost" "app_res_query_params" {
+  host = "acc-test-query-params"
+  port = 4000
+}
+
+resource "pingaccess_application" "app_res_query_params" {
+  application_type = "API"
+  name             = "acctest_app_res_query_params"
+  context_root     = "/query"
+  destination      = "Site"
+  site_id          = pingaccess_site.app_res_query_params.id
+  virtual_host_ids = [pingaccess_virtualhost.app_res_query_params.id]
+}
+
+resource "pingaccess_application_resource" "app_res_query_params" {
+  name           = "acctest_query_params"
+  methods        = ["GET"]
+  application_id = pingaccess_application.app_res_query_params.id
+
+  path_patterns {
+    pattern = "/api/*"
+    type    = "WILDCARD"
+  }
+
+  query_param_config {
+    params {
+      name {
+        pattern = "version"
+        type    = "EXACT"
+      }
+      value {
+        pattern = "%s"
+        type    = "WILDCARD"
+      }
+    }
+  }
+}`, version)
+}
+
 func testAccCheckPingAccessApplicationResourceDestroy(s *terraform.State) error {
 	return nil
 }
@@ -202,10 +292,6 @@ resource "pingaccess_application_resource" "app_res_test_root_resource" {
 resource "pingaccess_rule" "acc_test_resource_rule" {
   class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
   name       = "acc_test_resource_rule"
-  supported_destinations = [
-    "Site",
-    "Agent"
-  ]
   configuration = <<EOF
 	{
 		"cidrNotation": "127.0.0.1/32",
@@ -227,10 +313,6 @@ resource "pingaccess_rule" "acc_test_resource_rule" {
 resource "pingaccess_rule" "acc_test_resource_rule_two" {
   class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
   name       = "acc_test_resource_rule_two"
-  supported_destinations = [
-    "Site",
-    "Agent"
-  ]
   configuration = <<EOF
 	{
 		"cidrNotation": "127.0.0.1/32",
@@ -425,3 +507,49 @@ func Test_resourcePingAccessApplicationResourceReadData(t *testing.T) {
 		})
 	}
 }
+
+func Test_resourcePingAccessApplicationResourceQueryParamConfigOmitted(t *testing.T) {
+	client, _ := newFakeClient(t)
+	r := resourcePingAccessApplicationResource()
+	defaultConfig := &models.QueryParamConfigView{MatchesNoParams: Bool(false), Params: []*models.QueryParamPairView{}}
+	raw := map[string]interface{}{
+		"name":           "acc_test_query_params",
+		"application_id": "1",
+		"methods":        []interface{}{"*"},
+		"path_patterns":  []interface{}{map[string]interface{}{"pattern": "/*", "type": "WILDCARD"}},
+	}
+	tests := []struct {
+		name     string
+		config   []interface{}
+		expected int
+	}{
+		{name: "without the block", expected: 0},
+		{name: "with an empty block", config: []interface{}{map[string]interface{}{"matches_no_params": false}}, expected: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw["query_param_config"] = tt.config
+			if tt.config == nil {
+				delete(raw, "query_param_config")
+			}
+			d := schema.TestResourceDataRaw(t, r.Schema, raw)
+			d.SetId("1")
+			rv := resourcePingAccessApplicationResourceReadData(d)
+			rv.QueryParamConfig = defaultConfig
+			if diags := resourcePingAccessApplicationResourceReadResult(d, rv); diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if got := len(d.Get("query_param_config").([]interface{})); got != tt.expected {
+				t.Fatalf("expected %d query_param_config blocks, got %d", tt.expected, got)
+			}
+
+			diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff != nil && len(diff.Attributes) > 0 {
+				t.Errorf("expected no changes when PingAccess returns the default query_param_config, got %v", diff.Attributes)
+			}
+		})
+	}
+}
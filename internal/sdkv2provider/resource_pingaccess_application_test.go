@@ -1,14 +1,20 @@
 package sdkv2provider
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/services/applications"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/sites"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/virtualhosts"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -65,6 +71,164 @@ func TestAccPingAccessApplication(t *testing.T) {
 	})
 }
 
+func TestAccPingAccessApplication_invalidPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		CheckDestroy:             testAccCheckPingAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccPingAccessApplicationInvalidPolicyConfig("Web", "web", `"999999"`),
+				ExpectError: regexp.MustCompile(`policy.0.web.0: the Rule 999999 does not exist`),
+			},
+			{
+				Config:      testAccPingAccessApplicationInvalidPolicyConfig("API", "web", "pingaccess_rule.acc_test_invalid_policy.id"),
+				ExpectError: regexp.MustCompile(`the web policy is never evaluated for API applications`),
+			},
+		},
+	})
+}
+
+func TestAccPingAccessApplication_inlineResources(t *testing.T) {
+	resourceName := "pingaccess_application.acc_test_inline"
+	var applicationID string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		CheckDestroy:             testAccCheckPingAccessApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPingAccessApplicationInlineResourcesConfig("acctest_two", "/two/*"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "resource.#", "2"),
+					func(s *terraform.State) error {
+						applicationID = s.RootModule().Resources[resourceName].Primary.ID
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccPingAccessApplicationInlineResourcesConfig("acctest_three", "/three/*"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "resource.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "resource.*", map[string]string{"name": "acctest_three"}),
+				),
+			},
+			{
+				PreConfig: func() {
+					id, _ := strconv.Atoi(applicationID)
+					_, _, err := applications.New(conf).AddApplicationResourceCommand(&applications.AddApplicationResourceCommandInput{
+						Id: applicationID,
+						Body: models.ResourceView{
+							ApplicationId: Int(id),
+							Name:          String("acctest_orphan"),
+							Methods:       &[]*string{String("*")},
+							PathPatterns:  []*models.PathPatternView{{Pattern: String("/orphan/*"), Type: String("WILDCARD")}},
+						},
+					})
+					if err != nil {
+						t.Fatalf("unable to create orphaned resource: %s", err)
+					}
+				},
+				Config: testAccPingAccessApplicationInlineResourcesConfig("acctest_three", "/three/*"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "resource.#", "2"),
+				),
+			},
+			{
+				Config:      testAccPingAccessApplicationInlineResourcesConfig("acctest_one", "/three/*"),
+				ExpectError: regexp.MustCompile(`resource names must be unique, duplicated: acctest_one`),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"resource"},
+			},
+		},
+	})
+}
+
+func testAccPingAccessApplicationInlineResourcesConfig(name, pattern string) string {
+	return fmt.Sprintf(`
+resource "pingaccess_site" "acc_test_inline" {
+  name    = "acctest_inline"
+  targets = ["localhost:4321"]
+}
+
+resource "pingaccess_virtualhost" "acc_test_inline" {
+  host = "acctest-inline"
+  port = 4001
+}
+
+resource "pingaccess_application" "acc_test_inline" {
+  application_type = "Web"
+  name             = "acctest_inline"
+  context_root     = "/inline"
+  destination      = "Site"
+  site_id          = pingaccess_site.acc_test_inline.id
+  virtual_host_ids = [pingaccess_virtualhost.acc_test_inline.id]
+
+  resource {
+    name    = "acctest_one"
+    methods = ["GET", "POST"]
+
+    path_patterns {
+      pattern = "/one/*"
+      type    = "WILDCARD"
+    }
+  }
+
+  resource {
+    name    = "%s"
+    methods = ["*"]
+
+    path_patterns {
+      pattern = "%s"
+      type    = "WILDCARD"
+    }
+  }
+}`, name, pattern)
+}
+
+func testAccPingAccessApplicationInvalidPolicyConfig(appType, policy, id string) string {
+	return fmt.Sprintf(`
+resource "pingaccess_site" "acc_test_invalid_policy" {
+  name    = "acctest_invalid_policy"
+  targets = ["localhost:4321"]
+}
+
+resource "pingaccess_virtualhost" "acc_test_invalid_policy" {
+  host = "acctest-invalid-policy"
+  port = 4001
+}
+
+resource "pingaccess_rule" "acc_test_invalid_policy" {
+  class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
+  name       = "acctest_invalid_policy"
+  configuration = jsonencode({
+    cidrNotation = "127.0.0.1/32"
+  })
+}
+
+resource "pingaccess_application" "acc_test_invalid_policy" {
+  application_type = "%s"
+  name             = "acctest_invalid_policy"
+  context_root     = "/invalid"
+  destination      = "Site"
+  site_id          = pingaccess_site.acc_test_invalid_policy.id
+  virtual_host_ids = [pingaccess_virtualhost.acc_test_invalid_policy.id]
+
+  policy {
+    %s {
+      type = "Rule"
+      id   = %s
+    }
+  }
+}`, appType, policy, id)
+}
+
 func testAccCheckPingAccessApplicationDestroy(s *terraform.State) error {
 	return nil
 }
@@ -75,13 +239,13 @@ func testAccPingAccessApplicationConfig(name, context, appType string) string {
   client_secret {
     value = "top_secret"
   }`
-	if (paClient{apiVersion: paVersion}).Is62OrAbove() {
+	if paVersionAtLeast("6.2") {
 		block = `"exclusionList": false,
 			"exclusionListAttributes": [],
 			"exclusionListSubject": null,
 			"headerNamePrefix": null,`
 	}
-	if (paClient{apiVersion: paVersion}).Is61OrAbove() {
+	if paVersionAtLeast("6.1") {
 		oauth = `client_credentials {
 			credentials_type = "SECRET"
 			client_id = "my_client"
@@ -165,10 +329,6 @@ resource "pingaccess_identity_mapping" "idm_foo" {
 resource "pingaccess_rule" "acc_test_app_rule" {
   class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
   name       = "acctest_app_rule"
-  supported_destinations = [
-    "Site",
-    "Agent"
-  ]
   configuration = <<EOF
 		{
 			"cidrNotation": "127.0.0.1/32",
@@ -472,3 +632,117 @@ func Test_issue48(t *testing.T) {
 	exp = resourcePingAccessApplicationReadData(resourceLocalData)
 	assert.Equal(t, exp, app)
 }
+
+func TestApplicationUpdateKeepsResourceOrder(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	site, _, err := c.Sites.AddSiteCommand(&sites.AddSiteCommandInput{Body: models.SiteView{Name: String("site"), Targets: &[]*string{String("localhost:443")}}})
+	require.NoError(t, err)
+	vh, _, err := c.Virtualhosts.AddVirtualHostCommand(&virtualhosts.AddVirtualHostCommandInput{Body: models.VirtualHostView{Host: String("localhost"), Port: Int(443)}})
+	require.NoError(t, err)
+	d := schema.TestResourceDataRaw(t, resourcePingAccessApplicationSchema(), map[string]interface{}{
+		"name":                    "app",
+		"application_type":        "Web",
+		"context_root":            "/app",
+		"destination":             "Site",
+		"site_id":                 site.Id.String(),
+		"virtual_host_ids":        []interface{}{vh.Id.String()},
+		"manual_ordering_enabled": true,
+	})
+	diags := resourcePingAccessApplicationCreate(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	for _, name := range []string{"one", "two"} {
+		_, _, err := c.Applications.AddApplicationResourceCommand(&applications.AddApplicationResourceCommandInput{Id: d.Id(), Body: models.ResourceView{
+			Name:         String(name),
+			PathPrefixes: &[]*string{String("/" + name + "/*")},
+			Methods:      &[]*string{String("*")},
+		}})
+		require.NoError(t, err)
+	}
+	resources, _, err := c.Applications.GetApplicationResourcesCommand(&applications.GetApplicationResourcesCommandInput{Id: d.Id()})
+	require.NoError(t, err)
+	var ids, reversed []int
+	for _, res := range resources.Items {
+		id, _ := res.Id.Int64()
+		ids = append(ids, int(id))
+		reversed = append([]int{int(id)}, reversed...)
+	}
+	app, _, err := c.Applications.GetApplicationCommand(&applications.GetApplicationCommandInput{Id: d.Id()})
+	require.NoError(t, err)
+	_, err = setApplicationResourceOrder(c, d.Id(), app, reversed)
+	require.NoError(t, err)
+	diags = resourcePingAccessApplicationRead(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+
+	// the order is changed by pingaccess_application_resource_order after the application was read
+	app, _, err = c.Applications.GetApplicationCommand(&applications.GetApplicationCommandInput{Id: d.Id()})
+	require.NoError(t, err)
+	_, err = setApplicationResourceOrder(c, d.Id(), app, ids)
+	require.NoError(t, err)
+
+	require.NoError(t, d.Set("name", "renamed"))
+	diags = resourcePingAccessApplicationUpdate(context.Background(), d, c)
+	require.False(t, diags.HasError(), "%v", diags)
+	app, _, err = c.Applications.GetApplicationCommand(&applications.GetApplicationCommandInput{Id: d.Id()})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", *app.Name)
+	order := make([]int, 0, len(ids))
+	for _, id := range *app.ResourceOrder {
+		order = append(order, *id)
+	}
+	assert.Equal(t, ids, order)
+}
+
+func TestApplicationUpdateAppliesResourcesAfterApplication(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	site, _, err := c.Sites.AddSiteCommand(&sites.AddSiteCommandInput{Body: models.SiteView{Name: String("site"), Targets: &[]*string{String("localhost:443")}}})
+	require.NoError(t, err)
+	vh, _, err := c.Virtualhosts.AddVirtualHostCommand(&virtualhosts.AddVirtualHostCommandInput{Body: models.VirtualHostView{Host: String("localhost"), Port: Int(443)}})
+	require.NoError(t, err)
+	config := func(name string, resources ...string) *terraform.ResourceConfig {
+		var blocks []interface{}
+		for _, r := range resources {
+			blocks = append(blocks, map[string]interface{}{
+				"name":          r,
+				"methods":       []interface{}{"*"},
+				"path_patterns": []interface{}{map[string]interface{}{"pattern": "/" + r + "/*", "type": "WILDCARD"}},
+			})
+		}
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":                    name,
+			"application_type":        "Web",
+			"context_root":            "/app",
+			"destination":             "Site",
+			"site_id":                 site.Id.String(),
+			"virtual_host_ids":        []interface{}{vh.Id.String()},
+			"manual_ordering_enabled": true,
+			"resource":                blocks,
+		})
+	}
+	r := resourcePingAccessApplication()
+	apply := func(state *terraform.InstanceState, conf *terraform.ResourceConfig) *terraform.InstanceState {
+		diff, err := r.Diff(context.Background(), state, conf, c)
+		require.NoError(t, err)
+		state, diags := r.Apply(context.Background(), state, diff, c)
+		require.False(t, diags.HasError(), "%v", diags)
+		return state
+	}
+
+	state := apply(nil, config("app", "one", "two"))
+	state = apply(state, config("renamed", "one", "three"))
+
+	app, _, err := c.Applications.GetApplicationCommand(&applications.GetApplicationCommandInput{Id: state.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "renamed", *app.Name)
+	resources, _, err := c.Applications.GetApplicationResourcesCommand(&applications.GetApplicationResourcesCommandInput{Id: state.ID})
+	require.NoError(t, err)
+	var names []string
+	for _, rv := range resources.Items {
+		if !*rv.RootResource {
+			names = append(names, *rv.Name)
+		}
+	}
+	assert.ElementsMatch(t, []string{"one", "three"}, names)
+	assert.Len(t, *app.ResourceOrder, len(resources.Items))
+}
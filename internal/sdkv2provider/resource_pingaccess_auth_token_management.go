@@ -77,7 +77,7 @@ func resourcePingAccessAuthTokenManagementUpdate(_ context.Context, d *schema.Re
 	}
 	result, _, err := svc.UpdateAuthTokenManagementCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update AuthTokenManagement: %s", err)
+		return apiErrorDiags(err, "unable to update AuthTokenManagement", resourcePingAccessAuthTokenManagementSchema())
 	}
 
 	d.SetId("auth_token_management")
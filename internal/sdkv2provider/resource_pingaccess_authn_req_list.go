@@ -17,7 +17,7 @@ func resourcePingAccessAuthnReqList() *schema.Resource {
 		UpdateContext: resourcePingAccessAuthnReqListUpdate,
 		DeleteContext: resourcePingAccessAuthnReqListDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, authnReqListNameKey),
 		},
 		Schema:      resourcePingAccessAuthnReqListSchema(),
 		Description: `Provides configuration for Authentication Requirements within PingAccess.`,
@@ -50,7 +50,7 @@ func resourcePingAccessAuthnReqListCreate(_ context.Context, d *schema.ResourceD
 
 	result, _, err := svc.AddAuthnReqListCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create AuthnReqList: %s", err)
+		return apiErrorDiags(err, "unable to create AuthnReqList", resourcePingAccessAuthnReqListSchema())
 	}
 
 	d.SetId(result.Id.String())
@@ -62,9 +62,9 @@ func resourcePingAccessAuthnReqListRead(_ context.Context, d *schema.ResourceDat
 	input := &authnReqLists.GetAuthnReqListCommandInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetAuthnReqListCommand(input)
+	result, resp, err := svc.GetAuthnReqListCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read AuthnReqList: %s", err)
+		return readErrorDiags(d, resp, err, "AuthnReqList")
 	}
 	return resourcePingAccessAuthnReqListReadResult(d, result)
 }
@@ -78,7 +78,7 @@ func resourcePingAccessAuthnReqListUpdate(_ context.Context, d *schema.ResourceD
 
 	result, _, err := svc.UpdateAuthnReqListCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update AuthnReqList: %s", err)
+		return apiErrorDiags(err, "unable to update AuthnReqList", resourcePingAccessAuthnReqListSchema())
 	}
 	return resourcePingAccessAuthnReqListReadResult(d, result)
 }
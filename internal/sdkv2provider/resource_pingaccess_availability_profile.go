@@ -3,6 +3,7 @@ package sdkv2provider
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 
 	"github.com/iwarapter/pingaccess-sdk-go/v62/services/highAvailability"
 
@@ -19,15 +20,19 @@ func resourcePingAccessAvailabilityProfile() *schema.Resource {
 		UpdateContext: resourcePingAccessAvailabilityProfileUpdate,
 		DeleteContext: resourcePingAccessAvailabilityProfileDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, availabilityProfileNameKey),
 		},
 		Schema: resourcePingAccessAvailabilityProfileSchema(),
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			desc, err := m.(paClient).availabilityProfileDescriptors()
+			if err != nil {
+				return fmt.Errorf("unable to retrieve AvailabilityProfile descriptors %s", err)
+			}
 			className := d.Get("class_name").(string)
-			if err := descriptorsHasClassName(className, m.(paClient).HighAvailabilityDescriptors); err != nil {
+			if err := descriptorsHasClassName(className, desc); err != nil {
 				return err
 			}
-			return validateConfiguration(className, d, m.(paClient).HighAvailabilityDescriptors)
+			return validateConfiguration(className, d, desc)
 		},
 		Description: `Provides configuration for Availability Profiles within PingAccess.
 
@@ -64,11 +69,15 @@ func resourcePingAccessAvailabilityProfileCreate(_ context.Context, d *schema.Re
 
 	result, _, err := svc.AddAvailabilityProfileCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create AvailabilityProfile: %s", err)
+		return apiErrorDiags(err, "unable to create AvailabilityProfile", resourcePingAccessAvailabilityProfileSchema())
 	}
 
 	d.SetId(result.Id.String())
-	return resourcePingAccessAvailabilityProfileReadResult(d, result, m.(paClient).HighAvailabilityDescriptors)
+	desc, err := m.(paClient).availabilityProfileDescriptors()
+	if err != nil {
+		return diag.Errorf("unable to retrieve AvailabilityProfile descriptors: %s", err)
+	}
+	return resourcePingAccessAvailabilityProfileReadResult(d, result, desc)
 }
 
 func resourcePingAccessAvailabilityProfileRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -78,12 +87,16 @@ func resourcePingAccessAvailabilityProfileRead(_ context.Context, d *schema.Reso
 		Id: d.Id(),
 	}
 
-	result, _, err := svc.GetAvailabilityProfileCommand(input)
+	result, resp, err := svc.GetAvailabilityProfileCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read AvailabilityProfile: %s", err)
+		return readErrorDiags(d, resp, err, "AvailabilityProfile")
 	}
 
-	return resourcePingAccessAvailabilityProfileReadResult(d, result, m.(paClient).HighAvailabilityDescriptors)
+	desc, err := m.(paClient).availabilityProfileDescriptors()
+	if err != nil {
+		return diag.Errorf("unable to retrieve AvailabilityProfile descriptors: %s", err)
+	}
+	return resourcePingAccessAvailabilityProfileReadResult(d, result, desc)
 }
 
 func resourcePingAccessAvailabilityProfileUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -95,11 +108,15 @@ func resourcePingAccessAvailabilityProfileUpdate(_ context.Context, d *schema.Re
 
 	result, _, err := svc.UpdateAvailabilityProfileCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update AvailabilityProfile: %s", err)
+		return apiErrorDiags(err, "unable to update AvailabilityProfile", resourcePingAccessAvailabilityProfileSchema())
 	}
 
 	d.SetId(result.Id.String())
-	return resourcePingAccessAvailabilityProfileReadResult(d, result, m.(paClient).HighAvailabilityDescriptors)
+	desc, err := m.(paClient).availabilityProfileDescriptors()
+	if err != nil {
+		return diag.Errorf("unable to retrieve AvailabilityProfile descriptors: %s", err)
+	}
+	return resourcePingAccessAvailabilityProfileReadResult(d, result, desc)
 }
 
 func resourcePingAccessAvailabilityProfileDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -21,7 +21,7 @@ func resourcePingAccessCertificate() *schema.Resource {
 		UpdateContext: resourcePingAccessCertificateUpdate,
 		DeleteContext: resourcePingAccessCertificateDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: resourcePingAccessCertificateImport,
+			StateContext: importByKey(resourcePingAccessCertificateImport, certificateAliasKey),
 		},
 		Schema:      resourcePingAccessCertificateSchema(),
 		Description: `Provides configuration for Certificates within PingAccess.`,
@@ -105,7 +105,7 @@ func resourcePingAccessCertificateCreate(_ context.Context, d *schema.ResourceDa
 
 	result, _, err := svc.ImportTrustedCert(&input)
 	if err != nil {
-		return diag.Errorf("unable to create Certificate: %s", err)
+		return apiErrorDiags(err, "unable to create Certificate", resourcePingAccessCertificateSchema())
 	}
 
 	d.SetId(strconv.Itoa(*result.Id))
@@ -117,9 +117,9 @@ func resourcePingAccessCertificateRead(_ context.Context, d *schema.ResourceData
 	input := &certificates.GetTrustedCertInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetTrustedCert(input)
+	result, resp, err := svc.GetTrustedCert(input)
 	if err != nil {
-		return diag.Errorf("unable to read Certificate: %s", err)
+		return readErrorDiags(d, resp, err, "Certificate")
 	}
 	return resourcePingAccessCertificateReadResult(d, result)
 }
@@ -137,7 +137,7 @@ func resourcePingAccessCertificateUpdate(_ context.Context, d *schema.ResourceDa
 
 	result, _, err := svc.UpdateTrustedCert(&input)
 	if err != nil {
-		return diag.Errorf("unable to update Certificate: %s", err)
+		return apiErrorDiags(err, "unable to update Certificate", resourcePingAccessCertificateSchema())
 	}
 
 	d.SetId(strconv.Itoa(*result.Id))
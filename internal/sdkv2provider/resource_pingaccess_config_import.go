@@ -0,0 +1,130 @@
+package sdkv2provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/config"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePingAccessConfigImport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePingAccessConfigImportCreate,
+		ReadContext:   resourcePingAccessConfigImportRead,
+		DeleteContext: resourcePingAccessConfigImportDelete,
+		Schema:        resourcePingAccessConfigImportSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Description: `Imports a PingAccess configuration export document, replacing the existing configuration.
+
+The import runs when the resource is created and again whenever the document or ` + "`triggers`" + ` change. Destroying the resource only removes it from the state, the imported configuration is left in place.`,
+	}
+}
+
+func resourcePingAccessConfigImportSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"data": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			Sensitive:        true,
+			ValidateDiagFunc: validateJSONObject,
+			DiffSuppressFunc: suppressEquivalentJSONDiffs,
+			Description:      "The JSON export document to import, such as the `data` of the `pingaccess_config_export` data source.",
+		},
+		"fail_fast": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     true,
+			Description: "Stop the import at the first entity which fails, when `false` failed entities are reported as warnings and the remaining entities are imported.",
+		},
+		"triggers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Arbitrary values which cause the document to be imported again when changed.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The status of the import workflow.",
+		},
+		"total_entities": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "The number of entities in the import.",
+		},
+		"warnings": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The warnings reported by the import workflow.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func resourcePingAccessConfigImportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(paClient)
+	id, _, err := client.ConfigDocuments.StartImport(d.Get("data").(string), d.Get("fail_fast").(bool))
+	if err != nil {
+		return apiErrorDiags(err, "unable to start the configuration import", resourcePingAccessConfigImportSchema())
+	}
+	status, err := waitForConfigWorkflow(ctx, d.Timeout(schema.TimeoutCreate), func() (*models.ConfigStatusView, error) {
+		status, _, err := client.Config.GetConfigImportWorkflowCommand(&config.GetConfigImportWorkflowCommandInput{Id: id})
+		return status, err
+	})
+	if err != nil {
+		return diag.Errorf("unable to read the configuration import workflow %s: %s", id, err)
+	}
+	diags := configWorkflowDiags("Unable to import the configuration", status)
+	if diags.HasError() {
+		return diags
+	}
+	d.SetId(id)
+	return append(diags, resourcePingAccessConfigImportReadResult(d, status)...)
+}
+
+func resourcePingAccessConfigImportRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	svc := m.(paClient).Config
+	status, resp, err := svc.GetConfigImportWorkflowCommand(&config.GetConfigImportWorkflowCommandInput{Id: d.Id()})
+	if isNotFound(resp) {
+		// the workflows are not retained by PingAccess indefinitely, the import itself is unaffected
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("unable to read the configuration import workflow %s: %s", d.Id(), err)
+	}
+	return resourcePingAccessConfigImportReadResult(d, status)
+}
+
+func resourcePingAccessConfigImportDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+func resourcePingAccessConfigImportReadResult(d *schema.ResourceData, input *models.ConfigStatusView) diag.Diagnostics {
+	var diags diag.Diagnostics
+	setResourceDataStringWithDiagnostic(d, "status", input.Status, &diags)
+	setResourceDataIntWithDiagnostic(d, "total_entities", input.TotalEntities, &diags)
+	var warnings []string
+	if input.Warnings != nil {
+		for _, w := range *input.Warnings {
+			warnings = append(warnings, *w)
+		}
+	}
+	if err := d.Set("warnings", warnings); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
@@ -0,0 +1,125 @@
+package sdkv2provider
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/config"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/pingaccesstest"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccPingAccessConfigImport(t *testing.T) {
+	resourceName := "pingaccess_config_import.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPingAccessConfigImportConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "status", "Complete"),
+					resource.TestCheckResourceAttrPair(resourceName, "total_entities", "data.pingaccess_config_export.test", "total_entities"),
+				),
+			},
+			{
+				Config:      testAccPingAccessConfigImportInvalidConfig(),
+				ExpectError: regexp.MustCompile(`(?i)unable to (start the configuration import|import the configuration)`),
+			},
+		},
+	})
+}
+
+func testAccPingAccessConfigImportConfig() string {
+	return `
+data "pingaccess_config_export" "test" {}
+
+resource "pingaccess_config_import" "test" {
+  data = data.pingaccess_config_export.test.data
+
+  triggers = {
+    checksum = data.pingaccess_config_export.test.sha256
+  }
+}`
+}
+
+func testAccPingAccessConfigImportInvalidConfig() string {
+	return `
+resource "pingaccess_config_import" "invalid" {
+  data = jsonencode({
+    version = "0.0.0"
+    data    = {}
+  })
+}`
+}
+
+func TestConfigImportWorkflowFailures(t *testing.T) {
+	c, _ := newFakeClient(t)
+
+	document := `{"version": "` + pingaccesstest.DefaultVersion + `", "data": {"sites": [{"name": "missing id"}], "widgets": []}}`
+	tests := []struct {
+		name     string
+		failFast bool
+		status   string
+		diags    []string
+	}{
+		{
+			name:     "fail fast",
+			failFast: true,
+			status:   "Failed",
+			diags:    []string{"Unable to import the configuration, workflow 1 failed (name=missing id, type=Site): Import Failed", "Unable to import the configuration, workflow 1 failed (name=missing id, type=Site): id: id is required"},
+		},
+		{
+			name:     "continue",
+			failFast: false,
+			status:   "Complete",
+			diags:    []string{"Unable to import the configuration, workflow 2 reported a warning: Site 'missing id': Import Failed", "Unable to import the configuration, workflow 2 reported a warning: widgets: Unknown entity type 'widgets'"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			id, _, err := c.ConfigDocuments.StartImport(document, tc.failFast)
+			require.NoError(t, err)
+			status, err := waitForConfigWorkflow(context.Background(), time.Minute, func() (*models.ConfigStatusView, error) {
+				status, _, err := c.Config.GetConfigImportWorkflowCommand(&config.GetConfigImportWorkflowCommandInput{Id: id})
+				return status, err
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tc.status, *status.Status)
+			var got []string
+			for _, d := range configWorkflowDiags("Unable to import the configuration", status) {
+				got = append(got, d.Summary+": "+d.Detail)
+			}
+			assert.Equal(t, tc.diags, got)
+		})
+	}
+
+	t.Run("export round trip", func(t *testing.T) {
+		export, _, err := c.Config.AddConfigExportWorkflowCommand()
+		require.NoError(t, err)
+		data, _, err := c.ConfigDocuments.ExportData(strconv.Itoa(*export.Id))
+		require.NoError(t, err)
+		id, _, err := c.ConfigDocuments.StartImport(data, true)
+		require.NoError(t, err)
+		status, _, err := c.Config.GetConfigImportWorkflowCommand(&config.GetConfigImportWorkflowCommandInput{Id: id})
+		require.NoError(t, err)
+		assert.Equal(t, "Complete", *status.Status)
+		assert.Equal(t, *export.TotalEntities, *status.TotalEntities)
+		assert.Empty(t, configWorkflowDiags("Unable to import the configuration", status))
+
+		_, resp, err := c.ConfigDocuments.StartImport("{", true)
+		assert.Nil(t, resp)
+		assert.EqualError(t, err, "the import document is not valid JSON")
+		_, resp, _ = c.Config.GetConfigImportWorkflowCommand(&config.GetConfigImportWorkflowCommandInput{Id: "99"})
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+}
@@ -17,7 +17,7 @@ func resourcePingAccessEngineListener() *schema.Resource {
 		UpdateContext: resourcePingAccessEngineListenerUpdate,
 		DeleteContext: resourcePingAccessEngineListenerDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, engineListenerNameKey),
 		},
 		Schema:      resourcePingAccessEngineListenerSchema(),
 		Description: `Provides configuration for Engine Listeners within PingAccess.`,
@@ -59,7 +59,7 @@ func resourcePingAccessEngineListenerCreate(_ context.Context, d *schema.Resourc
 
 	result, _, err := svc.AddEngineListenerCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create EngineListener: %s", err)
+		return apiErrorDiags(err, "unable to create EngineListener", resourcePingAccessEngineListenerSchema())
 	}
 
 	d.SetId(result.Id.String())
@@ -71,9 +71,9 @@ func resourcePingAccessEngineListenerRead(_ context.Context, d *schema.ResourceD
 	input := &engineListeners.GetEngineListenerCommandInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetEngineListenerCommand(input)
+	result, resp, err := svc.GetEngineListenerCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read EngineListener: %s", err)
+		return readErrorDiags(d, resp, err, "EngineListener")
 	}
 	return resourcePingAccessEngineListenerReadResult(d, result)
 }
@@ -87,7 +87,7 @@ func resourcePingAccessEngineListenerUpdate(_ context.Context, d *schema.Resourc
 
 	result, _, err := svc.UpdateEngineListenerCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update EngineListener: %s", err)
+		return apiErrorDiags(err, "unable to update EngineListener", resourcePingAccessEngineListenerSchema())
 	}
 	return resourcePingAccessEngineListenerReadResult(d, result)
 }
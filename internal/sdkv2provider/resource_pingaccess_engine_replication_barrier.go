@@ -0,0 +1,241 @@
+package sdkv2provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/engines"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourcePingAccessEngineReplicationBarrier() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePingAccessEngineReplicationBarrierCreate,
+		ReadContext:   resourcePingAccessEngineReplicationBarrierRead,
+		DeleteContext: resourcePingAccessEngineReplicationBarrierDelete,
+		Schema:        resourcePingAccessEngineReplicationBarrierSchema(),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Description: `Waits for the PingAccess engines to pull the latest configuration from the administrative node.
+
+An engine has the latest configuration once it has polled the administrative node after the barrier is created. Use ` + "`depends_on`" + ` to create the barrier after the configuration it should wait for, and ` + "`triggers`" + ` to wait again whenever that configuration changes. Only engines with configuration replication enabled are waited for. Destroying the resource only removes it from the state.`,
+	}
+}
+
+func resourcePingAccessEngineReplicationBarrierSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"triggers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Arbitrary values which cause the barrier to wait again when changed.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"quorum": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      0,
+			ValidateFunc: validation.IntAtLeast(0),
+			Description:  "The number of engines which must have the latest configuration, `0` waits for all of the engines.",
+		},
+		"started_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The time of the administrative node when the barrier started waiting, in RFC 3339 format.",
+		},
+		"engines": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The engines waited for and whether they had the latest configuration when the barrier completed.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The id of the engine.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the engine.",
+					},
+					"last_updated": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "When the engine last polled for the configuration in RFC 3339 format, empty if it has never polled.",
+					},
+					"current": {
+						Type:        schema.TypeBool,
+						Computed:    true,
+						Description: "Whether the engine has the latest configuration.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// engineReplication is the replication status of an engine, lastUpdated is nil when the engine has never polled the
+// administrative node.
+type engineReplication struct {
+	id          string
+	name        string
+	lastUpdated *time.Time
+	current     bool
+}
+
+func resourcePingAccessEngineReplicationBarrierCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	svc := m.(paClient).Engines
+	status, _, err := svc.GetEngineStatusCommand()
+	if err != nil {
+		return diag.Errorf("unable to read the engine status: %s", err)
+	}
+	started := time.Now()
+	if status.CurrentServerTime != nil {
+		started = time.UnixMilli(int64(*status.CurrentServerTime))
+	}
+	quorum := d.Get("quorum").(int)
+
+	var result []engineReplication
+	required := 0
+	err = resource.RetryContext(ctx, d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		result, err = engineReplicationStatus(svc, started)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		required = len(result)
+		if quorum > 0 && quorum < required {
+			required = quorum
+		}
+		if current := currentEngines(result); current < required {
+			return resource.RetryableError(fmt.Errorf("%d of %d engines have the latest configuration", current, required))
+		}
+		return nil
+	})
+	if err != nil {
+		if result == nil {
+			return diag.Errorf("unable to read the engine status: %s", err)
+		}
+		return engineReplicationDiags(result, required, err)
+	}
+
+	var diags diag.Diagnostics
+	if quorum > len(result) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Engine quorum not reached",
+			Detail:   fmt.Sprintf("The quorum is %d but only %d engines have configuration replication enabled, the barrier waited for all of them.", quorum, len(result)),
+		})
+	}
+	d.SetId(strconv.FormatInt(started.UnixMilli(), 10))
+	if err := d.Set("started_at", started.UTC().Format(time.RFC3339Nano)); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+	if err := d.Set("engines", flattenEngineReplication(result)); err != nil {
+		diags = append(diags, diag.FromErr(err)...)
+	}
+	return diags
+}
+
+func resourcePingAccessEngineReplicationBarrierRead(_ context.Context, _ *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	// the barrier only waits when created, the result is kept as it was
+	return nil
+}
+
+func resourcePingAccessEngineReplicationBarrierDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// engineReplicationStatus returns the engines with configuration replication enabled, an engine is current when it
+// has polled the administrative node since the given time.
+func engineReplicationStatus(svc engines.EnginesAPI, since time.Time) ([]engineReplication, error) {
+	list, _, err := svc.GetEnginesCommand(&engines.GetEnginesCommandInput{})
+	if err != nil {
+		return nil, err
+	}
+	status, _, err := svc.GetEngineStatusCommand()
+	if err != nil {
+		return nil, err
+	}
+	var result []engineReplication
+	for _, engine := range list.Items {
+		if engine.ConfigReplicationEnabled != nil && !*engine.ConfigReplicationEnabled {
+			continue
+		}
+		e := engineReplication{id: engine.Id.String()}
+		if engine.Name != nil {
+			e.name = *engine.Name
+		}
+		if info, ok := status.EnginesStatus[e.id]; ok && info != nil && info.LastUpdated != nil {
+			t := time.UnixMilli(int64(*info.LastUpdated))
+			e.lastUpdated = &t
+			e.current = !t.Before(since)
+		}
+		result = append(result, e)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].name < result[j].name })
+	return result, nil
+}
+
+func currentEngines(engines []engineReplication) int {
+	current := 0
+	for _, e := range engines {
+		if e.current {
+			current++
+		}
+	}
+	return current
+}
+
+// engineReplicationDiags reports each engine without the latest configuration followed by the overall failure.
+func engineReplicationDiags(engines []engineReplication, required int, err error) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, e := range engines {
+		if e.current {
+			continue
+		}
+		detail := "The engine has never polled the administrative node for its configuration."
+		if e.lastUpdated != nil {
+			detail = fmt.Sprintf("The engine last polled the administrative node at %s, before the barrier started.", e.lastUpdated.UTC().Format(time.RFC3339))
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Engine %q (id %s) does not have the latest configuration", e.name, e.id),
+			Detail:   detail,
+		})
+	}
+	return append(diags, diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  "Engine configuration replication did not complete",
+		Detail:   fmt.Sprintf("%d of the %d required engines have the latest configuration: %s", currentEngines(engines), required, err),
+	})
+}
+
+func flattenEngineReplication(engines []engineReplication) []interface{} {
+	result := make([]interface{}, 0, len(engines))
+	for _, e := range engines {
+		lastUpdated := ""
+		if e.lastUpdated != nil {
+			lastUpdated = e.lastUpdated.UTC().Format(time.RFC3339Nano)
+		}
+		result = append(result, map[string]interface{}{
+			"id":           e.id,
+			"name":         e.name,
+			"last_updated": lastUpdated,
+			"current":      e.current,
+		})
+	}
+	return result
+}
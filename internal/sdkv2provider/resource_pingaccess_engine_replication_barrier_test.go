@@ -0,0 +1,114 @@
+package sdkv2provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/pingaccess-sdk-go/v62/services/engines"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccPingAccessEngineReplicationBarrier(t *testing.T) {
+	resourceName := "pingaccess_engine_replication_barrier.test"
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPingAccessEngineReplicationBarrierConfig("one"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "started_at"),
+				),
+			},
+			{
+				Config: testAccPingAccessEngineReplicationBarrierConfig("two"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "started_at"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPingAccessEngineReplicationBarrierConfig(trigger string) string {
+	return `
+resource "pingaccess_engine_replication_barrier" "test" {
+  triggers = {
+    change = "` + trigger + `"
+  }
+}`
+}
+
+func TestEngineReplicationBarrierCreate(t *testing.T) {
+	c, s := newFakeClient(t)
+
+	for _, e := range []models.EngineView{
+		{Name: pingaccess.String("engine-a")},
+		{Name: pingaccess.String("engine-b")},
+		{Name: pingaccess.String("engine-c")},
+		{Name: pingaccess.String("standby"), ConfigReplicationEnabled: pingaccess.Bool(false)},
+	} {
+		_, _, err := c.Engines.AddEngineCommand(&engines.AddEngineCommandInput{Body: e})
+		require.NoError(t, err)
+	}
+	s.PollEngine("2")
+
+	t.Run("all engines", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourcePingAccessEngineReplicationBarrierSchema(), map[string]interface{}{})
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			for _, id := range []string{"1", "2", "3"} {
+				s.PollEngine(id)
+			}
+		}()
+		diags := resourcePingAccessEngineReplicationBarrierCreate(context.Background(), d, c)
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.Equal(t, 3, d.Get("engines.#"))
+		for i, name := range []string{"engine-a", "engine-b", "engine-c"} {
+			assert.Equal(t, name, d.Get(fmt.Sprintf("engines.%d.name", i)))
+			assert.Equal(t, true, d.Get(fmt.Sprintf("engines.%d.current", i)))
+		}
+	})
+
+	t.Run("quorum", func(t *testing.T) {
+		d := schema.TestResourceDataRaw(t, resourcePingAccessEngineReplicationBarrierSchema(), map[string]interface{}{"quorum": 2})
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			s.PollEngine("1")
+			s.PollEngine("3")
+		}()
+		diags := resourcePingAccessEngineReplicationBarrierCreate(context.Background(), d, c)
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.Equal(t, true, d.Get("engines.0.current"))
+		assert.Equal(t, false, d.Get("engines.1.current"))
+		assert.Equal(t, true, d.Get("engines.2.current"))
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		_, _, err := c.Engines.AddEngineCommand(&engines.AddEngineCommandInput{Body: models.EngineView{Name: pingaccess.String("engine-d")}})
+		require.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		d := schema.TestResourceDataRaw(t, resourcePingAccessEngineReplicationBarrierSchema(), map[string]interface{}{})
+		diags := resourcePingAccessEngineReplicationBarrierCreate(ctx, d, c)
+		require.Len(t, diags, 5)
+		for i, name := range []string{"engine-a", "engine-b", "engine-c"} {
+			assert.Contains(t, diags[i].Summary, fmt.Sprintf("Engine %q", name))
+			assert.Contains(t, diags[i].Detail, "before the barrier started")
+		}
+		assert.Equal(t, `Engine "engine-d" (id 5) does not have the latest configuration`, diags[3].Summary)
+		assert.Equal(t, "The engine has never polled the administrative node for its configuration.", diags[3].Detail)
+		assert.Equal(t, "Engine configuration replication did not complete", diags[4].Summary)
+		assert.Contains(t, diags[4].Detail, "0 of the 4 required engines")
+		assert.Empty(t, d.Id())
+	})
+}
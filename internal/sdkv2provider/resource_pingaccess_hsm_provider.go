@@ -19,12 +19,11 @@ func resourcePingAccessHsmProvider() *schema.Resource {
 		UpdateContext: resourcePingAccessHsmProviderUpdate,
 		DeleteContext: resourcePingAccessHsmProviderDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, hsmProviderNameKey),
 		},
 		Schema: resourcePingAccessHsmProviderSchema(),
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
-			svc := m.(paClient).HsmProviders
-			desc, _, err := svc.GetHsmProviderDescriptorsCommand()
+			desc, err := m.(paClient).hsmProviderDescriptors()
 			if err != nil {
 				return fmt.Errorf("unable to retrieve HsmProvider descriptors %s", err)
 			}
@@ -69,11 +68,15 @@ func resourcePingAccessHsmProviderCreate(_ context.Context, d *schema.ResourceDa
 
 	result, _, err := svc.AddHsmProviderCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create HsmProvider: %s", err)
+		return apiErrorDiags(err, "unable to create HsmProvider", resourcePingAccessHsmProviderSchema())
 	}
 
 	d.SetId(result.Id.String())
-	return resourcePingAccessHsmProviderReadResult(d, result, svc)
+	desc, err := m.(paClient).hsmProviderDescriptors()
+	if err != nil {
+		return diag.Errorf("unable to retrieve HsmProvider descriptors: %s", err)
+	}
+	return resourcePingAccessHsmProviderReadResult(d, result, desc)
 }
 
 func resourcePingAccessHsmProviderRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -81,11 +84,15 @@ func resourcePingAccessHsmProviderRead(_ context.Context, d *schema.ResourceData
 	input := &hsmProviders.GetHsmProviderCommandInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetHsmProviderCommand(input)
+	result, resp, err := svc.GetHsmProviderCommand(input)
+	if err != nil {
+		return readErrorDiags(d, resp, err, "HsmProvider")
+	}
+	desc, err := m.(paClient).hsmProviderDescriptors()
 	if err != nil {
-		return diag.Errorf("unable to read HsmProvider: %s", err)
+		return diag.Errorf("unable to retrieve HsmProvider descriptors: %s", err)
 	}
-	return resourcePingAccessHsmProviderReadResult(d, result, svc)
+	return resourcePingAccessHsmProviderReadResult(d, result, desc)
 }
 
 func resourcePingAccessHsmProviderUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -96,10 +103,14 @@ func resourcePingAccessHsmProviderUpdate(_ context.Context, d *schema.ResourceDa
 	}
 	result, _, err := svc.UpdateHsmProviderCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update HsmProvider: %s", err)
+		return apiErrorDiags(err, "unable to update HsmProvider", resourcePingAccessHsmProviderSchema())
 	}
 	d.SetId(result.Id.String())
-	return resourcePingAccessHsmProviderReadResult(d, result, svc)
+	desc, err := m.(paClient).hsmProviderDescriptors()
+	if err != nil {
+		return diag.Errorf("unable to retrieve HsmProvider descriptors: %s", err)
+	}
+	return resourcePingAccessHsmProviderReadResult(d, result, desc)
 }
 
 func resourcePingAccessHsmProviderDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -111,7 +122,7 @@ func resourcePingAccessHsmProviderDelete(_ context.Context, d *schema.ResourceDa
 	return nil
 }
 
-func resourcePingAccessHsmProviderReadResult(d *schema.ResourceData, input *models.HsmProviderView, svc hsmProviders.HsmProvidersAPI) diag.Diagnostics {
+func resourcePingAccessHsmProviderReadResult(d *schema.ResourceData, input *models.HsmProviderView, desc *models.DescriptorsView) diag.Diagnostics {
 	var diags diag.Diagnostics
 	b, _ := json.Marshal(input.Configuration)
 	config := string(b)
@@ -121,8 +132,6 @@ func resourcePingAccessHsmProviderReadResult(d *schema.ResourceData, input *mode
 	//Search the HSM descriptors for CONCEALED fields, and update the original value back as we cannot use the
 	// encryptedValue provided by the API, whilst this gives us a stable plan - we cannot determine if a CONCEALED value
 	// has changed and needs updating
-	desc, _, _ := svc.GetHsmProviderDescriptorsCommand()
-
 	config = maskConfigFromDescriptors(desc, input.ClassName, originalConfig, config)
 
 	setResourceDataStringWithDiagnostic(d, "name", input.Name, &diags)
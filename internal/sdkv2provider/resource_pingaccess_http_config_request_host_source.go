@@ -63,7 +63,7 @@ func resourcePingAccessHTTPConfigRequestHostSourceUpdate(_ context.Context, d *s
 	input := &httpConfig.UpdateHostSourceCommandInput{Body: *resourcePingAccessHTTPConfigRequestHostSourceReadData(d)}
 	result, _, err := svc.UpdateHostSourceCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to update HttpConfigHostSource: %s", err)
+		return apiErrorDiags(err, "unable to update HttpConfigHostSource", resourcePingAccessHTTPConfigRequestHostSourceResourceSchema())
 	}
 
 	d.SetId("http_config_host_source")
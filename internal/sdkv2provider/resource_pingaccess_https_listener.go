@@ -19,7 +19,7 @@ func resourcePingAccessHTTPSListener() *schema.Resource {
 		UpdateContext: resourcePingAccessHTTPSListenerUpdate,
 		DeleteContext: resourcePingAccessHTTPSListenerDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, httpsListenerNameKey),
 		},
 
 		Schema: resourcePingAccessHTTPSListenerSchema(),
@@ -74,9 +74,9 @@ func resourcePingAccessHTTPSListenerRead(_ context.Context, d *schema.ResourceDa
 	input := &httpsListeners.GetHttpsListenerCommandInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetHttpsListenerCommand(input)
+	result, resp, err := svc.GetHttpsListenerCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read listener: %s", err)
+		return readErrorDiags(d, resp, err, "listener")
 	}
 	return resourcePingAccessHTTPSListenerReadResult(d, result)
 }
@@ -90,7 +90,7 @@ func resourcePingAccessHTTPSListenerUpdate(_ context.Context, d *schema.Resource
 
 	result, _, err := svc.UpdateHttpsListener(&input)
 	if err != nil {
-		return diag.Errorf("unable to update listener: %s", err)
+		return apiErrorDiags(err, "unable to update listener", resourcePingAccessHTTPSListenerSchema())
 	}
 	return resourcePingAccessHTTPSListenerReadResult(d, result)
 }
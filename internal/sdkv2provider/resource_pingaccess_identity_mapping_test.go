@@ -6,13 +6,8 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/services/identityMappings"
 
-	"github.com/google/go-cmp/cmp"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -69,6 +64,8 @@ func TestAccPingAccessIdentityMapping(t *testing.T) {
 				ResourceName:      resourceName,
 				ImportState:       true,
 				ImportStateVerify: true,
+				// imported configuration is always structured
+				ImportStateVerifyIgnore: []string{"configuration"},
 			},
 			{
 				Config:      testAccPingAccessIdentityMappingConfigWrongClassName(),
@@ -92,13 +89,45 @@ func TestAccPingAccessIdentityMapping(t *testing.T) {
 	})
 }
 
+func TestAccPingAccessIdentityMappingStructured(t *testing.T) {
+	resourceName := "pingaccess_identity_mapping.acc_test_idm"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		CheckDestroy:             testAccCheckPingAccessIdentityMappingDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPingAccessIdentityMappingConfigStructured("SUB_HEADER"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPingAccessIdentityMappingExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "acctest_structured"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.attributeHeaderMappings.0.headerName", "SUB_HEADER"),
+				),
+			},
+			{
+				Config: testAccPingAccessIdentityMappingConfigStructured("SUB"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPingAccessIdentityMappingExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "configuration.attributeHeaderMappings.0.headerName", "SUB"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func testAccCheckPingAccessIdentityMappingDestroy(s *terraform.State) error {
 	return nil
 }
 
 func testAccPingAccessIdentityMappingConfig(name, configUpdate string) string {
 	block := ""
-	if (paClient{apiVersion: paVersion}).Is62OrAbove() {
+	if paVersionAtLeast("6.2") {
 		block = `"exclusionList": false,
 			"exclusionListAttributes": [],
 			"exclusionListSubject": null,
@@ -124,6 +153,30 @@ resource "pingaccess_identity_mapping" "acc_test_idm" {
 }`, name, block, configUpdate)
 }
 
+func testAccPingAccessIdentityMappingConfigStructured(header string) string {
+	block := ""
+	if paVersionAtLeast("6.2") {
+		block = `exclusionList           = false
+    exclusionListAttributes = []`
+	}
+	return fmt.Sprintf(`
+resource "pingaccess_identity_mapping" "acc_test_idm" {
+  class_name = "com.pingidentity.pa.identitymappings.HeaderIdentityMapping"
+  name       = "acctest_structured"
+  configuration = {
+    %s
+    attributeHeaderMappings = [
+      {
+        subject       = true
+        attributeName = "sub"
+        headerName    = "%s"
+      }
+    ]
+    headerClientCertificateMappings = []
+  }
+}`, block, header)
+}
+
 func testAccPingAccessIdentityMappingConfigWrongClassName() string {
 	return `
 resource "pingaccess_identity_mapping" "acc_test_idm" {
@@ -155,7 +208,7 @@ resource "pingaccess_identity_mapping" "acc_test_idm" {
 
 func testAccPingAccessIdentityMappingConfigInterpolatedSkipped() string {
 	block := ""
-	if (paClient{apiVersion: paVersion}).Is61OrAbove() {
+	if paVersionAtLeast("6.1") {
 		block = `"exclusionList": false,
 			"exclusionListAttributes": [],
 			"exclusionListSubject": null,
@@ -247,62 +300,3 @@ func testAccCheckPingAccessIdentityMappingAttributes(n string) resource.TestChec
 		return nil
 	}
 }
-
-func Test_resourcePingAccessIdentityMappingReadData(t *testing.T) {
-	desc := &models.DescriptorsView{
-		Items: []*models.DescriptorView{
-			{
-				ClassName: String("something"),
-				ConfigurationFields: []*models.ConfigurationField{
-					{
-						Name: String("password"),
-						Type: String("CONCEALED"),
-					},
-				},
-				Label: nil,
-				Type:  nil,
-			},
-		}}
-	cases := []struct {
-		Name            string
-		IdentityMapping models.IdentityMappingView
-		ExpectedDiags   diag.Diagnostics
-	}{
-		{
-			Name: "Stuff breaks",
-			IdentityMapping: models.IdentityMappingView{
-				Name:      String("foo"),
-				ClassName: String("foo"),
-			},
-			ExpectedDiags: diag.Diagnostics{},
-		},
-	}
-	for _, tc := range cases {
-		t.Run(tc.Name, func(t *testing.T) {
-
-			resourceSchema := resourcePingAccessIdentityMappingSchema()
-			resourceLocalData := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
-			diags := resourcePingAccessIdentityMappingReadResult(resourceLocalData, &tc.IdentityMapping, desc)
-
-			checkDiagnostics(t, tc.Name, diags, tc.ExpectedDiags)
-
-			if got := *resourcePingAccessIdentityMappingReadData(resourceLocalData); !cmp.Equal(got, tc.IdentityMapping) {
-				t.Errorf("resourcePingAccessIdentityMappingReadData() = %v", cmp.Diff(got, tc.IdentityMapping))
-			}
-		})
-	}
-}
-
-func checkDiagnostics(t *testing.T, tn string, got, expected diag.Diagnostics) {
-	if len(got) != len(expected) {
-		t.Fatalf("%s: wrong number of diags, expected %d, got %d", tn, len(expected), len(got))
-	}
-	for j := range got {
-		if got[j].Severity != expected[j].Severity {
-			t.Fatalf("%s: expected severity %v, got %v", tn, expected[j].Severity, got[j].Severity)
-		}
-		if !got[j].AttributePath.Equals(expected[j].AttributePath) {
-			t.Fatalf("%s: attribute paths do not match expected: %v, got %v", tn, expected[j].AttributePath, got[j].AttributePath)
-		}
-	}
-}
@@ -15,6 +15,7 @@ import (
 	keyPairs60 "github.com/iwarapter/pingaccess-sdk-go/v60/services/keyPairs"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
 	"github.com/iwarapter/pingaccess-sdk-go/v62/services/keyPairs"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/paversion"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -27,7 +28,7 @@ func resourcePingAccessKeyPair() *schema.Resource {
 		UpdateContext: resourcePingAccessKeyPairUpdate,
 		DeleteContext: resourcePingAccessKeyPairDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: resourcePingAccessKeyPairImport,
+			StateContext: importByKey(resourcePingAccessKeyPairImport, keyPairAliasKey),
 		},
 		Schema: resourcePingAccessKeyPairSchema(),
 		Description: `Provides configuration for Keypairs within PingAccess.
@@ -207,7 +208,7 @@ func resourcePingAccessKeyPairCreate(_ context.Context, d *schema.ResourceData,
 	//Import a keypair
 	if _, ok := d.GetOk("file_data"); ok {
 		//Import a keypair using 6.2+ API
-		if m.(paClient).Is62OrAbove() {
+		if m.(paClient).supports(paversion.FeatureKeyPairHiddenFieldPassword) {
 			input := keyPairs.ImportKeyPairCommandInput{
 				Body: models.PKCS12FileImportDocView{
 					Alias:             String(d.Get("alias").(string)),
@@ -219,7 +220,7 @@ func resourcePingAccessKeyPairCreate(_ context.Context, d *schema.ResourceData,
 			}
 			result, _, err := svc.ImportKeyPairCommand(&input)
 			if err != nil {
-				return diag.Errorf("unable to create KeyPair: %s", err)
+				return apiErrorDiags(err, "unable to create KeyPair", resourcePingAccessKeyPairSchema())
 			}
 
 			d.SetId(strconv.Itoa(*result.Id))
@@ -238,7 +239,7 @@ func resourcePingAccessKeyPairCreate(_ context.Context, d *schema.ResourceData,
 		svc60 := m.(paClient).KeyPairsV60
 		result, _, err := svc60.ImportKeyPairCommand(&input)
 		if err != nil {
-			return diag.Errorf("unable to create KeyPair: %s", err)
+			return apiErrorDiags(err, "unable to create KeyPair", resourcePingAccessKeyPairSchema())
 		}
 
 		d.SetId(strconv.Itoa(*result.Id))
@@ -278,7 +279,7 @@ func resourcePingAccessKeyPairCreate(_ context.Context, d *schema.ResourceData,
 
 	result, _, err := svc.GenerateKeyPairCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to generate KeyPair: %s", err)
+		return apiErrorDiags(err, "unable to generate KeyPair", resourcePingAccessKeyPairSchema())
 	}
 
 	d.SetId(strconv.Itoa(*result.Id))
@@ -290,9 +291,9 @@ func resourcePingAccessKeyPairRead(_ context.Context, d *schema.ResourceData, m
 	input := &keyPairs.GetKeyPairCommandInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetKeyPairCommand(input)
+	result, resp, err := svc.GetKeyPairCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read KeyPair: %s", err)
+		return readErrorDiags(d, resp, err, "KeyPair")
 	}
 	return resourcePingAccessKeyPairReadResult(d, result)
 }
@@ -316,7 +317,7 @@ func resourcePingAccessKeyPairUpdate(_ context.Context, d *schema.ResourceData,
 
 	result, _, err := svc.UpdateKeyPairCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update KeyPair: %s", err)
+		return apiErrorDiags(err, "unable to update KeyPair", resourcePingAccessKeyPairSchema())
 	}
 
 	d.SetId(strconv.Itoa(*result.Id))
@@ -334,7 +335,7 @@ func resourcePingAccessKeyPairDelete(_ context.Context, d *schema.ResourceData,
 
 func resourcePingAccessKeyPairImport(_ context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 	svc := m.(paClient).KeyPairs
-	is6 := m.(paClient).Is60OrAbove()
+	hasHsmProvider := m.(paClient).supports(paversion.FeatureKeyPairHsmProvider)
 	input := keyPairs.GetKeyPairCommandInput{
 		Id: d.Id(),
 	}
@@ -356,7 +357,7 @@ func resourcePingAccessKeyPairImport(_ context.Context, d *schema.ResourceData,
 	}
 
 	diags := resourcePingAccessKeyPairReadResult(d, result)
-	if !is6 {
+	if !hasHsmProvider {
 		//hsm providers are only available in 6+ so we just set to state to match the default for 5.3
 		setResourceDataIntWithDiagnostic(d, "hsm_provider_id", Int(0), &diags)
 	}
@@ -18,7 +18,7 @@ func resourcePingAccessKeyPairCsr() *schema.Resource {
 		UpdateContext: resourcePingAccessKeyPairCsrUpdate,
 		DeleteContext: resourcePingAccessKeyPairCsrDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, keyPairAliasKey),
 		},
 		Schema:      resourcePingAccessKeyPairCsrSchema(),
 		Description: `Provides configuration for Keypair CSRs within PingAccess.`,
@@ -64,7 +64,7 @@ func resourcePingAccessKeyPairCsrCreate(_ context.Context, d *schema.ResourceDat
 
 	result, _, err := svc.ImportCSRResponseCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create KeyPairCsr: %s", err)
+		return apiErrorDiags(err, "unable to create KeyPairCsr", resourcePingAccessKeyPairCsrSchema())
 	}
 
 	d.SetId(strconv.Itoa(*result.Id))
@@ -84,7 +84,7 @@ func resourcePingAccessKeyPairCsrUpdate(_ context.Context, d *schema.ResourceDat
 
 	_, _, err := svc.ImportCSRResponseCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update KeyPairCsr: %s", err)
+		return apiErrorDiags(err, "unable to update KeyPairCsr", resourcePingAccessKeyPairCsrSchema())
 	}
 	return nil
 }
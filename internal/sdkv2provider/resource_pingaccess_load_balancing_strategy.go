@@ -20,12 +20,11 @@ func resourcePingAccessLoadBalancingStrategy() *schema.Resource {
 		UpdateContext: resourcePingAccessLoadBalancingStrategyUpdate,
 		DeleteContext: resourcePingAccessLoadBalancingStrategyDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, loadBalancingStrategyNameKey),
 		},
 		Schema: resourcePingAccessLoadBalancingStrategySchema(),
 		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
-			svc := m.(paClient).HighAvailability
-			desc, _, err := svc.GetLoadBalancingStrategyDescriptorsCommand()
+			desc, err := m.(paClient).loadBalancingStrategyDescriptors()
 			if err != nil {
 				return fmt.Errorf("unable to retrieve LoadBalancingStrategy descriptors %s", err)
 			}
@@ -70,11 +69,15 @@ func resourcePingAccessLoadBalancingStrategyCreate(_ context.Context, d *schema.
 
 	result, _, err := svc.AddLoadBalancingStrategyCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create LoadBalancingStrategy: %s", err)
+		return apiErrorDiags(err, "unable to create LoadBalancingStrategy", resourcePingAccessLoadBalancingStrategySchema())
 	}
 
 	d.SetId(result.Id.String())
-	return resourcePingAccessLoadBalancingStrategyReadResult(d, result, svc)
+	desc, err := m.(paClient).loadBalancingStrategyDescriptors()
+	if err != nil {
+		return diag.Errorf("unable to retrieve LoadBalancingStrategy descriptors: %s", err)
+	}
+	return resourcePingAccessLoadBalancingStrategyReadResult(d, result, desc)
 }
 
 func resourcePingAccessLoadBalancingStrategyRead(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -84,12 +87,16 @@ func resourcePingAccessLoadBalancingStrategyRead(_ context.Context, d *schema.Re
 		Id: d.Id(),
 	}
 
-	result, _, err := svc.GetLoadBalancingStrategyCommand(input)
+	result, resp, err := svc.GetLoadBalancingStrategyCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read LoadBalancingStrategy: %s", err)
+		return readErrorDiags(d, resp, err, "LoadBalancingStrategy")
 	}
 
-	return resourcePingAccessLoadBalancingStrategyReadResult(d, result, svc)
+	desc, err := m.(paClient).loadBalancingStrategyDescriptors()
+	if err != nil {
+		return diag.Errorf("unable to retrieve LoadBalancingStrategy descriptors: %s", err)
+	}
+	return resourcePingAccessLoadBalancingStrategyReadResult(d, result, desc)
 }
 
 func resourcePingAccessLoadBalancingStrategyUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -101,11 +108,15 @@ func resourcePingAccessLoadBalancingStrategyUpdate(_ context.Context, d *schema.
 
 	result, _, err := svc.UpdateLoadBalancingStrategyCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update LoadBalancingStrategy: %s", err)
+		return apiErrorDiags(err, "unable to update LoadBalancingStrategy", resourcePingAccessLoadBalancingStrategySchema())
 	}
 
 	d.SetId(result.Id.String())
-	return resourcePingAccessLoadBalancingStrategyReadResult(d, result, svc)
+	desc, err := m.(paClient).loadBalancingStrategyDescriptors()
+	if err != nil {
+		return diag.Errorf("unable to retrieve LoadBalancingStrategy descriptors: %s", err)
+	}
+	return resourcePingAccessLoadBalancingStrategyReadResult(d, result, desc)
 }
 
 func resourcePingAccessLoadBalancingStrategyDelete(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -117,7 +128,7 @@ func resourcePingAccessLoadBalancingStrategyDelete(_ context.Context, d *schema.
 	return nil
 }
 
-func resourcePingAccessLoadBalancingStrategyReadResult(d *schema.ResourceData, input *models.LoadBalancingStrategyView, svc highAvailability.HighAvailabilityAPI) diag.Diagnostics {
+func resourcePingAccessLoadBalancingStrategyReadResult(d *schema.ResourceData, input *models.LoadBalancingStrategyView, desc *models.DescriptorsView) diag.Diagnostics {
 	var diags diag.Diagnostics
 	b, _ := json.Marshal(input.Configuration)
 	config := string(b)
@@ -127,7 +138,6 @@ func resourcePingAccessLoadBalancingStrategyReadResult(d *schema.ResourceData, i
 	//Search the Load Balancing Strategy descriptors for CONCEALED fields, and update the original value back as we cannot use the
 	//encryptedValue provided by the API, whilst this gives us a stable plan - we cannot determine if a CONCEALED value
 	//has changed and needs updating
-	desc, _, _ := svc.GetLoadBalancingStrategyDescriptorsCommand()
 	config = maskConfigFromDescriptors(desc, input.ClassName, originalConfig, config)
 
 	setResourceDataStringWithDiagnostic(d, "name", input.Name, &diags)
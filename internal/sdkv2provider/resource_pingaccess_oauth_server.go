@@ -121,7 +121,7 @@ func resourcePingAccessOAuthServerUpdate(_ context.Context, d *schema.ResourceDa
 	}
 	result, _, err := svc.UpdateAuthorizationServerCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update OAuthServerSettings: %s", err)
+		return apiErrorDiags(err, "unable to update OAuthServerSettings", resourcePingAccessOAuthServerSchema())
 	}
 
 	d.SetId("oauth_server_settings")
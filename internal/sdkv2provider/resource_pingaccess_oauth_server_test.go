@@ -18,7 +18,7 @@ import (
 func TestAccPingAccessOAuthServer(t *testing.T) {
 	resourceName := "pingaccess_oauth_server.demo_pfr"
 
-	canMask := !(paClient{apiVersion: paVersion}).Is61OrAbove()
+	canMask := !paVersionAtLeast("6.1")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
@@ -68,7 +68,7 @@ func TestAccPingAccessOAuthServer(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckPingAccessOAuthServerExists(resourceName),
 				),
-				ExpectError: regexp.MustCompile(`unable to update OAuthServerSettings: Save Failed:\nintrospectionEndpoint contains 1 validation failures:\n\tIntrospection endpoint must be a valid relative path`),
+				ExpectError: regexp.MustCompile(`unable to update OAuthServerSettings: Save Failed(.|\n)*introspection_endpoint(.|\n)*introspectionEndpoint: Introspection endpoint must be a valid relative path`),
 			},
 		},
 	})
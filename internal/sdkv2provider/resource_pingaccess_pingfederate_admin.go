@@ -104,7 +104,7 @@ func resourcePingAccessPingFederateAdminUpdate(_ context.Context, d *schema.Reso
 	}
 	result, _, err := svc.UpdatePingFederateAdminCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update PingFederateAdmin: %s", err)
+		return apiErrorDiags(err, "unable to update PingFederateAdmin", resourcePingAccessPingFederateAdminSchema())
 	}
 
 	d.SetId("pingfederate_admin_settings")
@@ -116,7 +116,7 @@ func resourcePingAccessPingFederateOAuthUpdate(_ context.Context, d *schema.Reso
 	}
 	result, _, err := svc.UpdatePingFederateAccessTokensCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update PingFederateOAuth: %s", err)
+		return apiErrorDiags(err, "unable to update PingFederateOAuth", resourcePingAccessPingFederateOAuthSchema())
 	}
 
 	d.SetId("pingfederate_oauth_settings")
@@ -14,7 +14,7 @@ import (
 )
 
 func TestAccPingAccessPingFederateOAuth61OrBelow(t *testing.T) {
-	if (paClient{apiVersion: paVersion}).Is61OrAbove() {
+	if paVersionAtLeast("6.1") {
 		t.Skipf("This test only runs against PingAccess 5.3 or 6.0, not: %s", paVersion)
 	}
 	resourceName := "pingaccess_pingfederate_oauth.demo_pfo"
@@ -47,7 +47,7 @@ func TestAccPingAccessPingFederateOAuth61OrBelow(t *testing.T) {
 }
 
 func TestAccPingAccessPingFederateOAuth61OrAbove(t *testing.T) {
-	if !(paClient{apiVersion: paVersion}).Is61OrAbove() {
+	if !paVersionAtLeast("6.1") {
 		t.Skipf("This test only runs against PingAccess 6.1 or above, not: %s", paVersion)
 	}
 	resourceName := "pingaccess_pingfederate_oauth.demo_pfo"
@@ -235,7 +235,7 @@ func resourcePingAccessPingFederateRuntimeUpdate(_ context.Context, d *schema.Re
 		}
 		result, _, err := svc.UpdatePingFederateRuntimeCommand(&input)
 		if err != nil {
-			return diag.Errorf("unable to update PingFederateRuntime: %s", err)
+			return apiErrorDiags(err, "unable to update PingFederateRuntime", resourcePingAccessPingFederateRuntimeSchema())
 		}
 
 		d.SetId("pingfederate_runtime_settings")
@@ -16,7 +16,7 @@ import (
 )
 
 func TestAccPingAccessPingFederateRuntimeIssuer(t *testing.T) {
-	if !(paClient{apiVersion: paVersion}).Is60OrAbove() {
+	if !paVersionAtLeast("6.0") {
 		t.Skipf("This test only runs against PingAccess 6.0 and above, not: %s", paVersion)
 	}
 	resourceName := "pingaccess_pingfederate_runtime.demo"
@@ -59,7 +59,7 @@ func TestAccPingAccessPingFederateRuntimeIssuer(t *testing.T) {
 }
 
 func TestAccPingAccessPingFederateRuntimeDeprecatedRuntime(t *testing.T) {
-	if !(paClient{apiVersion: paVersion}).Is62OrAbove() {
+	if !paVersionAtLeast("6.2") {
 		t.Skipf("This test only runs against PingAccess 5.3, 6.0 or 6.1, not: %s", paVersion)
 	}
 	resourceName := "pingaccess_pingfederate_runtime.demo"
@@ -111,7 +111,7 @@ func TestAccPingAccessPingFederateRuntimeDeprecatedRuntime(t *testing.T) {
 }
 
 func TestAccPingAccessPingFederateRuntimeNewConfig(t *testing.T) {
-	if !(paClient{apiVersion: paVersion}).Is61OrAbove() {
+	if !paVersionAtLeast("6.1") {
 		t.Skipf("This test only runs against PingAccess 6.1 or above, not: %s", paVersion)
 	}
 	resourceName := "pingaccess_pingfederate_runtime.demo"
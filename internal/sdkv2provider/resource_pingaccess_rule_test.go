@@ -59,6 +59,43 @@ func TestAccPingAccessRule(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "supported_destinations.1", "Site"),
 				),
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// imported configuration is always structured
+				ImportStateVerifyIgnore: []string{"configuration"},
+			},
+		},
+	})
+}
+
+func TestAccPingAccessRuleStructured(t *testing.T) {
+	resourceName := "pingaccess_rule.acc_test_rule"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		CheckDestroy:             testAccCheckPingAccessRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPingAccessRuleConfigStructured("404"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPingAccessRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "acctest_structured"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.errorResponseCode", "404"),
+					resource.TestCheckResourceAttr(resourceName, "supported_destinations.0", "Agent"),
+					resource.TestCheckResourceAttr(resourceName, "supported_destinations.1", "Site"),
+				),
+			},
+			{
+				Config: testAccPingAccessRuleConfigStructured("403"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckPingAccessRuleExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", "acctest_structured"),
+					resource.TestCheckResourceAttr(resourceName, "configuration.errorResponseCode", "403"),
+				),
+			},
 			{
 				ResourceName:      resourceName,
 				ImportState:       true,
@@ -77,10 +114,6 @@ func testAccPingAccessRuleConfig(configUpdate string) string {
 resource "pingaccess_rule" "acc_test_rule" {
   class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
   name       = "acctest_test"
-  supported_destinations = [
-    "Site",
-    "Agent"
-  ]
   configuration = <<EOF
 		{
 			"cidrNotation": "127.0.0.${pingaccess_virtualhost.unknown_value.id}/32",
@@ -108,6 +141,27 @@ resource "pingaccess_virtualhost" "unknown_value" {
 }`, configUpdate)
 }
 
+func testAccPingAccessRuleConfigStructured(configUpdate string) string {
+	return fmt.Sprintf(`
+resource "pingaccess_rule" "acc_test_rule" {
+  class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
+  name       = "acctest_structured"
+  configuration = {
+    cidrNotation              = "127.0.0.1/32"
+    negate                    = false
+    overrideIpSource          = false
+    headers                   = []
+    headerValueLocation       = "LAST"
+    fallbackToLastHopIp       = true
+    errorResponseCode         = %s
+    errorResponseStatusMsg    = "Forbidden"
+    errorResponseTemplateFile = "policy.error.page.template.html"
+    errorResponseContentType  = "text/html;charset=UTF-8"
+    rejectionHandlingEnabled  = false
+  }
+}`, configUpdate)
+}
+
 func testAccCheckPingAccessRuleExists(n string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -18,10 +18,11 @@ func resourcePingAccessRuleSet() *schema.Resource {
 		UpdateContext: resourcePingAccessRuleSetUpdate,
 		DeleteContext: resourcePingAccessRuleSetDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, rulesetNameKey),
 		},
-		Schema:      resourcePingAccessRuleSetSchema(),
-		Description: `Provides configuration for Rulesets within PingAccess.`,
+		Schema:        resourcePingAccessRuleSetSchema(),
+		CustomizeDiff: ruleSetPolicyDiff,
+		Description:   `Provides configuration for Rulesets within PingAccess.`,
 	}
 }
 
@@ -31,7 +32,7 @@ func resourcePingAccessRuleSetSchema() map[string]*schema.Schema {
 			Type:             schema.TypeString,
 			Required:         true,
 			ValidateDiagFunc: validateRuleOrRuleSet,
-			Description:      "The rule set's element type (what it contains). Can be either `Rule` or `Ruleset`.",
+			Description:      "The rule set's element type (what it contains). Can be either `Rule` or `RuleSet`, the `policy` ids must be of this type.",
 		},
 		"name": {
 			Type:        schema.TypeString,
@@ -82,7 +83,7 @@ func resourcePingAccessRuleSetCreate(ctx context.Context, d *schema.ResourceData
 
 	result, _, err := svc.AddRuleSetCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create RuleSet: %s", err)
+		return apiErrorDiags(err, "unable to create RuleSet", resourcePingAccessRuleSetSchema())
 	}
 
 	d.SetId(result.Id.String())
@@ -91,11 +92,11 @@ func resourcePingAccessRuleSetCreate(ctx context.Context, d *schema.ResourceData
 
 func resourcePingAccessRuleSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	svc := m.(paClient).Rulesets
-	result, _, err := svc.GetRuleSetCommand(&rulesets.GetRuleSetCommandInput{
+	result, resp, err := svc.GetRuleSetCommand(&rulesets.GetRuleSetCommandInput{
 		Id: d.Id(),
 	})
 	if err != nil {
-		return diag.Errorf("unable to read RuleSet: %s", err)
+		return readErrorDiags(d, resp, err, "RuleSet")
 	}
 	return resourcePingAccessRuleSetReadResult(d, result)
 }
@@ -127,7 +128,7 @@ func resourcePingAccessRuleSetUpdate(ctx context.Context, d *schema.ResourceData
 
 	result, _, err := svc.UpdateRuleSetCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update RuleSet: %s", err)
+		return apiErrorDiags(err, "unable to update RuleSet", resourcePingAccessRuleSetSchema())
 	}
 	d.SetId(result.Id.String())
 	return resourcePingAccessRuleSetReadResult(d, result)
@@ -2,6 +2,7 @@ package sdkv2provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/iwarapter/pingaccess-sdk-go/v62/services/rulesets"
@@ -69,6 +70,26 @@ func TestAccPingAccessRuleSet(t *testing.T) {
 	})
 }
 
+func TestAccPingAccessRuleSet_invalidPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: testAccProviders,
+		CheckDestroy:             testAccCheckPingAccessRuleDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "pingaccess_ruleset" "invalid_policy" {
+  name             = "acctest_invalid_policy"
+  success_criteria = "SuccessIfAllSucceed"
+  element_type     = "Rule"
+  policy           = ["999999"]
+}`,
+				ExpectError: regexp.MustCompile(`policy.0: the Rule 999999 does not exist`),
+			},
+		},
+	})
+}
+
 func testAccPingAccessRuleSetConfig(configUpdate string) string {
 	return fmt.Sprintf(`
 resource "pingaccess_ruleset" "ruleset_one" {
@@ -81,10 +102,6 @@ resource "pingaccess_ruleset" "ruleset_one" {
 resource "pingaccess_rule" "ruleset_rule_one" {
   class_name = "com.pingidentity.pa.policy.CIDRPolicyInterceptor"
   name       = "acctest_ruleset-rule-one"
-  supported_destinations = [
-    "Site",
-    "Agent"
-  ]
   configuration = <<EOF
 		{
 			"cidrNotation": "127.0.0.1/32",
@@ -17,7 +17,7 @@ func resourcePingAccessSite() *schema.Resource {
 		UpdateContext: resourcePingAccessSiteUpdate,
 		DeleteContext: resourcePingAccessSiteDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, siteNameKey),
 		},
 		Schema:      resourcePingAccessSiteSchema(),
 		Description: `Provides configuration for Sites within PingAccess.`,
@@ -130,7 +130,7 @@ func resourcePingAccessSiteCreate(_ context.Context, d *schema.ResourceData, m i
 
 	result, _, err := svc.AddSiteCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create Site: %s", err)
+		return apiErrorDiags(err, "unable to create Site", resourcePingAccessSiteSchema())
 	}
 
 	d.SetId(result.Id.String())
@@ -142,22 +142,28 @@ func resourcePingAccessSiteRead(_ context.Context, d *schema.ResourceData, m int
 	input := &sites.GetSiteCommandInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetSiteCommand(input)
+	result, resp, err := svc.GetSiteCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read Site: %s", err)
+		return readErrorDiags(d, resp, err, "Site")
 	}
 	return resourcePingAccessSiteReadResult(d, result)
 }
 
 func resourcePingAccessSiteUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	svc := m.(paClient).Sites
+	client := m.(paClient)
 	input := sites.UpdateSiteCommandInput{
 		Body: *resourcePingAccessSiteReadData(d),
 		Id:   d.Id(),
 	}
-	result, _, err := svc.UpdateSiteCommand(&input)
+	var result *models.SiteView
+	var err error
+	if client.V7 != nil {
+		result, _, err = client.V7.UpdateSiteCommand(input.Id, &input.Body)
+	} else {
+		result, _, err = client.Sites.UpdateSiteCommand(&input)
+	}
 	if err != nil {
-		return diag.Errorf("unable to update Site: %s", err)
+		return apiErrorDiags(err, "unable to update Site", resourcePingAccessSiteSchema())
 	}
 	return resourcePingAccessSiteReadResult(d, result)
 }
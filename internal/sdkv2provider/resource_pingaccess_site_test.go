@@ -85,6 +85,12 @@ func TestAccPingAccessSite(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "name=acctest_foo",
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
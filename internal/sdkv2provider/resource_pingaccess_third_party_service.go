@@ -17,7 +17,7 @@ func resourcePingAccessThirdPartyService() *schema.Resource {
 		UpdateContext: resourcePingAccessThirdPartyServiceUpdate,
 		DeleteContext: resourcePingAccessThirdPartyServiceDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, thirdPartyServiceNameKey),
 		},
 		Schema:      resourcePingAccessThirdPartyServiceSchema(),
 		Description: `Provides configuration for Third Party Services within PingAccess.`,
@@ -102,7 +102,7 @@ func resourcePingAccessThirdPartyServiceCreate(ctx context.Context, d *schema.Re
 
 	result, _, err := svc.AddThirdPartyServiceCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create ThirdPartyService: %s", err)
+		return apiErrorDiags(err, "unable to create ThirdPartyService", resourcePingAccessThirdPartyServiceSchema())
 
 	}
 
@@ -115,9 +115,9 @@ func resourcePingAccessThirdPartyServiceRead(ctx context.Context, d *schema.Reso
 	input := &thirdPartyServices.GetThirdPartyServiceCommandInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetThirdPartyServiceCommand(input)
+	result, resp, err := svc.GetThirdPartyServiceCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read ThirdPartyService: %s", err)
+		return readErrorDiags(d, resp, err, "ThirdPartyService")
 	}
 	return resourcePingAccessThirdPartyServiceReadResult(d, result)
 }
@@ -130,7 +130,7 @@ func resourcePingAccessThirdPartyServiceUpdate(ctx context.Context, d *schema.Re
 	}
 	result, _, err := svc.UpdateThirdPartyServiceCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update ThirdPartyService: %s", err)
+		return apiErrorDiags(err, "unable to update ThirdPartyService", resourcePingAccessThirdPartyServiceSchema())
 	}
 	return resourcePingAccessThirdPartyServiceReadResult(d, result)
 }
@@ -19,7 +19,7 @@ func resourcePingAccessTrustedCertificateGroups() *schema.Resource {
 		UpdateContext: resourcePingAccessTrustedCertificateGroupsUpdate,
 		DeleteContext: resourcePingAccessTrustedCertificateGroupsDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, trustedCertificateGroupNameKey),
 		},
 		Schema:      resourcePingAccessTrustedCertificateGroupsSchema(),
 		Description: `Provides configuration for Trusted Certificate Groups within PingAccess.`,
@@ -76,7 +76,7 @@ func resourcePingAccessTrustedCertificateGroupsCreate(_ context.Context, d *sche
 
 	result, _, err := svc.AddTrustedCertificateGroupCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create TrustedCertificateGroup: %s", err)
+		return apiErrorDiags(err, "unable to create TrustedCertificateGroup", resourcePingAccessTrustedCertificateGroupsSchema())
 	}
 
 	d.SetId(result.Id.String())
@@ -88,9 +88,9 @@ func resourcePingAccessTrustedCertificateGroupsRead(_ context.Context, d *schema
 	input := &trustedCertificateGroups.GetTrustedCertificateGroupCommandInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetTrustedCertificateGroupCommand(input)
+	result, resp, err := svc.GetTrustedCertificateGroupCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read TrustedCertificateGroup: %s", err)
+		return readErrorDiags(d, resp, err, "TrustedCertificateGroup")
 	}
 	return resourcePingAccessTrustedCertificateGroupsReadResult(d, result)
 }
@@ -103,7 +103,7 @@ func resourcePingAccessTrustedCertificateGroupsUpdate(_ context.Context, d *sche
 	}
 	result, _, err := svc.UpdateTrustedCertificateGroupCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update TrustedCertificateGroup: %s", err)
+		return apiErrorDiags(err, "unable to update TrustedCertificateGroup", resourcePingAccessTrustedCertificateGroupsSchema())
 	}
 	return resourcePingAccessTrustedCertificateGroupsReadResult(d, result)
 }
@@ -18,7 +18,7 @@ func resourcePingAccessVirtualHost() *schema.Resource {
 		UpdateContext: resourcePingAccessVirtualHostUpdate,
 		DeleteContext: resourcePingAccessVirtualHostDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, virtualHostKey),
 		},
 		Schema:      resourcePingAccessVirtualHostSchema(),
 		Description: `Provides configuration for Virtualhosts within PingAccess.`,
@@ -66,7 +66,7 @@ func resourcePingAccessVirtualHostCreate(_ context.Context, d *schema.ResourceDa
 
 	result, _, err := svc.AddVirtualHostCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create VirtualHost: %s", err)
+		return apiErrorDiags(err, "unable to create VirtualHost", resourcePingAccessVirtualHostSchema())
 	}
 
 	d.SetId(result.Id.String())
@@ -78,9 +78,9 @@ func resourcePingAccessVirtualHostRead(_ context.Context, d *schema.ResourceData
 	input := &virtualhosts.GetVirtualHostCommandInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetVirtualHostCommand(input)
+	result, resp, err := svc.GetVirtualHostCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read VirtualHost: %s", err)
+		return readErrorDiags(d, resp, err, "VirtualHost")
 	}
 	return resourcePingAccessVirtualHostReadResult(d, result)
 }
@@ -94,7 +94,7 @@ func resourcePingAccessVirtualHostUpdate(_ context.Context, d *schema.ResourceDa
 
 	result, _, err := svc.UpdateVirtualHostCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to update VirtualHost: %s", err)
+		return apiErrorDiags(err, "unable to update VirtualHost", resourcePingAccessVirtualHostSchema())
 	}
 	return resourcePingAccessVirtualHostReadResult(d, result)
 }
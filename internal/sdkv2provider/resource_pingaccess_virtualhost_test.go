@@ -69,6 +69,12 @@ func TestAccPingAccessVirtualHost(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateId:     "acctest-cheese:3001",
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -18,7 +18,7 @@ func resourcePingAccessWebSession() *schema.Resource {
 		UpdateContext: resourcePingAccessWebSessionUpdate,
 		DeleteContext: resourcePingAccessWebSessionDelete,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByKey(schema.ImportStatePassthroughContext, webSessionNameKey),
 		},
 		Schema:      resourcePingAccessWebSessionSchema(),
 		Description: `Provides configuration for Web Sessions within PingAccess.`,
@@ -181,7 +181,7 @@ func resourcePingAccessWebSessionCreate(_ context.Context, d *schema.ResourceDat
 
 	result, _, err := svc.AddWebSessionCommand(&input)
 	if err != nil {
-		return diag.Errorf("unable to create WebSession: %s", err)
+		return apiErrorDiags(err, "unable to create WebSession", resourcePingAccessWebSessionSchema())
 	}
 
 	d.SetId(result.Id.String())
@@ -193,22 +193,28 @@ func resourcePingAccessWebSessionRead(_ context.Context, d *schema.ResourceData,
 	input := &webSessions.GetWebSessionCommandInput{
 		Id: d.Id(),
 	}
-	result, _, err := svc.GetWebSessionCommand(input)
+	result, resp, err := svc.GetWebSessionCommand(input)
 	if err != nil {
-		return diag.Errorf("unable to read WebSession: %s", err)
+		return readErrorDiags(d, resp, err, "WebSession")
 	}
 	return resourcePingAccessWebSessionReadResult(d, result, m.(paClient).CanMaskPasswords())
 }
 
 func resourcePingAccessWebSessionUpdate(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	svc := m.(paClient).WebSessions
+	client := m.(paClient)
 	input := webSessions.UpdateWebSessionCommandInput{
 		Body: *resourcePingAccessWebSessionReadData(d),
 		Id:   d.Id(),
 	}
-	result, _, err := svc.UpdateWebSessionCommand(&input)
+	var result *models.WebSessionView
+	var err error
+	if client.V7 != nil {
+		result, _, err = client.V7.UpdateWebSessionCommand(input.Id, &input.Body)
+	} else {
+		result, _, err = client.WebSessions.UpdateWebSessionCommand(&input)
+	}
 	if err != nil {
-		return diag.Errorf("unable to update WebSession: %s", err)
+		return apiErrorDiags(err, "unable to update WebSession", resourcePingAccessWebSessionSchema())
 	}
 	return resourcePingAccessWebSessionReadResult(d, result, false)
 }
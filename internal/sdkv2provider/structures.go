@@ -3,6 +3,7 @@ package sdkv2provider
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"hash/crc32"
 	"log"
@@ -10,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/iwarapter/pingaccess-sdk-go/v62/pingaccess/models"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/descriptors"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -331,6 +333,22 @@ func flattenChainCertificates(in []*models.ChainCertificateView) *schema.Set {
 	return schema.NewSet(configFieldHash, m)
 }
 
+// flattenCertificateHash sets the certificate hash of an engine, agent or replica admin, the attributes are empty until
+// the node configuration file has been downloaded.
+func flattenCertificateHash(in *models.Hash, s map[string]interface{}) {
+	s["certificate_hash"] = ""
+	s["certificate_hash_algorithm"] = ""
+	if in == nil {
+		return
+	}
+	if in.HexValue != nil {
+		s["certificate_hash"] = *in.HexValue
+	}
+	if in.Algorithm != nil {
+		s["certificate_hash_algorithm"] = *in.Algorithm
+	}
+}
+
 func configFieldHash(v interface{}) int {
 	var buf bytes.Buffer
 	m := v.(map[string]interface{})
@@ -360,38 +378,18 @@ func flattenLinkView(in *models.LinkView) map[string]interface{} {
 	return s
 }
 
-// Searches a given set of RuleDescriptors for a matching className, when found it will check all fields types for
-// a CONCEALED flag or COMPOSITE if CONCEALED, we massage the configuration to to remove the encryptedValue returned by
-// current API and set the value back to the original defined. For COMPOSITE fields we then iterate recursively on its
-// fields.
-//
-// TODO This has a drawback that we cannot detect drift in CONCEALED fields due to the way the PingAccess API works.
-func maskConfigFromRuleDescriptors(desc *models.RuleDescriptorsView, input *string, originalConfig string, config string) string {
-	for _, value := range desc.Items {
-		if *value.ClassName == *input {
-			config = maskConfigFromRuleDescriptor(value, String(""), originalConfig, config)
-		}
-	}
-	return config
-}
-
-func maskConfigFromRuleDescriptor(desc *models.RuleDescriptorView, input *string, originalConfig string, config string) string {
-	for _, c := range desc.ConfigurationFields {
-		config = maskConfigFromConfigurationField(c, input, originalConfig, config)
-	}
-	return config
-}
-
 // Searches a given set of descriptors for a matching className, when found it will check all fields types for
 // a CONCEALED flag or COMPOSITE if CONCEALED, we massage the configuration to to remove the encryptedValue returned by
 // current API and set the value back to the original defined. For COMPOSITE fields we then iterate recursively on its
-// fields.
+// fields. The result is then overlaid on the original configuration, so only the values which actually differ are
+// changed and omitted defaults do not show as a difference.
 //
 // TODO This has a drawback that we cannot detect drift in CONCEALED fields due to the way the PingAccess API works.
 func maskConfigFromDescriptors(desc *models.DescriptorsView, className *string, originalConfig string, config string) string {
 	for _, value := range desc.Items {
 		if *value.ClassName == *className {
 			config = maskConfigFromDescriptor(value, String(""), originalConfig, config)
+			config = descriptors.Overlay(value.ConfigurationFields, originalConfig, config)
 		}
 	}
 	return config
@@ -438,78 +436,39 @@ func descriptorsHasClassName(className string, desc *models.DescriptorsView) err
 	return fmt.Errorf("unable to find className '%s' available classNames: %s", className, strings.Join(classes, ", "))
 }
 
-// Checks the class name specified exists in the DescriptorsView
+// Validates the configuration against the descriptor for the class name
 func validateConfiguration(className string, d *schema.ResourceDiff, desc *models.DescriptorsView) error {
-	var diags diag.Diagnostics
-	conf := d.Get("configuration").(string)
-	if conf == "" {
-		log.Println("[INFO] configuration is in a potentially unknown state, gracefully skipping configuration validation")
-		return nil
-	}
 	for _, value := range desc.Items {
 		if *value.ClassName == className {
-			for _, f := range value.ConfigurationFields {
-				if *f.Required {
-					if v := gjson.Get(conf, *f.Name); !v.Exists() {
-						diags = append(diags, diag.Errorf("the field '%s' is required for the class_name '%s'", *f.Name, className)...)
-					}
-				}
-			}
-		}
-	}
-	if diags.HasError() {
-		msgs := []string{
-			"configuration validation failed against the class descriptor definition",
-		}
-		for _, diagnostic := range diags {
-			msgs = append(msgs, diagnostic.Summary)
+			return validateConfigurationFields(className, d, value.ConfigurationFields)
 		}
-		return fmt.Errorf(strings.Join(msgs, "\n"))
 	}
 	return nil
 }
 
-// Checks the class name specified exists in the RuleDescriptorsView
-func ruleDescriptorsHasClassName(className string, desc *models.RuleDescriptorsView) error {
-	var classes []string
-	for _, value := range desc.Items {
-		classes = append(classes, *value.ClassName)
-		if *value.ClassName == className {
-			return nil
-		}
-	}
-	return fmt.Errorf("unable to find className '%s' available classNames: %s", className, strings.Join(classes, ", "))
-}
-
-// Checks all the fields in the Rule descriptor to ensure all required fields are set
-func validateRulesConfiguration(className string, d *schema.ResourceDiff, desc *models.RuleDescriptorsView) error {
-	var diags diag.Diagnostics
+// Checks the configuration satisfies the descriptor fields, ensuring all required fields are set and the values match
+// the field types and available options. The configuration is a json string so the path of each field is included in
+// the error.
+func validateConfigurationFields(className string, d *schema.ResourceDiff, fields []*models.ConfigurationField) error {
 	conf := d.Get("configuration").(string)
 	if conf == "" {
 		log.Println("[INFO] configuration is in a potentially unknown state, gracefully skipping configuration validation")
 		return nil
 	}
-	for _, value := range desc.Items {
-		if *value.ClassName == className {
-			for _, f := range value.ConfigurationFields {
-				if *f.Required {
-					if v := gjson.Get(conf, *f.Name); !v.Exists() {
-						diags = append(diags, diag.Errorf("the field '%s' is required for the class_name '%s'", *f.Name, className)...)
-					}
-				}
-			}
-		}
+	violations, err := descriptors.ValidateConfiguration(className, fields, conf)
+	if err != nil {
+		return err
 	}
-	if diags.HasError() {
-		msgs := []string{
-			"configuration validation failed against the class descriptor definition",
-		}
-		for _, diagnostic := range diags {
-			msgs = append(msgs, diagnostic.Summary)
-		}
-		return fmt.Errorf(strings.Join(msgs, "\n"))
+	if len(violations) == 0 {
+		return nil
 	}
-	return nil
+	msgs := []string{
+		"configuration validation failed against the class descriptor definition",
+	}
+	for _, v := range violations {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", v, v.Message))
+	}
+	return errors.New(strings.Join(msgs, "\n"))
 }
 
 // String hashes a string to a unique hashcode.
@@ -623,3 +582,351 @@ func flattenResponseGenerator(in *models.ResponseGeneratorView) []interface{} {
 
 	return m
 }
+
+func expandQueryParamConfig(in []interface{}) *models.QueryParamConfigView {
+	qpc := &models.QueryParamConfigView{Params: []*models.QueryParamPairView{}}
+	for _, raw := range in {
+		if raw == nil {
+			continue
+		}
+		l := raw.(map[string]interface{})
+		qpc.MatchesNoParams = Bool(l["matches_no_params"].(bool))
+		for _, p := range l["params"].([]interface{}) {
+			param := p.(map[string]interface{})
+			pair := &models.QueryParamPairView{}
+			for _, n := range param["name"].([]interface{}) {
+				name := n.(map[string]interface{})
+				pair.Name = &models.QueryParamNameView{
+					Pattern: String(name["pattern"].(string)),
+					Type:    String(name["type"].(string)),
+				}
+			}
+			for _, v := range param["value"].([]interface{}) {
+				value := &models.QueryParamValueView{MatchAny: Bool(false)}
+				if v != nil {
+					val := v.(map[string]interface{})
+					value.MatchAny = Bool(val["match_any"].(bool))
+					if pattern := val["pattern"].(string); pattern != "" {
+						value.Pattern = String(pattern)
+					}
+					if t := val["type"].(string); t != "" {
+						value.Type = String(t)
+					}
+				}
+				pair.Value = value
+			}
+			qpc.Params = append(qpc.Params, pair)
+		}
+	}
+	return qpc
+}
+
+// flattenQueryParamConfig returns no block when PingAccess has no query parameter configuration for the resource or
+// returns the default configuration, which matches no parameters, so that omitting query_param_config does not show
+// as a difference.
+func flattenQueryParamConfig(in *models.QueryParamConfigView) []interface{} {
+	if isDefaultQueryParamConfig(in) {
+		return []interface{}{}
+	}
+	s := map[string]interface{}{
+		"matches_no_params": in.MatchesNoParams != nil && *in.MatchesNoParams,
+	}
+	params := []interface{}{}
+	for _, p := range in.Params {
+		if p == nil {
+			continue
+		}
+		param := make(map[string]interface{})
+		if p.Name != nil {
+			name := make(map[string]interface{})
+			if p.Name.Pattern != nil {
+				name["pattern"] = *p.Name.Pattern
+			}
+			if p.Name.Type != nil {
+				name["type"] = *p.Name.Type
+			}
+			param["name"] = []interface{}{name}
+		}
+		if p.Value != nil {
+			value := make(map[string]interface{})
+			if p.Value.MatchAny != nil {
+				value["match_any"] = *p.Value.MatchAny
+			}
+			if p.Value.Pattern != nil {
+				value["pattern"] = *p.Value.Pattern
+			}
+			if p.Value.Type != nil {
+				value["type"] = *p.Value.Type
+			}
+			param["value"] = []interface{}{value}
+		}
+		params = append(params, param)
+	}
+	s["params"] = params
+	return []interface{}{s}
+}
+
+// isDefaultQueryParamConfig checks whether the query parameter configuration is missing or holds the values PingAccess
+// returns when none is configured.
+func isDefaultQueryParamConfig(in *models.QueryParamConfigView) bool {
+	if in == nil {
+		return true
+	}
+	if in.MatchesNoParams != nil && *in.MatchesNoParams {
+		return false
+	}
+	for _, p := range in.Params {
+		if p != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// descriptorSchema returns the attributes describing a plugin descriptor, rule descriptors also include the category,
+// modes and agent caching details.
+func descriptorSchema(rule bool) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"class_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The plugin class name.",
+		},
+		"label": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The plugin label as displayed in the PingAccess admin UI.",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The plugin type.",
+		},
+		"configuration_fields": descriptorFieldsSchema(descriptorFieldDepth),
+	}
+	if rule {
+		s["category"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The rule category.",
+		}
+		s["modes"] = &schema.Schema{
+			Type:        schema.TypeSet,
+			Computed:    true,
+			Description: "The destinations the rule can be applied to, e.g. `Site` or `Agent`.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		}
+		s["agent_caching_disabled"] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the rule prevents agents from caching the policy decision.",
+		}
+	}
+	return s
+}
+
+// descriptorFieldDepth is the number of levels of TABLE and COMPOSITE child fields exposed by the descriptor data
+// sources, schemas cannot be recursive so the field tree is cut off below this depth.
+const descriptorFieldDepth = 3
+
+// descriptorFieldsSchema returns the schema of the configuration fields of a descriptor, including the child fields of
+// TABLE and COMPOSITE fields down to the given depth.
+func descriptorFieldsSchema(depth int) *schema.Schema {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the field within the plugin `configuration`.",
+		},
+		"label": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The field label.",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The field type, e.g. `TEXT`, `SELECT`, `CONCEALED`, `TABLE` or `COMPOSITE`.",
+		},
+		"required": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the field is required.",
+		},
+		"advanced": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the field is an advanced field.",
+		},
+		"default": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The default value of the field.",
+		},
+		"options": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The options available for the field.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"value": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The option value.",
+					},
+					"label": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The option label.",
+					},
+					"category": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The option category.",
+					},
+				},
+			},
+		},
+		"help": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The help for the field.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"content": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The help content.",
+					},
+					"title": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The help title.",
+					},
+					"url": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "A link to further documentation.",
+					},
+				},
+			},
+		},
+	}
+	if depth > 1 {
+		s["fields"] = descriptorFieldsSchema(depth - 1)
+		s["fields"].Description = "The child fields of a `TABLE` or `COMPOSITE` field."
+	}
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The configuration fields of the plugin.",
+		Elem: &schema.Resource{
+			Schema: s,
+		},
+	}
+}
+
+func flattenDescriptorView(in *models.DescriptorView) map[string]interface{} {
+	s := map[string]interface{}{
+		"configuration_fields": flattenConfigurationFields(in.ConfigurationFields, descriptorFieldDepth),
+	}
+	if in.ClassName != nil {
+		s["class_name"] = *in.ClassName
+	}
+	if in.Label != nil {
+		s["label"] = *in.Label
+	}
+	if in.Type != nil {
+		s["type"] = *in.Type
+	}
+	return s
+}
+
+func flattenRuleDescriptorView(in *models.RuleDescriptorView) map[string]interface{} {
+	s := flattenDescriptorView(&models.DescriptorView{
+		ClassName:           in.ClassName,
+		ConfigurationFields: in.ConfigurationFields,
+		Label:               in.Label,
+		Type:                in.Type,
+	})
+	if in.Category != nil {
+		s["category"] = *in.Category
+	}
+	modes := []interface{}{}
+	for _, mode := range in.Modes {
+		if mode != nil {
+			modes = append(modes, *mode)
+		}
+	}
+	s["modes"] = modes
+	if in.AgentCachingDisabled != nil {
+		s["agent_caching_disabled"] = *in.AgentCachingDisabled
+	}
+	return s
+}
+
+func flattenConfigurationFields(in []*models.ConfigurationField, depth int) []interface{} {
+	m := []interface{}{}
+	for _, f := range in {
+		if f == nil {
+			continue
+		}
+		s := make(map[string]interface{})
+		if f.Name != nil {
+			s["name"] = *f.Name
+		}
+		if f.Label != nil {
+			s["label"] = *f.Label
+		}
+		if f.Type != nil {
+			s["type"] = *f.Type
+		}
+		if f.Required != nil {
+			s["required"] = *f.Required
+		}
+		if f.Advanced != nil {
+			s["advanced"] = *f.Advanced
+		}
+		if f.Default != nil {
+			s["default"] = *f.Default
+		}
+		options := []interface{}{}
+		for _, o := range f.Options {
+			if o == nil {
+				continue
+			}
+			option := make(map[string]interface{})
+			if o.Value != nil {
+				option["value"] = *o.Value
+			}
+			if o.Label != nil {
+				option["label"] = *o.Label
+			}
+			if o.Category != nil {
+				option["category"] = *o.Category
+			}
+			options = append(options, option)
+		}
+		s["options"] = options
+		if f.Help != nil {
+			help := make(map[string]interface{})
+			if f.Help.Content != nil {
+				help["content"] = *f.Help.Content
+			}
+			if f.Help.Title != nil {
+				help["title"] = *f.Help.Title
+			}
+			if f.Help.Url != nil {
+				help["url"] = *f.Help.Url
+			}
+			s["help"] = []interface{}{help}
+		}
+		if depth > 1 {
+			s["fields"] = flattenConfigurationFields(f.Fields, depth-1)
+		}
+		m = append(m, s)
+	}
+	return m
+}
@@ -136,3 +136,158 @@ func Test_maskConfigFromConfigurationField(t *testing.T) {
 		})
 	}
 }
+
+func Test_maskConfigFromDescriptors(t *testing.T) {
+	desc := &models.DescriptorsView{Items: []*models.DescriptorView{{
+		ClassName: String("something"),
+		ConfigurationFields: []*models.ConfigurationField{
+			{Name: String("errorResponseCode"), Type: String("TEXT"), Default: String("403")},
+			{Name: String("negate"), Type: String("CHECKBOX")},
+			{Name: String("username"), Type: String("TEXT")},
+		},
+	}}}
+	tests := []struct {
+		name           string
+		config         string
+		originalConfig string
+		want           string
+	}{
+		{
+			name:           "omitted defaults keep the original configuration",
+			config:         "{\"errorResponseCode\":403,\"negate\":false,\"username\":\"cheese\"}",
+			originalConfig: "{\"username\":\"cheese\"}",
+			want:           "{\"username\":\"cheese\"}",
+		},
+		{
+			name:           "drift only overwrites the differing values",
+			config:         "{\"errorResponseCode\":404,\"negate\":false,\"username\":\"cheese\"}",
+			originalConfig: "{\"username\":\"cheese\"}",
+			want:           "{\"errorResponseCode\":404,\"username\":\"cheese\"}",
+		},
+		{
+			name:           "an empty value matches an unset field",
+			config:         "{\"errorResponseCode\":403,\"negate\":false,\"username\":\"\"}",
+			originalConfig: "{\n  \"negate\": false\n}",
+			want:           "{\n  \"negate\": false\n}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := maskConfigFromDescriptors(desc, String("something"), tt.originalConfig, tt.config); got != tt.want {
+				t.Errorf("maskConfigFromDescriptors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_weCanFlattenRuleDescriptorView(t *testing.T) {
+	initialRuleDescriptorView := &models.RuleDescriptorView{
+		AgentCachingDisabled: Bool(false),
+		Category:             String("AccessControl"),
+		ClassName:            String("com.pingidentity.pa.policy.CIDRPolicyInterceptor"),
+		Label:                String("Network Range"),
+		Modes:                []*string{String("Site"), String("Agent")},
+		Type:                 String("Rule"),
+		ConfigurationFields: []*models.ConfigurationField{
+			{
+				Name:     String("cidrNotation"),
+				Label:    String("Network Range"),
+				Type:     String("TEXT"),
+				Required: Bool(true),
+				Advanced: Bool(false),
+				Help:     &models.Help{Content: String("The network range."), Url: String("https://example.com")},
+			},
+			{
+				Name:    String("headers"),
+				Type:    String("TABLE"),
+				Default: String(""),
+				Fields: []*models.ConfigurationField{
+					{
+						Name:    String("type"),
+						Type:    String("SELECT"),
+						Options: []*models.ConfigurationOption{{Value: String("A"), Label: String("Option A")}},
+					},
+				},
+			},
+		},
+	}
+
+	output := map[string]interface{}{
+		"agent_caching_disabled": false,
+		"category":               "AccessControl",
+		"class_name":             "com.pingidentity.pa.policy.CIDRPolicyInterceptor",
+		"label":                  "Network Range",
+		"modes":                  []interface{}{"Site", "Agent"},
+		"type":                   "Rule",
+		"configuration_fields": []interface{}{
+			map[string]interface{}{
+				"name":     "cidrNotation",
+				"label":    "Network Range",
+				"type":     "TEXT",
+				"required": true,
+				"advanced": false,
+				"options":  []interface{}{},
+				"help":     []interface{}{map[string]interface{}{"content": "The network range.", "url": "https://example.com"}},
+				"fields":   []interface{}{},
+			},
+			map[string]interface{}{
+				"name":    "headers",
+				"type":    "TABLE",
+				"default": "",
+				"options": []interface{}{},
+				"fields": []interface{}{
+					map[string]interface{}{
+						"name":    "type",
+						"type":    "SELECT",
+						"options": []interface{}{map[string]interface{}{"value": "A", "label": "Option A"}},
+						"fields":  []interface{}{},
+					},
+				},
+			},
+		},
+	}
+
+	flattened := flattenRuleDescriptorView(initialRuleDescriptorView)
+
+	equals(t, output, flattened)
+}
+
+func Test_queryParamConfigRoundTrip(t *testing.T) {
+	config := []interface{}{
+		map[string]interface{}{
+			"matches_no_params": true,
+			"params": []interface{}{
+				map[string]interface{}{
+					"name":  []interface{}{map[string]interface{}{"pattern": "version", "type": "EXACT"}},
+					"value": []interface{}{map[string]interface{}{"match_any": false, "pattern": "v2*", "type": "WILDCARD"}},
+				},
+				map[string]interface{}{
+					"name":  []interface{}{map[string]interface{}{"pattern": "debug", "type": "EXACT"}},
+					"value": []interface{}{map[string]interface{}{"match_any": true, "pattern": "", "type": ""}},
+				},
+			},
+		},
+	}
+
+	expanded := expandQueryParamConfig(config)
+	equals(t, true, *expanded.MatchesNoParams)
+	equals(t, 2, len(expanded.Params))
+	equals(t, "version", *expanded.Params[0].Name.Pattern)
+	equals(t, "v2*", *expanded.Params[0].Value.Pattern)
+	equals(t, true, *expanded.Params[1].Value.MatchAny)
+	equals(t, (*string)(nil), expanded.Params[1].Value.Pattern)
+
+	flattened := flattenQueryParamConfig(expanded)
+	params := flattened[0].(map[string]interface{})["params"].([]interface{})
+	equals(t, config[0].(map[string]interface{})["params"].([]interface{})[0], params[0])
+	equals(t, []interface{}{map[string]interface{}{"match_any": true}}, params[1].(map[string]interface{})["value"])
+}
+
+func Test_flattenQueryParamConfigEmpty(t *testing.T) {
+	equals(t, []interface{}{}, flattenQueryParamConfig(nil))
+	equals(t, []interface{}{}, flattenQueryParamConfig(&models.QueryParamConfigView{MatchesNoParams: Bool(false), Params: []*models.QueryParamPairView{}}))
+	equals(t, []interface{}{}, flattenQueryParamConfig(&models.QueryParamConfigView{}))
+	equals(t, []interface{}{}, flattenQueryParamConfig(expandQueryParamConfig([]interface{}{nil})))
+	matchesNoParams := []interface{}{map[string]interface{}{"matches_no_params": true, "params": []interface{}{}}}
+	equals(t, matchesNoParams, flattenQueryParamConfig(&models.QueryParamConfigView{MatchesNoParams: Bool(true)}))
+}
@@ -1,6 +1,9 @@
 package sdkv2provider
 
 import (
+	"encoding/json"
+	"strings"
+
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 )
@@ -108,3 +111,20 @@ func validateResourceType(value interface{}, _ cty.Path) diag.Diagnostics {
 	}
 	return nil
 }
+
+func validateJSONObject(value interface{}, _ cty.Path) diag.Diagnostics {
+	v := value.(string)
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(v), &obj); err != nil {
+		return diag.Errorf("must be a JSON object: %s", err)
+	}
+	return nil
+}
+
+func validatePluginDescriptorType(value interface{}, _ cty.Path) diag.Diagnostics {
+	v := value.(string)
+	if _, ok := pluginDescriptorTypes[v]; !ok {
+		return diag.Errorf("must be one of '%s' not %s", strings.Join(pluginDescriptorTypeNames(), "', '"), v)
+	}
+	return nil
+}
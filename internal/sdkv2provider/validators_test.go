@@ -403,3 +403,59 @@ func Test_validateListLocationValue(t *testing.T) {
 		})
 	}
 }
+
+func Test_validateJSONObject(t *testing.T) {
+	tests := []struct {
+		name   string
+		value  interface{}
+		errors bool
+	}{
+		{name: "object passes", value: `{"foo": true}`},
+		{name: "empty object passes", value: `{}`},
+		{name: "array does not pass", value: `[]`, errors: true},
+		{name: "junk does not pass", value: `foo`, errors: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := validateJSONObject(tc.value, cty.Path{})
+			if diags.HasError() != tc.errors {
+				t.Fatalf("%s: expected errors %v, got %v", tc.name, tc.errors, diags)
+			}
+		})
+	}
+}
+
+func Test_validatePluginDescriptorType(t *testing.T) {
+	tests := []struct {
+		name          string
+		value         interface{}
+		expectedDiags diag.Diagnostics
+	}{
+		{
+			name:          "site_authenticator passes",
+			value:         "site_authenticator",
+			expectedDiags: nil,
+		},
+		{
+			name:          "rule does not pass",
+			value:         "rule",
+			expectedDiags: diag.Errorf("must be one of 'access_token_validator', 'availability_profile', 'hsm_provider', 'identity_mapping', 'load_balancing_strategy', 'rejection_handler', 'site_authenticator' not rule"),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := validatePluginDescriptorType(tc.value, cty.Path{})
+			if len(diags) != len(tc.expectedDiags) {
+				t.Fatalf("%s: wrong number of diags, expected %d, got %d", tc.name, len(tc.expectedDiags), len(diags))
+			}
+			for j := range diags {
+				if diags[j].Severity != tc.expectedDiags[j].Severity {
+					t.Fatalf("%s: expected severity %v, got %v", tc.name, tc.expectedDiags[j].Severity, diags[j].Severity)
+				}
+				if diags[j].Summary != tc.expectedDiags[j].Summary {
+					t.Fatalf("%s: summary does not match expected: %v, got %v", tc.name, tc.expectedDiags[j].Summary, diags[j].Summary)
+				}
+			}
+		})
+	}
+}
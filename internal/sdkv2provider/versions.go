@@ -0,0 +1,101 @@
+package sdkv2provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	goversion "github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/paversion"
+)
+
+// parsePingAccessVersion parses the version reported by the PingAccess API, see paversion.Parse.
+func parsePingAccessVersion(v string) (*goversion.Version, error) {
+	return paversion.Parse(v)
+}
+
+// versionAtLeast checks whether we are running against the given PingAccess version or above.
+func (c paClient) versionAtLeast(v string) bool {
+	return paversion.AtLeast(c.apiVersion, v)
+}
+
+// supports checks whether the PingAccess version satisfies the version requirements for the given key, keys without
+// any declared requirement are always supported.
+func (c paClient) supports(key string) bool {
+	return c.checkVersionRequirement(key) == ""
+}
+
+// checkVersionRequirement returns a description of the version constraint which is not satisfied for the given key,
+// the requirements are declared in paversion so both providers gate on the same versions.
+func (c paClient) checkVersionRequirement(key string) string {
+	return paversion.Unsatisfied(c.apiVersion, key)
+}
+
+// versionRequirementsDiff validates the planned configuration of a resource against the version requirements of the
+// resource and its attributes, so unsupported configuration is reported during plan instead of by the API.
+func versionRequirementsDiff(name string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		client, ok := m.(paClient)
+		if !ok {
+			return nil
+		}
+		if constraint := client.checkVersionRequirement(name); constraint != "" {
+			return fmt.Errorf("%s requires PingAccess %s, the server is running %s", name, constraint, client.apiVersion)
+		}
+		var errs []string
+		for _, attr := range paversion.Attributes(name) {
+			if _, ok := d.GetOk(attr); !ok {
+				continue
+			}
+			if constraint := client.checkVersionRequirement(name + "." + attr); constraint != "" {
+				errs = append(errs, fmt.Sprintf("attribute %q requires PingAccess %s", attr, constraint))
+			}
+		}
+		if len(errs) > 0 {
+			return fmt.Errorf("%s, the server is running %s", strings.Join(errs, ", "), client.apiVersion)
+		}
+		return nil
+	}
+}
+
+// withVersionRequirements adds the version requirement validation to the resources and data sources of the provider.
+func withVersionRequirements(p *schema.Provider) *schema.Provider {
+	for name, r := range p.ResourcesMap {
+		if !hasVersionRequirements(name) {
+			continue
+		}
+		validate := versionRequirementsDiff(name)
+		existing := r.CustomizeDiff
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if err := validate(ctx, d, m); err != nil {
+				return err
+			}
+			if existing != nil {
+				return existing(ctx, d, m)
+			}
+			return nil
+		}
+	}
+	for name, r := range p.DataSourcesMap {
+		if !paversion.Declared(name) || r.ReadContext == nil {
+			continue
+		}
+		name, read := name, r.ReadContext
+		r.ReadContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			if client, ok := m.(paClient); ok {
+				if constraint := client.checkVersionRequirement(name); constraint != "" {
+					return diag.Errorf("%s requires PingAccess %s, the server is running %s", name, constraint, client.apiVersion)
+				}
+			}
+			return read(ctx, d, m)
+		}
+	}
+	return p
+}
+
+// hasVersionRequirements checks whether the type or any of its attributes declare version requirements.
+func hasVersionRequirements(name string) bool {
+	return paversion.Declared(name) || len(paversion.Attributes(name)) > 0
+}
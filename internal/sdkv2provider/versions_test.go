@@ -0,0 +1,122 @@
+package sdkv2provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/paversion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testVersionedClient(t *testing.T, version string) paClient {
+	v, err := parsePingAccessVersion(version)
+	require.NoError(t, err)
+	return paClient{apiVersion: v}
+}
+
+func Test_versionAtLeast(t *testing.T) {
+	tests := []struct {
+		version string
+		min     string
+		expect  bool
+	}{
+		{"5.3.2", "6.0", false},
+		{"6.0.0", "6.0", true},
+		{"6.1.3", "6.2", false},
+		{"6.2.0", "6.2", true},
+		{"6.3.1", "6.2", true},
+		{"7.0.0-SNAPSHOT", "7.0", true},
+		{"10.0", "6.2", true},
+		{"10.0", "9.1", true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s is at least %s", tt.version, tt.min), func(t *testing.T) {
+			assert.Equal(t, tt.expect, testVersionedClient(t, tt.version).versionAtLeast(tt.min))
+		})
+	}
+	assert.False(t, paClient{}.versionAtLeast("6.0"))
+}
+
+func Test_parsePingAccessVersion(t *testing.T) {
+	_, err := parsePingAccessVersion("not-a-version")
+	assert.Error(t, err)
+}
+
+func Test_supports(t *testing.T) {
+	tests := []struct {
+		version string
+		key     string
+		expect  bool
+	}{
+		{"5.3", "pingaccess_acme_server", false},
+		{"6.0", "pingaccess_acme_server", true},
+		{"6.0", "pingaccess_pingfederate_oauth.client_id", true},
+		{"6.1", "pingaccess_pingfederate_oauth.client_id", false},
+		{"6.1", paversion.FeatureKeyPairHiddenFieldPassword, false},
+		{"6.2", paversion.FeatureKeyPairHiddenFieldPassword, true},
+		{"10.0", paversion.FeatureKeyPairHiddenFieldPassword, true},
+		{"5.3", paversion.FeatureKeyPairHsmProvider, false},
+		{"6.0", paversion.FeatureKeyPairHsmProvider, true},
+		{"5.3", "pingaccess_site", true},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s on %s", tt.key, tt.version), func(t *testing.T) {
+			assert.Equal(t, tt.expect, testVersionedClient(t, tt.version).supports(tt.key))
+		})
+	}
+}
+
+func Test_versionRequirementsAreValid(t *testing.T) {
+	p := Provider()
+	for name, r := range p.ResourcesMap {
+		for _, attr := range paversion.Attributes(name) {
+			assert.Contains(t, r.Schema, attr, name)
+		}
+	}
+}
+
+func Test_versionRequirementsDiff(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		raw     map[string]interface{}
+		err     string
+	}{
+		{
+			name:    "unsupported attribute is rejected",
+			version: "5.3.2",
+			raw:     map[string]interface{}{"alias": "test", "hsm_provider_id": 1},
+			err:     `attribute "hsm_provider_id" requires PingAccess >= 6.0, the server is running 5.3.2`,
+		},
+		{
+			name:    "supported attribute is accepted",
+			version: "6.2.0",
+			raw:     map[string]interface{}{"alias": "test", "hsm_provider_id": 1},
+		},
+		{
+			name:    "unset attribute is ignored",
+			version: "5.3.2",
+			raw:     map[string]interface{}{"alias": "test"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Provider().ResourcesMap["pingaccess_keypair"]
+			_, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(tt.raw), testVersionedClient(t, tt.version))
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+
+	t.Run("unsupported resource is rejected", func(t *testing.T) {
+		err := versionRequirementsDiff("pingaccess_acme_server")(context.Background(), &schema.ResourceDiff{}, testVersionedClient(t, "5.3.2"))
+		assert.EqualError(t, err, "pingaccess_acme_server requires PingAccess >= 6.0, the server is running 5.3.2")
+	})
+}
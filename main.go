@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"os"
 
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 	tfmux "github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+	"github.com/iwarapter/terraform-provider-pingaccess/internal/generate"
 	protocol "github.com/iwarapter/terraform-provider-pingaccess/internal/protocolprovider"
 	"github.com/iwarapter/terraform-provider-pingaccess/internal/sdkv2provider"
 )
@@ -17,6 +19,9 @@ func main() {
 	if err != nil {
 		panic(err)
 	}
+	if len(os.Args) > 1 && os.Args[1] == "generate" {
+		os.Exit(generate.Run(ctx, os.Args[2:], factory.ProviderServer(), os.Stdout, os.Stderr))
+	}
 	err = tf5server.Serve("registry.terraform.io/iwarapter/pingaccess", func() tfprotov5.ProviderServer {
 		return factory.ProviderServer()
 	})